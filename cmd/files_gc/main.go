@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/selmison/code-micro-videos/config"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+	"github.com/selmison/code-micro-videos/pkg/storage/sqlboiler"
+)
+
+// files_gc removes the stored files that no video references, it only reports them unless -dry-run=false
+func main() {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	dryRun := flag.Bool("dry-run", true, "only report the orphan files")
+	minAge := flag.Duration("min-age", cfg.FilesGC.MinAge, "spare the files stored more recently")
+	flag.Parse()
+	db, err := sql.Open(cfg.DBDrive, cfg.DBConnStr)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	r := sqlboiler.NewRepository(context.Background(), db, cfg.RepoFiles)
+	report, err := r.CollectOrphanFiles(*minAge, *dryRun)
+	for _, f := range report.Orphans {
+		fmt.Printf("%s/%s\t%d\n", f.VideoID, f.Name, f.Size)
+	}
+	for _, blob := range report.OrphanBlobs {
+		fmt.Printf("%s/%s\t%d\n", files.BlobsDir, blob.Hash, blob.Size)
+	}
+	action := "removed"
+	if report.DryRun {
+		action = "would remove"
+	}
+	fmt.Printf(
+		"scanned %d files, %s %d orphans of %d bytes\n",
+		report.Scanned,
+		action,
+		len(report.Orphans)+len(report.OrphanBlobs),
+		report.Freed,
+	)
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
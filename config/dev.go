@@ -2,9 +2,20 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
 
-func GetConfig() (*Config, error) {
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+	"github.com/selmison/code-micro-videos/pkg/storage/files/disk"
+	"github.com/selmison/code-micro-videos/pkg/storage/files/s3"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
+)
+
+func GetConfig() (Config, error) {
 	dbConnStr := fmt.Sprintf(
 		"host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
 		dbHost,
@@ -14,14 +25,69 @@ func GetConfig() (*Config, error) {
 		dbPass,
 		dbSSLMode,
 	)
-	return &Config{
-		AddressServer: addressServer,
-		DBDrive:       dbDrive,
-		DBName:        dbName,
-		DBPort:        dbPort,
-		DBUser:        dbUser,
-		DBPass:        dbPass,
-		DBSSLMode:     dbSSLMode,
-		DBConnStr:     dbConnStr,
+	repoFiles, err := newRepoFiles()
+	if err != nil {
+		return Config{}, fmt.Errorf("init files repository: %s\n", err)
+	}
+	maxSizes, err := assetMaxSizes()
+	if err != nil {
+		return Config{}, fmt.Errorf("init asset sizes: %s\n", err)
+	}
+	gc, err := filesGC()
+	if err != nil {
+		return Config{}, fmt.Errorf("init files gc: %s\n", err)
+	}
+	authCfg, err := authConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("init auth: %s\n", err)
+	}
+	rateLimit, err := rateLimitConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("init rate limit: %s\n", err)
+	}
+	rootDeprecation, err := rootDeprecationConfig()
+	if err != nil {
+		return Config{}, fmt.Errorf("init root deprecation: %s\n", err)
+	}
+	repoUploadsDir := filepath.Join(ProjectPath, filesRootDir, uploadsDir)
+	if err := os.MkdirAll(repoUploadsDir, 0755); err != nil {
+		return Config{}, fmt.Errorf("init uploads store: %s\n", err)
+	}
+	return Config{
+		AddressServer:   addressServer,
+		DBDrive:         dbDrive,
+		DBName:          dbName,
+		DBPort:          dbPort,
+		DBUser:          dbUser,
+		DBPass:          dbPass,
+		DBSSLMode:       dbSSLMode,
+		DBConnStr:       dbConnStr,
+		RepoFiles:       repoFiles,
+		RepoUploads:     uploads.NewStore(afero.NewBasePathFs(afero.NewOsFs(), repoUploadsDir), uploadsTTL),
+		AssetMaxSizes:   maxSizes,
+		FilesGC:         gc,
+		SearchLanguage:  os.Getenv(envSearchLanguage),
+		Auth:            authCfg,
+		RateLimit:       rateLimit,
+		RootDeprecation: rootDeprecation,
 	}, nil
 }
+
+// newRepoFiles keeps the video files in the S3 bucket named by FILES_S3_BUCKET, or on the local disk when it is unset
+func newRepoFiles() (files.Repository, error) {
+	if bucket := os.Getenv(envS3Bucket); bucket != "" {
+		endpoint := os.Getenv(envS3Endpoint)
+		return s3.NewRepository(s3.Config{
+			Bucket:         bucket,
+			Prefix:         os.Getenv(envS3Prefix),
+			Endpoint:       endpoint,
+			Region:         os.Getenv(envS3Region),
+			ForcePathStyle: endpoint != "",
+		})
+	}
+	return disk.NewRepository(filepath.Join(ProjectPath, filesRootDir))
+}
+
+func (c *Config) TerminateContainer() error {
+	return nil
+}
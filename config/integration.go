@@ -8,10 +8,15 @@ import (
 	"strconv"
 
 	"github.com/docker/go-connections/nat"
+	"github.com/spf13/afero"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/ratelimit"
 	"github.com/selmison/code-micro-videos/pkg/storage/files/memory"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
 )
 
 func GetConfig() (Config, error) {
@@ -32,6 +37,10 @@ func GetConfig() (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("access dbContainer: %s\n", err)
 	}
+	maxSizes, err := assetMaxSizes()
+	if err != nil {
+		return Config{}, fmt.Errorf("init asset sizes: %s\n", err)
+	}
 	dbConnStr := fmt.Sprintf(
 		"host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
 		host,
@@ -53,6 +62,13 @@ func GetConfig() (Config, error) {
 		dbSSLMode,
 		dbConnStr,
 		memory.NewRepository(),
+		uploads.NewStore(afero.NewMemMapFs(), uploadsTTL),
+		maxSizes,
+		FilesGC{},
+		crud.DefaultSearchLanguage,
+		auth.Config{Disabled: true},
+		ratelimit.Config{},
+		Deprecation{},
 	}, nil
 }
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/ratelimit"
 	"github.com/selmison/code-micro-videos/pkg/storage/files"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
 )
 
 const (
@@ -22,6 +29,46 @@ const (
 	dbUser         = "postgres"
 	dbPass         = "postgres"
 	dbSSLMode      = "disable"
+	filesRootDir   = "storage"
+	uploadsDir     = "uploads"
+	uploadsTTL     = 24 * time.Hour
+	envS3Bucket    = "FILES_S3_BUCKET"
+	envS3Prefix    = "FILES_S3_PREFIX"
+	envS3Endpoint  = "FILES_S3_ENDPOINT"
+	envS3Region    = "FILES_S3_REGION"
+
+	envFilesGCInterval = "FILES_GC_INTERVAL"
+	envFilesGCDryRun   = "FILES_GC_DRY_RUN"
+	filesGCInterval    = 24 * time.Hour
+	filesGCMinAge      = time.Hour
+	// envSearchLanguage names the text search configuration of the searches that do not name their own
+	envSearchLanguage = "SEARCH_LANGUAGE"
+	// envAssetMaxSizePrefix followed by the upper case kind, like ASSET_MAX_SIZE_TRAILER, overrides its size in bytes
+	envAssetMaxSizePrefix = "ASSET_MAX_SIZE_"
+	// envAuthJWKS names the file or the URL of the keys that sign the tokens, it is required unless envAuthDisabled
+	// is true, which serves the requests without authenticating them
+	envAuthJWKS       = "AUTH_JWKS"
+	envAuthIssuer     = "AUTH_ISSUER"
+	envAuthAudience   = "AUTH_AUDIENCE"
+	envAuthRolesClaim = "AUTH_ROLES_CLAIM"
+	envAuthDisabled   = "AUTH_DISABLED"
+	// envRateLimitRead and envRateLimitWrite limit the requests of each client like 120/1m, 0 does not limit them.
+	// envRateLimitAddress limits the ones of each address before their client is authenticated.
+	envRateLimitRead    = "RATE_LIMIT_READ"
+	envRateLimitWrite   = "RATE_LIMIT_WRITE"
+	envRateLimitAddress = "RATE_LIMIT_ADDRESS"
+	// envRootDeprecation and envRootSunset tell, like 2026-10-17, since when the paths without a version are
+	// deprecated and until when they are served. They are not deprecated when envRootDeprecation is not set.
+	envRootDeprecation = "API_ROOT_DEPRECATION"
+	envRootSunset      = "API_ROOT_SUNSET"
+)
+
+// rateLimitRead and rateLimitWrite are the limits of each client, rateLimitAddress the one of each address, when
+// the environment does not override them. An address is shared by the clients behind it.
+var (
+	rateLimitRead    = ratelimit.Limit{Requests: 600, Period: time.Minute}
+	rateLimitWrite   = ratelimit.Limit{Requests: 120, Period: time.Minute}
+	rateLimitAddress = ratelimit.Limit{Requests: 1200, Period: time.Minute}
 )
 
 var (
@@ -40,6 +87,33 @@ type Config struct {
 	DBSSLMode     string
 	DBConnStr     string
 	RepoFiles     files.Repository
+	RepoUploads   uploads.Store
+	AssetMaxSizes map[crud.AssetKind]int64
+	FilesGC       FilesGC
+	// SearchLanguage parses the searches that do not name their text search configuration
+	SearchLanguage string
+	// Auth verifies the tokens of the requests, they are not authenticated only when it is disabled
+	Auth auth.Config
+	// RateLimit limits the reads and the writes of each client, and the requests of each address
+	RateLimit ratelimit.Config
+	// RootDeprecation deprecates the paths without a version, which alias the ones of the first version
+	RootDeprecation Deprecation
+}
+
+// Deprecation tells since when a version of the API is deprecated and until when it is served, it is not
+// deprecated when At is zero
+type Deprecation struct {
+	At     time.Time
+	Sunset time.Time
+}
+
+// FilesGC schedules the removal of the stored files that no video references
+type FilesGC struct {
+	// Interval between two collections, zero disables them
+	Interval time.Duration
+	// MinAge spares the files stored for a request still in progress
+	MinAge time.Duration
+	DryRun bool
 }
 
 func init() {
@@ -49,3 +123,118 @@ func init() {
 	}
 	ProjectPath = strings.TrimSpace(string(cmdOut))
 }
+
+// assetMaxSizes reads the sizes of the asset kinds overridden by the environment
+func assetMaxSizes() (map[crud.AssetKind]int64, error) {
+	sizes := make(map[crud.AssetKind]int64)
+	for _, kind := range crud.AssetKinds() {
+		env := envAssetMaxSizePrefix + strings.ToUpper(string(kind))
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("%s should be a positive number of bytes", env)
+		}
+		sizes[kind] = size
+	}
+	return sizes, nil
+}
+
+// filesGC reads the schedule of the garbage collector of the files from the environment
+func filesGC() (FilesGC, error) {
+	gc := FilesGC{Interval: filesGCInterval, MinAge: filesGCMinAge}
+	if value := os.Getenv(envFilesGCInterval); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval < 0 {
+			return FilesGC{}, fmt.Errorf("%s should be a duration like 12h, 0 disables it", envFilesGCInterval)
+		}
+		gc.Interval = interval
+	}
+	if value := os.Getenv(envFilesGCDryRun); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return FilesGC{}, fmt.Errorf("%s should be true or false", envFilesGCDryRun)
+		}
+		gc.DryRun = dryRun
+	}
+	return gc, nil
+}
+
+// authConfig reads from the environment which tokens authenticate the requests, it refuses to leave them
+// unauthenticated unless the authentication is disabled explicitly
+func authConfig() (auth.Config, error) {
+	cfg := auth.Config{
+		JWKS:       os.Getenv(envAuthJWKS),
+		Issuer:     os.Getenv(envAuthIssuer),
+		Audience:   os.Getenv(envAuthAudience),
+		RolesClaim: os.Getenv(envAuthRolesClaim),
+	}
+	if value := os.Getenv(envAuthDisabled); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return auth.Config{}, fmt.Errorf("%s should be true or false", envAuthDisabled)
+		}
+		cfg.Disabled = disabled
+	}
+	if cfg.JWKS == "" && !cfg.Disabled {
+		return auth.Config{}, fmt.Errorf("%s should name the keys of the tokens, %s=true serves the requests without authentication", envAuthJWKS, envAuthDisabled)
+	}
+	return cfg, nil
+}
+
+// rateLimitConfig reads the limits of the requests of each client from the environment
+func rateLimitConfig() (ratelimit.Config, error) {
+	cfg := ratelimit.Config{Read: rateLimitRead, Write: rateLimitWrite, Address: rateLimitAddress}
+	limits := []struct {
+		env   string
+		limit *ratelimit.Limit
+	}{
+		{envRateLimitRead, &cfg.Read},
+		{envRateLimitWrite, &cfg.Write},
+		{envRateLimitAddress, &cfg.Address},
+	}
+	for _, l := range limits {
+		value := os.Getenv(l.env)
+		if value == "" {
+			continue
+		}
+		limit, err := ratelimit.ParseLimit(value)
+		if err != nil {
+			return ratelimit.Config{}, fmt.Errorf("%s should be like 120/1m, 0 does not limit: %w", l.env, err)
+		}
+		*l.limit = limit
+	}
+	return cfg, nil
+}
+
+// rootDeprecationConfig reads the deprecation of the paths without a version from the environment
+func rootDeprecationConfig() (Deprecation, error) {
+	var d Deprecation
+	dates := []struct {
+		env  string
+		date *time.Time
+	}{
+		{envRootDeprecation, &d.At},
+		{envRootSunset, &d.Sunset},
+	}
+	for _, date := range dates {
+		value := os.Getenv(date.env)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return Deprecation{}, fmt.Errorf("%s should be a date like 2026-10-17", date.env)
+		}
+		*date.date = t
+	}
+	if d.At.IsZero() && !d.Sunset.IsZero() {
+		return Deprecation{}, fmt.Errorf("%s should be set with %s", envRootSunset, envRootDeprecation)
+	}
+	if !d.Sunset.IsZero() && d.Sunset.Before(d.At) {
+		return Deprecation{}, fmt.Errorf("%s should not be before %s", envRootSunset, envRootDeprecation)
+	}
+	return d, nil
+}
@@ -1,9 +1,10 @@
 module github.com/selmison/code-micro-videos
 
-go 1.14
+go 1.16
 
 require (
 	github.com/Pallinder/go-randomdata v1.2.0
+	github.com/aws/aws-sdk-go v1.34.0
 	github.com/bluele/factory-go v0.0.0-20200430111232-df9c4ffc2e3e
 	github.com/bxcodec/faker/v3 v3.5.0
 	github.com/docker/go-connections v0.4.0
@@ -13,10 +14,11 @@ require (
 	github.com/golang/mock v1.4.3
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/schema v1.2.0
+	github.com/johannesboyne/gofakes3 v0.0.0-20200716060623-6b2b4cb092cc
 	github.com/julienschmidt/httprouter v1.2.0
 	github.com/lib/pq v1.2.1-0.20191011153232-f91d3411e481
 	github.com/rubenv/sql-migrate v0.0.0-20200616145509-8d140a17f351
-	github.com/spf13/afero v1.1.2
+	github.com/spf13/afero v1.2.1
 	github.com/stretchr/testify v1.6.1
 	github.com/testcontainers/testcontainers-go v0.7.0
 	github.com/volatiletech/null/v8 v8.1.0
@@ -27,4 +29,5 @@ require (
 	golang.org/x/sys v0.0.0-20200724161237-0e2f3a69832c // indirect
 	golang.org/x/text v0.3.3 // indirect
 	google.golang.org/genproto v0.0.0-20191108220845-16a3f7862a1a // indirect
+	gopkg.in/square/go-jose.v2 v2.5.1
 )
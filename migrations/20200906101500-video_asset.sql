@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE video_assets
+(
+    id           uuid         NOT NULL PRIMARY KEY,
+    video_id     uuid         NOT NULL REFERENCES videos (id) ON DELETE CASCADE,
+    kind         varchar(32)  NOT NULL,
+    file_name    varchar(255) NOT NULL,
+    content_type varchar(255) NOT NULL,
+    size         bigint       NOT NULL,
+    created_at   timestamp,
+    updated_at   timestamp,
+    UNIQUE (video_id, kind)
+);
+
+INSERT INTO video_assets (id, video_id, kind, file_name, content_type, size, created_at, updated_at)
+SELECT md5(id::text || 'video')::uuid, id, 'video', video_file, 'application/octet-stream', 0, updated_at, updated_at
+FROM videos
+WHERE video_file IS NOT NULL
+  AND video_file <> '';
+
+ALTER TABLE videos
+    DROP COLUMN video_file;
+
+-- +migrate Down
+ALTER TABLE videos
+    ADD COLUMN video_file varchar(255);
+
+UPDATE videos
+SET video_file = video_assets.file_name
+FROM video_assets
+WHERE video_assets.video_id = videos.id
+  AND video_assets.kind = 'video';
+
+DROP TABLE video_assets;
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- blobs are the content addressed files shared by the video assets, id is the sha256 of the content
+CREATE TABLE blobs
+(
+    id         varchar(64) NOT NULL PRIMARY KEY,
+    size       bigint      NOT NULL,
+    ref_count  integer     NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    created_at timestamp,
+    updated_at timestamp
+);
+
+-- the assets stored before keep their file under the video directory and have no blob
+ALTER TABLE video_assets
+    ADD COLUMN blob_id varchar(64) REFERENCES blobs (id);
+
+CREATE INDEX video_assets_blob_id_idx ON video_assets (blob_id);
+
+-- +migrate Down
+DELETE
+FROM video_assets
+WHERE blob_id IS NOT NULL;
+
+ALTER TABLE video_assets
+    DROP COLUMN blob_id;
+
+DROP TABLE blobs;
@@ -0,0 +1,43 @@
+-- +migrate Up
+-- the lists are paged by (created_at, id), so every row needs a creation time
+UPDATE categories SET created_at = COALESCE(updated_at, now()) WHERE created_at IS NULL;
+UPDATE genres SET created_at = COALESCE(updated_at, now()) WHERE created_at IS NULL;
+UPDATE cast_members SET created_at = COALESCE(updated_at, now()) WHERE created_at IS NULL;
+UPDATE videos SET created_at = COALESCE(updated_at, now()) WHERE created_at IS NULL;
+
+ALTER TABLE categories
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE genres
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE cast_members
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE videos
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX categories_created_at_id_idx ON categories (created_at, id);
+CREATE INDEX genres_created_at_id_idx ON genres (created_at, id);
+CREATE INDEX cast_members_created_at_id_idx ON cast_members (created_at, id);
+CREATE INDEX videos_created_at_id_idx ON videos (created_at, id);
+
+-- +migrate Down
+DROP INDEX videos_created_at_id_idx;
+DROP INDEX cast_members_created_at_id_idx;
+DROP INDEX genres_created_at_id_idx;
+DROP INDEX categories_created_at_id_idx;
+
+ALTER TABLE videos
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE cast_members
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE genres
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE categories
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT;
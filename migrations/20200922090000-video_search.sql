@@ -0,0 +1,22 @@
+-- +migrate Up
+-- language is the text search configuration of the title and the description, like english or portuguese
+ALTER TABLE videos
+    ADD COLUMN language regconfig NOT NULL DEFAULT 'english';
+
+-- search weighs the words of the title above the ones of the description
+ALTER TABLE videos
+    ADD COLUMN search tsvector GENERATED ALWAYS AS (
+                setweight(to_tsvector(language, title), 'A') ||
+                setweight(to_tsvector(language, description), 'B')
+        ) STORED;
+
+CREATE INDEX videos_search_idx ON videos USING GIN (search);
+
+-- +migrate Down
+DROP INDEX videos_search_idx;
+
+ALTER TABLE videos
+    DROP COLUMN search;
+
+ALTER TABLE videos
+    DROP COLUMN language;
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- a cast member may both direct and act in a video, so the role is part of the key
+CREATE TABLE cast_member_video
+(
+    cast_member_id uuid         NOT NULL REFERENCES cast_members (id) ON DELETE CASCADE,
+    video_id       uuid         NOT NULL REFERENCES videos (id) ON DELETE CASCADE,
+    role           smallint     NOT NULL,
+    character_name varchar(255),
+    billing_order  smallint     NOT NULL,
+    PRIMARY KEY (cast_member_id, video_id, role)
+);
+
+CREATE INDEX cast_member_video_video_id_idx ON cast_member_video (video_id, billing_order);
+
+-- +migrate Down
+DROP TABLE cast_member_video;
@@ -0,0 +1,52 @@
+-- +migrate Up
+-- every table keeps its removed rows in the trash by their deletion time, until they are restored or purged
+ALTER TABLE categories
+    ADD COLUMN deleted_at timestamp;
+UPDATE categories SET deleted_at = COALESCE(updated_at, now()) WHERE NOT is_validated;
+UPDATE genres SET deleted_at = COALESCE(updated_at, now()) WHERE NOT is_validated AND deleted_at IS NULL;
+ALTER TABLE categories
+    DROP COLUMN is_validated;
+ALTER TABLE genres
+    DROP COLUMN is_validated;
+
+-- the names only have to be unique out of the trash
+ALTER TABLE categories
+    DROP CONSTRAINT categories_name_key;
+ALTER TABLE genres
+    DROP CONSTRAINT genres_name_key;
+CREATE UNIQUE INDEX categories_name_key ON categories (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX genres_name_key ON genres (name) WHERE deleted_at IS NULL;
+
+CREATE INDEX categories_deleted_at_id_idx ON categories (deleted_at, id) WHERE deleted_at IS NOT NULL;
+CREATE INDEX genres_deleted_at_id_idx ON genres (deleted_at, id) WHERE deleted_at IS NOT NULL;
+CREATE INDEX cast_members_deleted_at_id_idx ON cast_members (deleted_at, id) WHERE deleted_at IS NOT NULL;
+CREATE INDEX videos_deleted_at_id_idx ON videos (deleted_at, id) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX videos_deleted_at_id_idx;
+DROP INDEX cast_members_deleted_at_id_idx;
+DROP INDEX genres_deleted_at_id_idx;
+DROP INDEX categories_deleted_at_id_idx;
+
+-- a name taken again after its row was trashed cannot be unique anymore, so the trashed row is purged
+DELETE FROM categories
+WHERE deleted_at IS NOT NULL
+  AND name IN (SELECT name FROM categories WHERE deleted_at IS NULL);
+DELETE FROM genres
+WHERE deleted_at IS NOT NULL
+  AND name IN (SELECT name FROM genres WHERE deleted_at IS NULL);
+DROP INDEX genres_name_key;
+DROP INDEX categories_name_key;
+ALTER TABLE genres
+    ADD CONSTRAINT genres_name_key UNIQUE (name);
+ALTER TABLE categories
+    ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+ALTER TABLE genres
+    ADD COLUMN is_validated boolean NOT NULL DEFAULT true;
+ALTER TABLE categories
+    ADD COLUMN is_validated boolean NOT NULL DEFAULT true;
+UPDATE genres SET is_validated = false WHERE deleted_at IS NOT NULL;
+UPDATE categories SET is_validated = false WHERE deleted_at IS NOT NULL;
+ALTER TABLE categories
+    DROP COLUMN deleted_at;
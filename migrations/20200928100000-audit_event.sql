@@ -0,0 +1,16 @@
+-- +migrate Up
+-- the events are only appended, the purge of an entity keeps its history
+CREATE TABLE audit_events
+(
+    id          bigserial    NOT NULL PRIMARY KEY,
+    actor       varchar(255) NOT NULL,
+    occurred_at timestamp    NOT NULL DEFAULT now(),
+    entity_type varchar(32)  NOT NULL,
+    entity_id   uuid         NOT NULL,
+    operation   varchar(16)  NOT NULL,
+    diff        jsonb        NOT NULL DEFAULT '{}'::jsonb
+);
+CREATE INDEX audit_events_entity_idx ON audit_events (entity_type, entity_id, occurred_at DESC, id DESC);
+
+-- +migrate Down
+DROP TABLE audit_events;
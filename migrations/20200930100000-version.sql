@@ -0,0 +1,20 @@
+-- +migrate Up
+-- every write of a row moves its version on, a write based on an older version is refused
+ALTER TABLE categories
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE genres
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE cast_members
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE videos
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE videos
+    DROP COLUMN version;
+ALTER TABLE cast_members
+    DROP COLUMN version;
+ALTER TABLE genres
+    DROP COLUMN version;
+ALTER TABLE categories
+    DROP COLUMN version;
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- only the SHA-256 of a key is kept, its prefix tells it apart in the lists
+CREATE TABLE api_keys
+(
+    id           uuid         NOT NULL PRIMARY KEY,
+    name         varchar(255) NOT NULL,
+    prefix       varchar(16)  NOT NULL,
+    hash         char(64)     NOT NULL UNIQUE,
+    scopes       text[]       NOT NULL,
+    created_by   varchar(255) NOT NULL,
+    created_at   timestamp    NOT NULL DEFAULT now(),
+    expires_at   timestamp,
+    last_used_at timestamp,
+    revoked_at   timestamp
+);
+
+-- +migrate Down
+DROP TABLE api_keys;
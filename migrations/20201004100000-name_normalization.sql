@@ -0,0 +1,20 @@
+-- +migrate Up
+-- the names are stored the way they are looked up: in lower case, with each run of spaces made one space and none
+-- around it, so an exact name is compared to the column as it is
+UPDATE categories SET name = lower(btrim(regexp_replace(name, '\s+', ' ', 'g')))
+WHERE name <> lower(btrim(regexp_replace(name, '\s+', ' ', 'g')));
+UPDATE genres SET name = lower(btrim(regexp_replace(name, '\s+', ' ', 'g')))
+WHERE name <> lower(btrim(regexp_replace(name, '\s+', ' ', 'g')));
+UPDATE cast_members SET name = lower(btrim(regexp_replace(name, '\s+', ' ', 'g')))
+WHERE name <> lower(btrim(regexp_replace(name, '\s+', ' ', 'g')));
+UPDATE videos SET title = lower(btrim(regexp_replace(title, '\s+', ' ', 'g')))
+WHERE title <> lower(btrim(regexp_replace(title, '\s+', ' ', 'g')));
+
+-- the names of the categories and the genres are looked up by their unique index, the ones of the cast members and
+-- the titles of the videos by these
+CREATE INDEX cast_members_name_idx ON cast_members (name) WHERE deleted_at IS NULL;
+CREATE INDEX videos_title_idx ON videos (title) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX videos_title_idx;
+DROP INDEX cast_members_name_idx;
@@ -4,21 +4,31 @@
 package models
 
 var TableNames = struct {
-	CastMembers    string
-	Categories     string
-	CategoryGenre  string
-	CategoryVideo  string
-	GenreVideo     string
-	Genres         string
-	GorpMigrations string
-	Videos         string
+	APIKeys         string
+	AuditEvents     string
+	Blobs           string
+	CastMemberVideo string
+	CastMembers     string
+	Categories      string
+	CategoryGenre   string
+	CategoryVideo   string
+	GenreVideo      string
+	Genres          string
+	GorpMigrations  string
+	VideoAssets     string
+	Videos          string
 }{
-	CastMembers:    "cast_members",
-	Categories:     "categories",
-	CategoryGenre:  "category_genre",
-	CategoryVideo:  "category_video",
-	GenreVideo:     "genre_video",
-	Genres:         "genres",
-	GorpMigrations: "gorp_migrations",
-	Videos:         "videos",
+	APIKeys:         "api_keys",
+	AuditEvents:     "audit_events",
+	Blobs:           "blobs",
+	CastMemberVideo: "cast_member_video",
+	CastMembers:     "cast_members",
+	Categories:      "categories",
+	CategoryGenre:   "category_genre",
+	CategoryVideo:   "category_video",
+	GenreVideo:      "genre_video",
+	Genres:          "genres",
+	GorpMigrations:  "gorp_migrations",
+	VideoAssets:     "video_assets",
+	Videos:          "videos",
 }
@@ -0,0 +1,1377 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// CastMemberVideo is an object representing the database table.
+type CastMemberVideo struct {
+	CastMemberID  string      `boil:"cast_member_id" json:"cast_member_id" toml:"cast_member_id" yaml:"cast_member_id"`
+	VideoID       string      `boil:"video_id" json:"video_id" toml:"video_id" yaml:"video_id"`
+	Role          int16       `boil:"role" json:"role" toml:"role" yaml:"role"`
+	CharacterName null.String `boil:"character_name" json:"character_name,omitempty" toml:"character_name" yaml:"character_name,omitempty"`
+	BillingOrder  int16       `boil:"billing_order" json:"billing_order" toml:"billing_order" yaml:"billing_order"`
+
+	R *castMemberVideoR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L castMemberVideoL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var CastMemberVideoColumns = struct {
+	CastMemberID  string
+	VideoID       string
+	Role          string
+	CharacterName string
+	BillingOrder  string
+}{
+	CastMemberID:  "cast_member_id",
+	VideoID:       "video_id",
+	Role:          "role",
+	CharacterName: "character_name",
+	BillingOrder:  "billing_order",
+}
+
+// Generated where
+
+type whereHelperint16 struct{ field string }
+
+func (w whereHelperint16) EQ(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint16) NEQ(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint16) LT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint16) LTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint16) GT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint16) GTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint16) IN(slice []int16) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint16) NIN(slice []int16) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var CastMemberVideoWhere = struct {
+	CastMemberID  whereHelperstring
+	VideoID       whereHelperstring
+	Role          whereHelperint16
+	CharacterName whereHelpernull_String
+	BillingOrder  whereHelperint16
+}{
+	CastMemberID:  whereHelperstring{field: "\"cast_member_video\".\"cast_member_id\""},
+	VideoID:       whereHelperstring{field: "\"cast_member_video\".\"video_id\""},
+	Role:          whereHelperint16{field: "\"cast_member_video\".\"role\""},
+	CharacterName: whereHelpernull_String{field: "\"cast_member_video\".\"character_name\""},
+	BillingOrder:  whereHelperint16{field: "\"cast_member_video\".\"billing_order\""},
+}
+
+// CastMemberVideoRels is where relationship names are stored.
+var CastMemberVideoRels = struct {
+	CastMember string
+	Video      string
+}{
+	CastMember: "CastMember",
+	Video:      "Video",
+}
+
+// castMemberVideoR is where relationships are stored.
+type castMemberVideoR struct {
+	CastMember *CastMember `boil:"CastMember" json:"CastMember" toml:"CastMember" yaml:"CastMember"`
+	Video      *Video      `boil:"Video" json:"Video" toml:"Video" yaml:"Video"`
+}
+
+// NewStruct creates a new relationship struct
+func (*castMemberVideoR) NewStruct() *castMemberVideoR {
+	return &castMemberVideoR{}
+}
+
+// castMemberVideoL is where Load methods for each relationship are stored.
+type castMemberVideoL struct{}
+
+var (
+	castMemberVideoAllColumns            = []string{"cast_member_id", "video_id", "role", "character_name", "billing_order"}
+	castMemberVideoColumnsWithoutDefault = []string{"cast_member_id", "video_id", "role", "character_name", "billing_order"}
+	castMemberVideoColumnsWithDefault    = []string{}
+	castMemberVideoPrimaryKeyColumns     = []string{"cast_member_id", "video_id", "role"}
+)
+
+type (
+	// CastMemberVideoSlice is an alias for a slice of pointers to CastMemberVideo.
+	// This should generally be used opposed to []CastMemberVideo.
+	CastMemberVideoSlice []*CastMemberVideo
+	// CastMemberVideoHook is the signature for custom CastMemberVideo hook methods
+	CastMemberVideoHook func(context.Context, boil.ContextExecutor, *CastMemberVideo) error
+
+	castMemberVideoQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	castMemberVideoType                 = reflect.TypeOf(&CastMemberVideo{})
+	castMemberVideoMapping              = queries.MakeStructMapping(castMemberVideoType)
+	castMemberVideoPrimaryKeyMapping, _ = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, castMemberVideoPrimaryKeyColumns)
+	castMemberVideoInsertCacheMut       sync.RWMutex
+	castMemberVideoInsertCache          = make(map[string]insertCache)
+	castMemberVideoUpdateCacheMut       sync.RWMutex
+	castMemberVideoUpdateCache          = make(map[string]updateCache)
+	castMemberVideoUpsertCacheMut       sync.RWMutex
+	castMemberVideoUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var castMemberVideoBeforeInsertHooks []CastMemberVideoHook
+var castMemberVideoBeforeUpdateHooks []CastMemberVideoHook
+var castMemberVideoBeforeDeleteHooks []CastMemberVideoHook
+var castMemberVideoBeforeUpsertHooks []CastMemberVideoHook
+
+var castMemberVideoAfterInsertHooks []CastMemberVideoHook
+var castMemberVideoAfterSelectHooks []CastMemberVideoHook
+var castMemberVideoAfterUpdateHooks []CastMemberVideoHook
+var castMemberVideoAfterDeleteHooks []CastMemberVideoHook
+var castMemberVideoAfterUpsertHooks []CastMemberVideoHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *CastMemberVideo) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *CastMemberVideo) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *CastMemberVideo) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *CastMemberVideo) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *CastMemberVideo) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *CastMemberVideo) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *CastMemberVideo) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *CastMemberVideo) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *CastMemberVideo) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range castMemberVideoAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCastMemberVideoHook registers your hook function for all future operations.
+func AddCastMemberVideoHook(hookPoint boil.HookPoint, castMemberVideoHook CastMemberVideoHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		castMemberVideoBeforeInsertHooks = append(castMemberVideoBeforeInsertHooks, castMemberVideoHook)
+	case boil.BeforeUpdateHook:
+		castMemberVideoBeforeUpdateHooks = append(castMemberVideoBeforeUpdateHooks, castMemberVideoHook)
+	case boil.BeforeDeleteHook:
+		castMemberVideoBeforeDeleteHooks = append(castMemberVideoBeforeDeleteHooks, castMemberVideoHook)
+	case boil.BeforeUpsertHook:
+		castMemberVideoBeforeUpsertHooks = append(castMemberVideoBeforeUpsertHooks, castMemberVideoHook)
+	case boil.AfterInsertHook:
+		castMemberVideoAfterInsertHooks = append(castMemberVideoAfterInsertHooks, castMemberVideoHook)
+	case boil.AfterSelectHook:
+		castMemberVideoAfterSelectHooks = append(castMemberVideoAfterSelectHooks, castMemberVideoHook)
+	case boil.AfterUpdateHook:
+		castMemberVideoAfterUpdateHooks = append(castMemberVideoAfterUpdateHooks, castMemberVideoHook)
+	case boil.AfterDeleteHook:
+		castMemberVideoAfterDeleteHooks = append(castMemberVideoAfterDeleteHooks, castMemberVideoHook)
+	case boil.AfterUpsertHook:
+		castMemberVideoAfterUpsertHooks = append(castMemberVideoAfterUpsertHooks, castMemberVideoHook)
+	}
+}
+
+// OneG returns a single castMemberVideo record from the query using the global executor.
+func (q castMemberVideoQuery) OneG(ctx context.Context) (*CastMemberVideo, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single castMemberVideo record from the query.
+func (q castMemberVideoQuery) One(ctx context.Context, exec boil.ContextExecutor) (*CastMemberVideo, error) {
+	o := &CastMemberVideo{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for cast_member_video")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all CastMemberVideo records from the query using the global executor.
+func (q castMemberVideoQuery) AllG(ctx context.Context) (CastMemberVideoSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all CastMemberVideo records from the query.
+func (q castMemberVideoQuery) All(ctx context.Context, exec boil.ContextExecutor) (CastMemberVideoSlice, error) {
+	var o []*CastMemberVideo
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to CastMemberVideo slice")
+	}
+
+	if len(castMemberVideoAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all CastMemberVideo records in the query, and panics on error.
+func (q castMemberVideoQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all CastMemberVideo records in the query.
+func (q castMemberVideoQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count cast_member_video rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q castMemberVideoQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q castMemberVideoQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if cast_member_video exists")
+	}
+
+	return count > 0, nil
+}
+
+// CastMember pointed to by the foreign key.
+func (o *CastMemberVideo) CastMember(mods ...qm.QueryMod) castMemberQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.CastMemberID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := CastMembers(queryMods...)
+	queries.SetFrom(query.Query, "\"cast_members\"")
+
+	return query
+}
+
+// Video pointed to by the foreign key.
+func (o *CastMemberVideo) Video(mods ...qm.QueryMod) videoQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.VideoID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Videos(queryMods...)
+	queries.SetFrom(query.Query, "\"videos\"")
+
+	return query
+}
+
+// LoadCastMember allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (castMemberVideoL) LoadCastMember(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCastMemberVideo interface{}, mods queries.Applicator) error {
+	var slice []*CastMemberVideo
+	var object *CastMemberVideo
+
+	if singular {
+		object = maybeCastMemberVideo.(*CastMemberVideo)
+	} else {
+		slice = *maybeCastMemberVideo.(*[]*CastMemberVideo)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &castMemberVideoR{}
+		}
+		args = append(args, object.CastMemberID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &castMemberVideoR{}
+			}
+
+			for _, a := range args {
+				if a == obj.CastMemberID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.CastMemberID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`cast_members`),
+		qm.WhereIn(`cast_members.id in ?`, args...),
+		qmhelper.WhereIsNull(`cast_members.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load CastMember")
+	}
+
+	var resultSlice []*CastMember
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice CastMember")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for cast_members")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for cast_members")
+	}
+
+	if len(castMemberVideoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.CastMember = foreign
+		if foreign.R == nil {
+			foreign.R = &castMemberR{}
+		}
+		foreign.R.CastMemberVideos = append(foreign.R.CastMemberVideos, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.CastMemberID == foreign.ID {
+				local.R.CastMember = foreign
+				if foreign.R == nil {
+					foreign.R = &castMemberR{}
+				}
+				foreign.R.CastMemberVideos = append(foreign.R.CastMemberVideos, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVideo allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (castMemberVideoL) LoadVideo(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCastMemberVideo interface{}, mods queries.Applicator) error {
+	var slice []*CastMemberVideo
+	var object *CastMemberVideo
+
+	if singular {
+		object = maybeCastMemberVideo.(*CastMemberVideo)
+	} else {
+		slice = *maybeCastMemberVideo.(*[]*CastMemberVideo)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &castMemberVideoR{}
+		}
+		args = append(args, object.VideoID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &castMemberVideoR{}
+			}
+
+			for _, a := range args {
+				if a == obj.VideoID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.VideoID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`videos`),
+		qm.WhereIn(`videos.id in ?`, args...),
+		qmhelper.WhereIsNull(`videos.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Video")
+	}
+
+	var resultSlice []*Video
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Video")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for videos")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for videos")
+	}
+
+	if len(castMemberVideoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Video = foreign
+		if foreign.R == nil {
+			foreign.R = &videoR{}
+		}
+		foreign.R.CastMemberVideos = append(foreign.R.CastMemberVideos, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.VideoID == foreign.ID {
+				local.R.Video = foreign
+				if foreign.R == nil {
+					foreign.R = &videoR{}
+				}
+				foreign.R.CastMemberVideos = append(foreign.R.CastMemberVideos, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCastMemberG of the castMemberVideo to the related item.
+// Sets o.R.CastMember to related.
+// Adds o to related.R.CastMemberVideos.
+// Uses the global database handle.
+func (o *CastMemberVideo) SetCastMemberG(ctx context.Context, insert bool, related *CastMember) error {
+	return o.SetCastMember(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetCastMember of the castMemberVideo to the related item.
+// Sets o.R.CastMember to related.
+// Adds o to related.R.CastMemberVideos.
+func (o *CastMemberVideo) SetCastMember(ctx context.Context, exec boil.ContextExecutor, insert bool, related *CastMember) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"cast_member_video\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"cast_member_id"}),
+		strmangle.WhereClause("\"", "\"", 2, castMemberVideoPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.CastMemberID, o.VideoID, o.Role}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.CastMemberID = related.ID
+	if o.R == nil {
+		o.R = &castMemberVideoR{
+			CastMember: related,
+		}
+	} else {
+		o.R.CastMember = related
+	}
+
+	if related.R == nil {
+		related.R = &castMemberR{
+			CastMemberVideos: CastMemberVideoSlice{o},
+		}
+	} else {
+		related.R.CastMemberVideos = append(related.R.CastMemberVideos, o)
+	}
+
+	return nil
+}
+
+// SetVideoG of the castMemberVideo to the related item.
+// Sets o.R.Video to related.
+// Adds o to related.R.CastMemberVideos.
+// Uses the global database handle.
+func (o *CastMemberVideo) SetVideoG(ctx context.Context, insert bool, related *Video) error {
+	return o.SetVideo(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetVideo of the castMemberVideo to the related item.
+// Sets o.R.Video to related.
+// Adds o to related.R.CastMemberVideos.
+func (o *CastMemberVideo) SetVideo(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Video) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"cast_member_video\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"video_id"}),
+		strmangle.WhereClause("\"", "\"", 2, castMemberVideoPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.CastMemberID, o.VideoID, o.Role}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.VideoID = related.ID
+	if o.R == nil {
+		o.R = &castMemberVideoR{
+			Video: related,
+		}
+	} else {
+		o.R.Video = related
+	}
+
+	if related.R == nil {
+		related.R = &videoR{
+			CastMemberVideos: CastMemberVideoSlice{o},
+		}
+	} else {
+		related.R.CastMemberVideos = append(related.R.CastMemberVideos, o)
+	}
+
+	return nil
+}
+
+// CastMemberVideos retrieves all the records using an executor.
+func CastMemberVideos(mods ...qm.QueryMod) castMemberVideoQuery {
+	mods = append(mods, qm.From("\"cast_member_video\""))
+	return castMemberVideoQuery{NewQuery(mods...)}
+}
+
+// FindCastMemberVideoG retrieves a single record by ID.
+func FindCastMemberVideoG(ctx context.Context, castMemberID string, videoID string, role int16, selectCols ...string) (*CastMemberVideo, error) {
+	return FindCastMemberVideo(ctx, boil.GetContextDB(), castMemberID, videoID, role, selectCols...)
+}
+
+// FindCastMemberVideo retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindCastMemberVideo(ctx context.Context, exec boil.ContextExecutor, castMemberID string, videoID string, role int16, selectCols ...string) (*CastMemberVideo, error) {
+	castMemberVideoObj := &CastMemberVideo{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"cast_member_video\" where \"cast_member_id\"=$1 AND \"video_id\"=$2 AND \"role\"=$3", sel,
+	)
+
+	q := queries.Raw(query, castMemberID, videoID, role)
+
+	err := q.Bind(ctx, exec, castMemberVideoObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from cast_member_video")
+	}
+
+	return castMemberVideoObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *CastMemberVideo) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *CastMemberVideo) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no cast_member_video provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(castMemberVideoColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	castMemberVideoInsertCacheMut.RLock()
+	cache, cached := castMemberVideoInsertCache[key]
+	castMemberVideoInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			castMemberVideoAllColumns,
+			castMemberVideoColumnsWithDefault,
+			castMemberVideoColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"cast_member_video\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"cast_member_video\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into cast_member_video")
+	}
+
+	if !cached {
+		castMemberVideoInsertCacheMut.Lock()
+		castMemberVideoInsertCache[key] = cache
+		castMemberVideoInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single CastMemberVideo record using the global executor.
+// See Update for more documentation.
+func (o *CastMemberVideo) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the CastMemberVideo.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *CastMemberVideo) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	castMemberVideoUpdateCacheMut.RLock()
+	cache, cached := castMemberVideoUpdateCache[key]
+	castMemberVideoUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			castMemberVideoAllColumns,
+			castMemberVideoPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update cast_member_video, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"cast_member_video\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, castMemberVideoPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, append(wl, castMemberVideoPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update cast_member_video row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for cast_member_video")
+	}
+
+	if !cached {
+		castMemberVideoUpdateCacheMut.Lock()
+		castMemberVideoUpdateCache[key] = cache
+		castMemberVideoUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q castMemberVideoQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q castMemberVideoQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for cast_member_video")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for cast_member_video")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o CastMemberVideoSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o CastMemberVideoSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), castMemberVideoPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"cast_member_video\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, castMemberVideoPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in castMemberVideo slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all castMemberVideo")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *CastMemberVideo) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *CastMemberVideo) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no cast_member_video provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(castMemberVideoColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	castMemberVideoUpsertCacheMut.RLock()
+	cache, cached := castMemberVideoUpsertCache[key]
+	castMemberVideoUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			castMemberVideoAllColumns,
+			castMemberVideoColumnsWithDefault,
+			castMemberVideoColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			castMemberVideoAllColumns,
+			castMemberVideoPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert cast_member_video, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(castMemberVideoPrimaryKeyColumns))
+			copy(conflict, castMemberVideoPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"cast_member_video\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(castMemberVideoType, castMemberVideoMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert cast_member_video")
+	}
+
+	if !cached {
+		castMemberVideoUpsertCacheMut.Lock()
+		castMemberVideoUpsertCache[key] = cache
+		castMemberVideoUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single CastMemberVideo record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *CastMemberVideo) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single CastMemberVideo record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *CastMemberVideo) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no CastMemberVideo provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), castMemberVideoPrimaryKeyMapping)
+	sql := "DELETE FROM \"cast_member_video\" WHERE \"cast_member_id\"=$1 AND \"video_id\"=$2 AND \"role\"=$3"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from cast_member_video")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for cast_member_video")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q castMemberVideoQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q castMemberVideoQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no castMemberVideoQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from cast_member_video")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for cast_member_video")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o CastMemberVideoSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o CastMemberVideoSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(castMemberVideoBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), castMemberVideoPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"cast_member_video\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, castMemberVideoPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from castMemberVideo slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for cast_member_video")
+	}
+
+	if len(castMemberVideoAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *CastMemberVideo) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("models: no CastMemberVideo provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *CastMemberVideo) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindCastMemberVideo(ctx, exec, o.CastMemberID, o.VideoID, o.Role)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CastMemberVideoSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("models: empty CastMemberVideoSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *CastMemberVideoSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := CastMemberVideoSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), castMemberVideoPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"cast_member_video\".* FROM \"cast_member_video\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, castMemberVideoPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in CastMemberVideoSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// CastMemberVideoExistsG checks if the CastMemberVideo row exists.
+func CastMemberVideoExistsG(ctx context.Context, castMemberID string, videoID string, role int16) (bool, error) {
+	return CastMemberVideoExists(ctx, boil.GetContextDB(), castMemberID, videoID, role)
+}
+
+// CastMemberVideoExists checks if the CastMemberVideo row exists.
+func CastMemberVideoExists(ctx context.Context, exec boil.ContextExecutor, castMemberID string, videoID string, role int16) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"cast_member_video\" where \"cast_member_id\"=$1 AND \"video_id\"=$2 AND \"role\"=$3 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, castMemberID, videoID, role)
+	}
+	row := exec.QueryRowContext(ctx, sql, castMemberID, videoID, role)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if cast_member_video exists")
+	}
+
+	return exists, nil
+}
@@ -27,9 +27,10 @@ type CastMember struct {
 	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
 	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
 	Type      int16     `boil:"type" json:"type" toml:"type" yaml:"type"`
-	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Version   int64     `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *castMemberR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L castMemberL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -42,6 +43,7 @@ var CastMemberColumns = struct {
 	CreatedAt string
 	UpdatedAt string
 	DeletedAt string
+	Version   string
 }{
 	ID:        "id",
 	Name:      "name",
@@ -49,101 +51,39 @@ var CastMemberColumns = struct {
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
 	DeletedAt: "deleted_at",
+	Version:   "version",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperint16 struct{ field string }
-
-func (w whereHelperint16) EQ(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint16) NEQ(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint16) LT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint16) LTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint16) GT(x int16) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint16) GTE(x int16) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint16) IN(slice []int16) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint16) NIN(slice []int16) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var CastMemberWhere = struct {
 	ID        whereHelperstring
 	Name      whereHelperstring
 	Type      whereHelperint16
-	CreatedAt whereHelpernull_Time
+	CreatedAt whereHelpertime_Time
 	UpdatedAt whereHelpernull_Time
 	DeletedAt whereHelpernull_Time
+	Version   whereHelperint64
 }{
 	ID:        whereHelperstring{field: "\"cast_members\".\"id\""},
 	Name:      whereHelperstring{field: "\"cast_members\".\"name\""},
 	Type:      whereHelperint16{field: "\"cast_members\".\"type\""},
-	CreatedAt: whereHelpernull_Time{field: "\"cast_members\".\"created_at\""},
+	CreatedAt: whereHelpertime_Time{field: "\"cast_members\".\"created_at\""},
 	UpdatedAt: whereHelpernull_Time{field: "\"cast_members\".\"updated_at\""},
 	DeletedAt: whereHelpernull_Time{field: "\"cast_members\".\"deleted_at\""},
+	Version:   whereHelperint64{field: "\"cast_members\".\"version\""},
 }
 
 // CastMemberRels is where relationship names are stored.
 var CastMemberRels = struct {
-}{}
+	CastMemberVideos string
+}{
+	CastMemberVideos: "CastMemberVideos",
+}
 
 // castMemberR is where relationships are stored.
 type castMemberR struct {
+	CastMemberVideos CastMemberVideoSlice `boil:"CastMemberVideos" json:"CastMemberVideos" toml:"CastMemberVideos" yaml:"CastMemberVideos"`
 }
 
 // NewStruct creates a new relationship struct
@@ -155,9 +95,9 @@ func (*castMemberR) NewStruct() *castMemberR {
 type castMemberL struct{}
 
 var (
-	castMemberAllColumns            = []string{"id", "name", "type", "created_at", "updated_at", "deleted_at"}
-	castMemberColumnsWithoutDefault = []string{"id", "name", "type", "created_at", "updated_at", "deleted_at"}
-	castMemberColumnsWithDefault    = []string{}
+	castMemberAllColumns            = []string{"id", "name", "type", "created_at", "updated_at", "deleted_at", "version"}
+	castMemberColumnsWithoutDefault = []string{"id", "name", "type", "updated_at", "deleted_at"}
+	castMemberColumnsWithDefault    = []string{"created_at", "version"}
 	castMemberPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -456,6 +396,187 @@ func (q castMemberQuery) Exists(ctx context.Context, exec boil.ContextExecutor)
 	return count > 0, nil
 }
 
+// CastMemberVideos retrieves all the cast_member_video's CastMemberVideos with an executor.
+func (o *CastMember) CastMemberVideos(mods ...qm.QueryMod) castMemberVideoQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"cast_member_video\".\"cast_member_id\"=?", o.ID),
+	)
+
+	query := CastMemberVideos(queryMods...)
+	queries.SetFrom(query.Query, "\"cast_member_video\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"cast_member_video\".*"})
+	}
+
+	return query
+}
+
+// LoadCastMemberVideos allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (castMemberL) LoadCastMemberVideos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCastMember interface{}, mods queries.Applicator) error {
+	var slice []*CastMember
+	var object *CastMember
+
+	if singular {
+		object = maybeCastMember.(*CastMember)
+	} else {
+		slice = *maybeCastMember.(*[]*CastMember)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &castMemberR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &castMemberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`cast_member_video`),
+		qm.WhereIn(`cast_member_video.cast_member_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load cast_member_video")
+	}
+
+	var resultSlice []*CastMemberVideo
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice cast_member_video")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on cast_member_video")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for cast_member_video")
+	}
+
+	if len(castMemberVideoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.CastMemberVideos = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &castMemberVideoR{}
+			}
+			foreign.R.CastMember = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.CastMemberID {
+				local.R.CastMemberVideos = append(local.R.CastMemberVideos, foreign)
+				if foreign.R == nil {
+					foreign.R = &castMemberVideoR{}
+				}
+				foreign.R.CastMember = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddCastMemberVideosG adds the given related objects to the existing relationships
+// of the cast_member, optionally inserting them as new records.
+// Appends related to o.R.CastMemberVideos.
+// Sets related.R.CastMember appropriately.
+// Uses the global database handle.
+func (o *CastMember) AddCastMemberVideosG(ctx context.Context, insert bool, related ...*CastMemberVideo) error {
+	return o.AddCastMemberVideos(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddCastMemberVideos adds the given related objects to the existing relationships
+// of the cast_member, optionally inserting them as new records.
+// Appends related to o.R.CastMemberVideos.
+// Sets related.R.CastMember appropriately.
+func (o *CastMember) AddCastMemberVideos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CastMemberVideo) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.CastMemberID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"cast_member_video\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"cast_member_id"}),
+				strmangle.WhereClause("\"", "\"", 2, castMemberVideoPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.CastMemberID, rel.VideoID, rel.Role}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.CastMemberID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &castMemberR{
+			CastMemberVideos: related,
+		}
+	} else {
+		o.R.CastMemberVideos = append(o.R.CastMemberVideos, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &castMemberVideoR{
+				CastMember: o,
+			}
+		} else {
+			rel.R.CastMember = o
+		}
+	}
+	return nil
+}
+
 // CastMembers retrieves all the records using an executor.
 func CastMembers(mods ...qm.QueryMod) castMemberQuery {
 	mods = append(mods, qm.From("\"cast_members\""), qmhelper.WhereIsNull("\"cast_members\".\"deleted_at\""))
@@ -509,8 +630,8 @@ func (o *CastMember) Insert(ctx context.Context, exec boil.ContextExecutor, colu
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		if queries.MustTime(o.UpdatedAt).IsZero() {
 			queries.SetScanner(&o.UpdatedAt, currTime)
@@ -751,8 +872,8 @@ func (o *CastMember) Upsert(ctx context.Context, exec boil.ContextExecutor, upda
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		queries.SetScanner(&o.UpdatedAt, currTime)
 	}
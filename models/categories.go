@@ -27,9 +27,10 @@ type Category struct {
 	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
 	Name        string      `boil:"name" json:"name" toml:"name" yaml:"name"`
 	Description null.String `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
-	IsValidated bool        `boil:"is_validated" json:"is_validated" toml:"is_validated" yaml:"is_validated"`
-	CreatedAt   null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt   null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	DeletedAt   null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Version     int64       `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *categoryR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L categoryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,66 +40,38 @@ var CategoryColumns = struct {
 	ID          string
 	Name        string
 	Description string
-	IsValidated string
 	CreatedAt   string
 	UpdatedAt   string
+	DeletedAt   string
+	Version     string
 }{
 	ID:          "id",
 	Name:        "name",
 	Description: "description",
-	IsValidated: "is_validated",
 	CreatedAt:   "created_at",
 	UpdatedAt:   "updated_at",
+	DeletedAt:   "deleted_at",
+	Version:     "version",
 }
 
 // Generated where
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var CategoryWhere = struct {
 	ID          whereHelperstring
 	Name        whereHelperstring
 	Description whereHelpernull_String
-	IsValidated whereHelperbool
-	CreatedAt   whereHelpernull_Time
+	CreatedAt   whereHelpertime_Time
 	UpdatedAt   whereHelpernull_Time
+	DeletedAt   whereHelpernull_Time
+	Version     whereHelperint64
 }{
 	ID:          whereHelperstring{field: "\"categories\".\"id\""},
 	Name:        whereHelperstring{field: "\"categories\".\"name\""},
 	Description: whereHelpernull_String{field: "\"categories\".\"description\""},
-	IsValidated: whereHelperbool{field: "\"categories\".\"is_validated\""},
-	CreatedAt:   whereHelpernull_Time{field: "\"categories\".\"created_at\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"categories\".\"created_at\""},
 	UpdatedAt:   whereHelpernull_Time{field: "\"categories\".\"updated_at\""},
+	DeletedAt:   whereHelpernull_Time{field: "\"categories\".\"deleted_at\""},
+	Version:     whereHelperint64{field: "\"categories\".\"version\""},
 }
 
 // CategoryRels is where relationship names are stored.
@@ -125,9 +98,9 @@ func (*categoryR) NewStruct() *categoryR {
 type categoryL struct{}
 
 var (
-	categoryAllColumns            = []string{"id", "name", "description", "is_validated", "created_at", "updated_at"}
-	categoryColumnsWithoutDefault = []string{"id", "name", "description", "created_at", "updated_at"}
-	categoryColumnsWithDefault    = []string{"is_validated"}
+	categoryAllColumns            = []string{"id", "name", "description", "created_at", "updated_at", "deleted_at", "version"}
+	categoryColumnsWithoutDefault = []string{"id", "name", "description", "updated_at", "deleted_at"}
+	categoryColumnsWithDefault    = []string{"created_at", "version"}
 	categoryPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -532,7 +505,7 @@ func (categoryL) LoadGenres(ctx context.Context, e boil.ContextExecutor, singula
 		one := new(Genre)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Name, &one.IsValidated, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Name, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for genres")
 		}
@@ -648,7 +621,7 @@ func (categoryL) LoadVideos(ctx context.Context, e boil.ContextExecutor, singula
 		one := new(Video)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Title, &one.Description, &one.YearLaunched, &one.Opened, &one.Rating, &one.Duration, &one.VideoFile, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Title, &one.Description, &one.YearLaunched, &one.Opened, &one.Rating, &one.Duration, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Language, &one.Search, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for videos")
 		}
@@ -1040,7 +1013,7 @@ func removeVideosFromCategoriesSlice(o *Category, related []*Video) {
 
 // Categories retrieves all the records using an executor.
 func Categories(mods ...qm.QueryMod) categoryQuery {
-	mods = append(mods, qm.From("\"categories\""))
+	mods = append(mods, qm.From("\"categories\""), qmhelper.WhereIsNull("\"categories\".\"deleted_at\""))
 	return categoryQuery{NewQuery(mods...)}
 }
 
@@ -1059,7 +1032,7 @@ func FindCategory(ctx context.Context, exec boil.ContextExecutor, iD string, sel
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
-		"select %s from \"categories\" where \"id\"=$1", sel,
+		"select %s from \"categories\" where \"id\"=$1 and \"deleted_at\" is null", sel,
 	)
 
 	q := queries.Raw(query, iD)
@@ -1091,8 +1064,8 @@ func (o *Category) Insert(ctx context.Context, exec boil.ContextExecutor, column
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		if queries.MustTime(o.UpdatedAt).IsZero() {
 			queries.SetScanner(&o.UpdatedAt, currTime)
@@ -1333,8 +1306,8 @@ func (o *Category) Upsert(ctx context.Context, exec boil.ContextExecutor, update
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		queries.SetScanner(&o.UpdatedAt, currTime)
 	}
@@ -1449,13 +1422,13 @@ func (o *Category) Upsert(ctx context.Context, exec boil.ContextExecutor, update
 
 // DeleteG deletes a single Category record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Category) DeleteG(ctx context.Context) (int64, error) {
-	return o.Delete(ctx, boil.GetContextDB())
+func (o *Category) DeleteG(ctx context.Context, hardDelete bool) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB(), hardDelete)
 }
 
 // Delete deletes a single Category record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Category) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+func (o *Category) Delete(ctx context.Context, exec boil.ContextExecutor, hardDelete bool) (int64, error) {
 	if o == nil {
 		return 0, errors.New("models: no Category provided for delete")
 	}
@@ -1464,8 +1437,26 @@ func (o *Category) Delete(ctx context.Context, exec boil.ContextExecutor) (int64
 		return 0, err
 	}
 
-	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), categoryPrimaryKeyMapping)
-	sql := "DELETE FROM \"categories\" WHERE \"id\"=$1"
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), categoryPrimaryKeyMapping)
+		sql = "DELETE FROM \"categories\" WHERE \"id\"=$1"
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		o.DeletedAt = null.TimeFrom(currTime)
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"categories\" SET %s WHERE \"id\"=$2",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		valueMapping, err := queries.BindMapping(categoryType, categoryMapping, append(wl, categoryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+		args = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+	}
 
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
@@ -1489,17 +1480,22 @@ func (o *Category) Delete(ctx context.Context, exec boil.ContextExecutor) (int64
 	return rowsAff, nil
 }
 
-func (q categoryQuery) DeleteAllG(ctx context.Context) (int64, error) {
-	return q.DeleteAll(ctx, boil.GetContextDB())
+func (q categoryQuery) DeleteAllG(ctx context.Context, hardDelete bool) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB(), hardDelete)
 }
 
 // DeleteAll deletes all matching rows.
-func (q categoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+func (q categoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor, hardDelete bool) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("models: no categoryQuery provided for delete all")
 	}
 
-	queries.SetDelete(q.Query)
+	if hardDelete {
+		queries.SetDelete(q.Query)
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		queries.SetUpdate(q.Query, M{"deleted_at": currTime})
+	}
 
 	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
@@ -1515,12 +1511,12 @@ func (q categoryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor)
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o CategorySlice) DeleteAllG(ctx context.Context) (int64, error) {
-	return o.DeleteAll(ctx, boil.GetContextDB())
+func (o CategorySlice) DeleteAllG(ctx context.Context, hardDelete bool) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB(), hardDelete)
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o CategorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+func (o CategorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor, hardDelete bool) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
@@ -1533,15 +1529,32 @@ func (o CategorySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor)
 		}
 	}
 
-	var args []interface{}
-	for _, obj := range o {
-		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), categoryPrimaryKeyMapping)
-		args = append(args, pkeyArgs...)
+	var (
+		sql  string
+		args []interface{}
+	)
+	if hardDelete {
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), categoryPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+		}
+		sql = "DELETE FROM \"categories\" WHERE " +
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, categoryPrimaryKeyColumns, len(o))
+	} else {
+		currTime := time.Now().In(boil.GetLocation())
+		for _, obj := range o {
+			pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), categoryPrimaryKeyMapping)
+			args = append(args, pkeyArgs...)
+			obj.DeletedAt = null.TimeFrom(currTime)
+		}
+		wl := []string{"deleted_at"}
+		sql = fmt.Sprintf("UPDATE \"categories\" SET %s WHERE "+
+			strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 2, categoryPrimaryKeyColumns, len(o)),
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+		)
+		args = append([]interface{}{currTime}, args...)
 	}
 
-	sql := "DELETE FROM \"categories\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, categoryPrimaryKeyColumns, len(o))
-
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
 		fmt.Fprintln(writer, sql)
@@ -1614,7 +1627,8 @@ func (o *CategorySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor
 	}
 
 	sql := "SELECT \"categories\".* FROM \"categories\" WHERE " +
-		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, categoryPrimaryKeyColumns, len(*o))
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, categoryPrimaryKeyColumns, len(*o)) +
+		"and \"deleted_at\" is null"
 
 	q := queries.Raw(sql, args...)
 
@@ -1636,7 +1650,7 @@ func CategoryExistsG(ctx context.Context, iD string) (bool, error) {
 // CategoryExists checks if the Category row exists.
 func CategoryExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
 	var exists bool
-	sql := "select exists(select 1 from \"categories\" where \"id\"=$1 limit 1)"
+	sql := "select exists(select 1 from \"categories\" where \"id\"=$1 and \"deleted_at\" is null limit 1)"
 
 	if boil.IsDebug(ctx) {
 		writer := boil.DebugWriterFrom(ctx)
@@ -24,49 +24,49 @@ import (
 
 // Genre is an object representing the database table.
 type Genre struct {
-	ID          string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name        string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	IsValidated bool      `boil:"is_validated" json:"is_validated" toml:"is_validated" yaml:"is_validated"`
-	CreatedAt   null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
-	UpdatedAt   null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
-	DeletedAt   null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Version   int64     `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *genreR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L genreL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var GenreColumns = struct {
-	ID          string
-	Name        string
-	IsValidated string
-	CreatedAt   string
-	UpdatedAt   string
-	DeletedAt   string
+	ID        string
+	Name      string
+	CreatedAt string
+	UpdatedAt string
+	DeletedAt string
+	Version   string
 }{
-	ID:          "id",
-	Name:        "name",
-	IsValidated: "is_validated",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
-	DeletedAt:   "deleted_at",
+	ID:        "id",
+	Name:      "name",
+	CreatedAt: "created_at",
+	UpdatedAt: "updated_at",
+	DeletedAt: "deleted_at",
+	Version:   "version",
 }
 
 // Generated where
 
 var GenreWhere = struct {
-	ID          whereHelperstring
-	Name        whereHelperstring
-	IsValidated whereHelperbool
-	CreatedAt   whereHelpernull_Time
-	UpdatedAt   whereHelpernull_Time
-	DeletedAt   whereHelpernull_Time
+	ID        whereHelperstring
+	Name      whereHelperstring
+	CreatedAt whereHelpertime_Time
+	UpdatedAt whereHelpernull_Time
+	DeletedAt whereHelpernull_Time
+	Version   whereHelperint64
 }{
-	ID:          whereHelperstring{field: "\"genres\".\"id\""},
-	Name:        whereHelperstring{field: "\"genres\".\"name\""},
-	IsValidated: whereHelperbool{field: "\"genres\".\"is_validated\""},
-	CreatedAt:   whereHelpernull_Time{field: "\"genres\".\"created_at\""},
-	UpdatedAt:   whereHelpernull_Time{field: "\"genres\".\"updated_at\""},
-	DeletedAt:   whereHelpernull_Time{field: "\"genres\".\"deleted_at\""},
+	ID:        whereHelperstring{field: "\"genres\".\"id\""},
+	Name:      whereHelperstring{field: "\"genres\".\"name\""},
+	CreatedAt: whereHelpertime_Time{field: "\"genres\".\"created_at\""},
+	UpdatedAt: whereHelpernull_Time{field: "\"genres\".\"updated_at\""},
+	DeletedAt: whereHelpernull_Time{field: "\"genres\".\"deleted_at\""},
+	Version:   whereHelperint64{field: "\"genres\".\"version\""},
 }
 
 // GenreRels is where relationship names are stored.
@@ -93,9 +93,9 @@ func (*genreR) NewStruct() *genreR {
 type genreL struct{}
 
 var (
-	genreAllColumns            = []string{"id", "name", "is_validated", "created_at", "updated_at", "deleted_at"}
-	genreColumnsWithoutDefault = []string{"id", "name", "created_at", "updated_at", "deleted_at"}
-	genreColumnsWithDefault    = []string{"is_validated"}
+	genreAllColumns            = []string{"id", "name", "created_at", "updated_at", "deleted_at", "version"}
+	genreColumnsWithoutDefault = []string{"id", "name", "updated_at", "deleted_at"}
+	genreColumnsWithDefault    = []string{"created_at", "version"}
 	genrePrimaryKeyColumns     = []string{"id"}
 )
 
@@ -482,6 +482,7 @@ func (genreL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singul
 		qm.From("\"categories\""),
 		qm.InnerJoin("\"category_genre\" as \"a\" on \"categories\".\"id\" = \"a\".\"category_id\""),
 		qm.WhereIn("\"a\".\"genre_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"categories\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -499,7 +500,7 @@ func (genreL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singul
 		one := new(Category)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Name, &one.Description, &one.IsValidated, &one.CreatedAt, &one.UpdatedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Name, &one.Description, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for categories")
 		}
@@ -615,7 +616,7 @@ func (genreL) LoadVideos(ctx context.Context, e boil.ContextExecutor, singular b
 		one := new(Video)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Title, &one.Description, &one.YearLaunched, &one.Opened, &one.Rating, &one.Duration, &one.VideoFile, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Title, &one.Description, &one.YearLaunched, &one.Opened, &one.Rating, &one.Duration, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Language, &one.Search, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for videos")
 		}
@@ -1058,8 +1059,8 @@ func (o *Genre) Insert(ctx context.Context, exec boil.ContextExecutor, columns b
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		if queries.MustTime(o.UpdatedAt).IsZero() {
 			queries.SetScanner(&o.UpdatedAt, currTime)
@@ -1300,8 +1301,8 @@ func (o *Genre) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnC
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		queries.SetScanner(&o.UpdatedAt, currTime)
 	}
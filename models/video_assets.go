@@ -0,0 +1,1418 @@
+// Code generated by SQLBoiler 4.2.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// VideoAsset is an object representing the database table.
+type VideoAsset struct {
+	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	VideoID     string      `boil:"video_id" json:"video_id" toml:"video_id" yaml:"video_id"`
+	Kind        string      `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	FileName    string      `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
+	ContentType string      `boil:"content_type" json:"content_type" toml:"content_type" yaml:"content_type"`
+	Size        int64       `boil:"size" json:"size" toml:"size" yaml:"size"`
+	CreatedAt   null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	UpdatedAt   null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	BlobID      null.String `boil:"blob_id" json:"blob_id,omitempty" toml:"blob_id" yaml:"blob_id,omitempty"`
+
+	R *videoAssetR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L videoAssetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var VideoAssetColumns = struct {
+	ID          string
+	VideoID     string
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        string
+	CreatedAt   string
+	UpdatedAt   string
+	BlobID      string
+}{
+	ID:          "id",
+	VideoID:     "video_id",
+	Kind:        "kind",
+	FileName:    "file_name",
+	ContentType: "content_type",
+	Size:        "size",
+	CreatedAt:   "created_at",
+	UpdatedAt:   "updated_at",
+	BlobID:      "blob_id",
+}
+
+// Generated where
+
+var VideoAssetWhere = struct {
+	ID          whereHelperstring
+	VideoID     whereHelperstring
+	Kind        whereHelperstring
+	FileName    whereHelperstring
+	ContentType whereHelperstring
+	Size        whereHelperint64
+	CreatedAt   whereHelpernull_Time
+	UpdatedAt   whereHelpernull_Time
+	BlobID      whereHelpernull_String
+}{
+	ID:          whereHelperstring{field: "\"video_assets\".\"id\""},
+	VideoID:     whereHelperstring{field: "\"video_assets\".\"video_id\""},
+	Kind:        whereHelperstring{field: "\"video_assets\".\"kind\""},
+	FileName:    whereHelperstring{field: "\"video_assets\".\"file_name\""},
+	ContentType: whereHelperstring{field: "\"video_assets\".\"content_type\""},
+	Size:        whereHelperint64{field: "\"video_assets\".\"size\""},
+	CreatedAt:   whereHelpernull_Time{field: "\"video_assets\".\"created_at\""},
+	UpdatedAt:   whereHelpernull_Time{field: "\"video_assets\".\"updated_at\""},
+	BlobID:      whereHelpernull_String{field: "\"video_assets\".\"blob_id\""},
+}
+
+// VideoAssetRels is where relationship names are stored.
+var VideoAssetRels = struct {
+	Video string
+	Blob  string
+}{
+	Video: "Video",
+	Blob:  "Blob",
+}
+
+// videoAssetR is where relationships are stored.
+type videoAssetR struct {
+	Video *Video `boil:"Video" json:"Video" toml:"Video" yaml:"Video"`
+	Blob  *Blob  `boil:"Blob" json:"Blob" toml:"Blob" yaml:"Blob"`
+}
+
+// NewStruct creates a new relationship struct
+func (*videoAssetR) NewStruct() *videoAssetR {
+	return &videoAssetR{}
+}
+
+// videoAssetL is where Load methods for each relationship are stored.
+type videoAssetL struct{}
+
+var (
+	videoAssetAllColumns            = []string{"id", "video_id", "kind", "file_name", "content_type", "size", "created_at", "updated_at", "blob_id"}
+	videoAssetColumnsWithoutDefault = []string{"id", "video_id", "kind", "file_name", "content_type", "size", "created_at", "updated_at", "blob_id"}
+	videoAssetColumnsWithDefault    = []string{}
+	videoAssetPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// VideoAssetSlice is an alias for a slice of pointers to VideoAsset.
+	// This should generally be used opposed to []VideoAsset.
+	VideoAssetSlice []*VideoAsset
+	// VideoAssetHook is the signature for custom VideoAsset hook methods
+	VideoAssetHook func(context.Context, boil.ContextExecutor, *VideoAsset) error
+
+	videoAssetQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	videoAssetType                 = reflect.TypeOf(&VideoAsset{})
+	videoAssetMapping              = queries.MakeStructMapping(videoAssetType)
+	videoAssetPrimaryKeyMapping, _ = queries.BindMapping(videoAssetType, videoAssetMapping, videoAssetPrimaryKeyColumns)
+	videoAssetInsertCacheMut       sync.RWMutex
+	videoAssetInsertCache          = make(map[string]insertCache)
+	videoAssetUpdateCacheMut       sync.RWMutex
+	videoAssetUpdateCache          = make(map[string]updateCache)
+	videoAssetUpsertCacheMut       sync.RWMutex
+	videoAssetUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var videoAssetBeforeInsertHooks []VideoAssetHook
+var videoAssetBeforeUpdateHooks []VideoAssetHook
+var videoAssetBeforeDeleteHooks []VideoAssetHook
+var videoAssetBeforeUpsertHooks []VideoAssetHook
+
+var videoAssetAfterInsertHooks []VideoAssetHook
+var videoAssetAfterSelectHooks []VideoAssetHook
+var videoAssetAfterUpdateHooks []VideoAssetHook
+var videoAssetAfterDeleteHooks []VideoAssetHook
+var videoAssetAfterUpsertHooks []VideoAssetHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *VideoAsset) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *VideoAsset) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *VideoAsset) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *VideoAsset) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *VideoAsset) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *VideoAsset) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *VideoAsset) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *VideoAsset) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *VideoAsset) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range videoAssetAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddVideoAssetHook registers your hook function for all future operations.
+func AddVideoAssetHook(hookPoint boil.HookPoint, videoAssetHook VideoAssetHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		videoAssetBeforeInsertHooks = append(videoAssetBeforeInsertHooks, videoAssetHook)
+	case boil.BeforeUpdateHook:
+		videoAssetBeforeUpdateHooks = append(videoAssetBeforeUpdateHooks, videoAssetHook)
+	case boil.BeforeDeleteHook:
+		videoAssetBeforeDeleteHooks = append(videoAssetBeforeDeleteHooks, videoAssetHook)
+	case boil.BeforeUpsertHook:
+		videoAssetBeforeUpsertHooks = append(videoAssetBeforeUpsertHooks, videoAssetHook)
+	case boil.AfterInsertHook:
+		videoAssetAfterInsertHooks = append(videoAssetAfterInsertHooks, videoAssetHook)
+	case boil.AfterSelectHook:
+		videoAssetAfterSelectHooks = append(videoAssetAfterSelectHooks, videoAssetHook)
+	case boil.AfterUpdateHook:
+		videoAssetAfterUpdateHooks = append(videoAssetAfterUpdateHooks, videoAssetHook)
+	case boil.AfterDeleteHook:
+		videoAssetAfterDeleteHooks = append(videoAssetAfterDeleteHooks, videoAssetHook)
+	case boil.AfterUpsertHook:
+		videoAssetAfterUpsertHooks = append(videoAssetAfterUpsertHooks, videoAssetHook)
+	}
+}
+
+// OneG returns a single videoAsset record from the query using the global executor.
+func (q videoAssetQuery) OneG(ctx context.Context) (*VideoAsset, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single videoAsset record from the query.
+func (q videoAssetQuery) One(ctx context.Context, exec boil.ContextExecutor) (*VideoAsset, error) {
+	o := &VideoAsset{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for video_assets")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all VideoAsset records from the query using the global executor.
+func (q videoAssetQuery) AllG(ctx context.Context) (VideoAssetSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all VideoAsset records from the query.
+func (q videoAssetQuery) All(ctx context.Context, exec boil.ContextExecutor) (VideoAssetSlice, error) {
+	var o []*VideoAsset
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to VideoAsset slice")
+	}
+
+	if len(videoAssetAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all VideoAsset records in the query, and panics on error.
+func (q videoAssetQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all VideoAsset records in the query.
+func (q videoAssetQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count video_assets rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q videoAssetQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q videoAssetQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if video_assets exists")
+	}
+
+	return count > 0, nil
+}
+
+// Video pointed to by the foreign key.
+func (o *VideoAsset) Video(mods ...qm.QueryMod) videoQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.VideoID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Videos(queryMods...)
+	queries.SetFrom(query.Query, "\"videos\"")
+
+	return query
+}
+
+// Blob pointed to by the foreign key.
+func (o *VideoAsset) Blob(mods ...qm.QueryMod) blobQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.BlobID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Blobs(queryMods...)
+	queries.SetFrom(query.Query, "\"blobs\"")
+
+	return query
+}
+
+// LoadVideo allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (videoAssetL) LoadVideo(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVideoAsset interface{}, mods queries.Applicator) error {
+	var slice []*VideoAsset
+	var object *VideoAsset
+
+	if singular {
+		object = maybeVideoAsset.(*VideoAsset)
+	} else {
+		slice = *maybeVideoAsset.(*[]*VideoAsset)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &videoAssetR{}
+		}
+		args = append(args, object.VideoID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &videoAssetR{}
+			}
+
+			for _, a := range args {
+				if a == obj.VideoID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.VideoID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`videos`),
+		qm.WhereIn(`videos.id in ?`, args...),
+		qmhelper.WhereIsNull(`videos.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Video")
+	}
+
+	var resultSlice []*Video
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Video")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for videos")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for videos")
+	}
+
+	if len(videoAssetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Video = foreign
+		if foreign.R == nil {
+			foreign.R = &videoR{}
+		}
+		foreign.R.VideoAssets = append(foreign.R.VideoAssets, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.VideoID == foreign.ID {
+				local.R.Video = foreign
+				if foreign.R == nil {
+					foreign.R = &videoR{}
+				}
+				foreign.R.VideoAssets = append(foreign.R.VideoAssets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadBlob allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (videoAssetL) LoadBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVideoAsset interface{}, mods queries.Applicator) error {
+	var slice []*VideoAsset
+	var object *VideoAsset
+
+	if singular {
+		object = maybeVideoAsset.(*VideoAsset)
+	} else {
+		slice = *maybeVideoAsset.(*[]*VideoAsset)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &videoAssetR{}
+		}
+		if !queries.IsNil(object.BlobID) {
+			args = append(args, object.BlobID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &videoAssetR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.BlobID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.BlobID) {
+				args = append(args, obj.BlobID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`blobs`),
+		qm.WhereIn(`blobs.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Blob")
+	}
+
+	var resultSlice []*Blob
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Blob")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for blobs")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blobs")
+	}
+
+	if len(videoAssetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Blob = foreign
+		if foreign.R == nil {
+			foreign.R = &blobR{}
+		}
+		foreign.R.VideoAssets = append(foreign.R.VideoAssets, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.BlobID, foreign.ID) {
+				local.R.Blob = foreign
+				if foreign.R == nil {
+					foreign.R = &blobR{}
+				}
+				foreign.R.VideoAssets = append(foreign.R.VideoAssets, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetVideoG of the videoAsset to the related item.
+// Sets o.R.Video to related.
+// Adds o to related.R.VideoAssets.
+// Uses the global database handle.
+func (o *VideoAsset) SetVideoG(ctx context.Context, insert bool, related *Video) error {
+	return o.SetVideo(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetVideo of the videoAsset to the related item.
+// Sets o.R.Video to related.
+// Adds o to related.R.VideoAssets.
+func (o *VideoAsset) SetVideo(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Video) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"video_assets\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"video_id"}),
+		strmangle.WhereClause("\"", "\"", 2, videoAssetPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.VideoID = related.ID
+	if o.R == nil {
+		o.R = &videoAssetR{
+			Video: related,
+		}
+	} else {
+		o.R.Video = related
+	}
+
+	if related.R == nil {
+		related.R = &videoR{
+			VideoAssets: VideoAssetSlice{o},
+		}
+	} else {
+		related.R.VideoAssets = append(related.R.VideoAssets, o)
+	}
+
+	return nil
+}
+
+// SetBlobG of the videoAsset to the related item.
+// Sets o.R.Blob to related.
+// Adds o to related.R.VideoAssets.
+// Uses the global database handle.
+func (o *VideoAsset) SetBlobG(ctx context.Context, insert bool, related *Blob) error {
+	return o.SetBlob(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetBlob of the videoAsset to the related item.
+// Sets o.R.Blob to related.
+// Adds o to related.R.VideoAssets.
+func (o *VideoAsset) SetBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Blob) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"video_assets\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"blob_id"}),
+		strmangle.WhereClause("\"", "\"", 2, videoAssetPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.BlobID, related.ID)
+	if o.R == nil {
+		o.R = &videoAssetR{
+			Blob: related,
+		}
+	} else {
+		o.R.Blob = related
+	}
+
+	if related.R == nil {
+		related.R = &blobR{
+			VideoAssets: VideoAssetSlice{o},
+		}
+	} else {
+		related.R.VideoAssets = append(related.R.VideoAssets, o)
+	}
+
+	return nil
+}
+
+// RemoveBlobG relationship.
+// Sets o.R.Blob to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+// Uses the global database handle.
+func (o *VideoAsset) RemoveBlobG(ctx context.Context, related *Blob) error {
+	return o.RemoveBlob(ctx, boil.GetContextDB(), related)
+}
+
+// RemoveBlob relationship.
+// Sets o.R.Blob to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *VideoAsset) RemoveBlob(ctx context.Context, exec boil.ContextExecutor, related *Blob) error {
+	var err error
+
+	queries.SetScanner(&o.BlobID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("blob_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Blob = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.VideoAssets {
+		if queries.Equal(o.BlobID, ri.BlobID) {
+			continue
+		}
+
+		ln := len(related.R.VideoAssets)
+		if ln > 1 && i < ln-1 {
+			related.R.VideoAssets[i] = related.R.VideoAssets[ln-1]
+		}
+		related.R.VideoAssets = related.R.VideoAssets[:ln-1]
+		break
+	}
+	return nil
+}
+
+// VideoAssets retrieves all the records using an executor.
+func VideoAssets(mods ...qm.QueryMod) videoAssetQuery {
+	mods = append(mods, qm.From("\"video_assets\""))
+	return videoAssetQuery{NewQuery(mods...)}
+}
+
+// FindVideoAssetG retrieves a single record by ID.
+func FindVideoAssetG(ctx context.Context, iD string, selectCols ...string) (*VideoAsset, error) {
+	return FindVideoAsset(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindVideoAsset retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindVideoAsset(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*VideoAsset, error) {
+	videoAssetObj := &VideoAsset{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"video_assets\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, videoAssetObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from video_assets")
+	}
+
+	return videoAssetObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *VideoAsset) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *VideoAsset) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no video_assets provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+		if queries.MustTime(o.UpdatedAt).IsZero() {
+			queries.SetScanner(&o.UpdatedAt, currTime)
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(videoAssetColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	videoAssetInsertCacheMut.RLock()
+	cache, cached := videoAssetInsertCache[key]
+	videoAssetInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			videoAssetAllColumns,
+			videoAssetColumnsWithDefault,
+			videoAssetColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(videoAssetType, videoAssetMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(videoAssetType, videoAssetMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"video_assets\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"video_assets\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into video_assets")
+	}
+
+	if !cached {
+		videoAssetInsertCacheMut.Lock()
+		videoAssetInsertCache[key] = cache
+		videoAssetInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single VideoAsset record using the global executor.
+// See Update for more documentation.
+func (o *VideoAsset) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the VideoAsset.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *VideoAsset) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		queries.SetScanner(&o.UpdatedAt, currTime)
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	videoAssetUpdateCacheMut.RLock()
+	cache, cached := videoAssetUpdateCache[key]
+	videoAssetUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			videoAssetAllColumns,
+			videoAssetPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update video_assets, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"video_assets\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, videoAssetPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(videoAssetType, videoAssetMapping, append(wl, videoAssetPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update video_assets row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for video_assets")
+	}
+
+	if !cached {
+		videoAssetUpdateCacheMut.Lock()
+		videoAssetUpdateCache[key] = cache
+		videoAssetUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q videoAssetQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q videoAssetQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for video_assets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for video_assets")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o VideoAssetSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o VideoAssetSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), videoAssetPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"video_assets\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, videoAssetPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in videoAsset slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all videoAsset")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *VideoAsset) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *VideoAsset) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no video_assets provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if queries.MustTime(o.CreatedAt).IsZero() {
+			queries.SetScanner(&o.CreatedAt, currTime)
+		}
+		queries.SetScanner(&o.UpdatedAt, currTime)
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(videoAssetColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	videoAssetUpsertCacheMut.RLock()
+	cache, cached := videoAssetUpsertCache[key]
+	videoAssetUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			videoAssetAllColumns,
+			videoAssetColumnsWithDefault,
+			videoAssetColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			videoAssetAllColumns,
+			videoAssetPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert video_assets, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(videoAssetPrimaryKeyColumns))
+			copy(conflict, videoAssetPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"video_assets\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(videoAssetType, videoAssetMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(videoAssetType, videoAssetMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert video_assets")
+	}
+
+	if !cached {
+		videoAssetUpsertCacheMut.Lock()
+		videoAssetUpsertCache[key] = cache
+		videoAssetUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single VideoAsset record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *VideoAsset) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single VideoAsset record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *VideoAsset) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no VideoAsset provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), videoAssetPrimaryKeyMapping)
+	sql := "DELETE FROM \"video_assets\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from video_assets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for video_assets")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q videoAssetQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q videoAssetQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no videoAssetQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from video_assets")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for video_assets")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o VideoAssetSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o VideoAssetSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(videoAssetBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), videoAssetPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"video_assets\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, videoAssetPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from videoAsset slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for video_assets")
+	}
+
+	if len(videoAssetAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *VideoAsset) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("models: no VideoAsset provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *VideoAsset) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindVideoAsset(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *VideoAssetSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("models: empty VideoAssetSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *VideoAssetSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := VideoAssetSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), videoAssetPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"video_assets\".* FROM \"video_assets\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, videoAssetPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in VideoAssetSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// VideoAssetExistsG checks if the VideoAsset row exists.
+func VideoAssetExistsG(ctx context.Context, iD string) (bool, error) {
+	return VideoAssetExists(ctx, boil.GetContextDB(), iD)
+}
+
+// VideoAssetExists checks if the VideoAsset row exists.
+func VideoAssetExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"video_assets\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if video_assets exists")
+	}
+
+	return exists, nil
+}
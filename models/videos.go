@@ -31,10 +31,12 @@ type Video struct {
 	Opened       null.Bool   `boil:"opened" json:"opened,omitempty" toml:"opened" yaml:"opened,omitempty"`
 	Rating       int16       `boil:"rating" json:"rating" toml:"rating" yaml:"rating"`
 	Duration     int16       `boil:"duration" json:"duration" toml:"duration" yaml:"duration"`
-	VideoFile    null.String `boil:"video_file" json:"video_file,omitempty" toml:"video_file" yaml:"video_file,omitempty"`
-	CreatedAt    null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt    null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 	DeletedAt    null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Language     string      `boil:"language" json:"language" toml:"language" yaml:"language"`
+	Search       null.String `boil:"search" json:"search,omitempty" toml:"search" yaml:"search,omitempty"`
+	Version      int64       `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *videoR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L videoL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -48,10 +50,12 @@ var VideoColumns = struct {
 	Opened       string
 	Rating       string
 	Duration     string
-	VideoFile    string
 	CreatedAt    string
 	UpdatedAt    string
 	DeletedAt    string
+	Language     string
+	Search       string
+	Version      string
 }{
 	ID:           "id",
 	Title:        "title",
@@ -60,10 +64,12 @@ var VideoColumns = struct {
 	Opened:       "opened",
 	Rating:       "rating",
 	Duration:     "duration",
-	VideoFile:    "video_file",
 	CreatedAt:    "created_at",
 	UpdatedAt:    "updated_at",
 	DeletedAt:    "deleted_at",
+	Language:     "language",
+	Search:       "search",
+	Version:      "version",
 }
 
 // Generated where
@@ -99,10 +105,12 @@ var VideoWhere = struct {
 	Opened       whereHelpernull_Bool
 	Rating       whereHelperint16
 	Duration     whereHelperint16
-	VideoFile    whereHelpernull_String
-	CreatedAt    whereHelpernull_Time
+	CreatedAt    whereHelpertime_Time
 	UpdatedAt    whereHelpernull_Time
 	DeletedAt    whereHelpernull_Time
+	Language     whereHelperstring
+	Search       whereHelpernull_String
+	Version      whereHelperint64
 }{
 	ID:           whereHelperstring{field: "\"videos\".\"id\""},
 	Title:        whereHelperstring{field: "\"videos\".\"title\""},
@@ -111,25 +119,33 @@ var VideoWhere = struct {
 	Opened:       whereHelpernull_Bool{field: "\"videos\".\"opened\""},
 	Rating:       whereHelperint16{field: "\"videos\".\"rating\""},
 	Duration:     whereHelperint16{field: "\"videos\".\"duration\""},
-	VideoFile:    whereHelpernull_String{field: "\"videos\".\"video_file\""},
-	CreatedAt:    whereHelpernull_Time{field: "\"videos\".\"created_at\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"videos\".\"created_at\""},
 	UpdatedAt:    whereHelpernull_Time{field: "\"videos\".\"updated_at\""},
 	DeletedAt:    whereHelpernull_Time{field: "\"videos\".\"deleted_at\""},
+	Language:     whereHelperstring{field: "\"videos\".\"language\""},
+	Search:       whereHelpernull_String{field: "\"videos\".\"search\""},
+	Version:      whereHelperint64{field: "\"videos\".\"version\""},
 }
 
 // VideoRels is where relationship names are stored.
 var VideoRels = struct {
-	Categories string
-	Genres     string
+	CastMemberVideos string
+	Categories       string
+	Genres           string
+	VideoAssets      string
 }{
-	Categories: "Categories",
-	Genres:     "Genres",
+	CastMemberVideos: "CastMemberVideos",
+	Categories:       "Categories",
+	Genres:           "Genres",
+	VideoAssets:      "VideoAssets",
 }
 
 // videoR is where relationships are stored.
 type videoR struct {
-	Categories CategorySlice `boil:"Categories" json:"Categories" toml:"Categories" yaml:"Categories"`
-	Genres     GenreSlice    `boil:"Genres" json:"Genres" toml:"Genres" yaml:"Genres"`
+	CastMemberVideos CastMemberVideoSlice `boil:"CastMemberVideos" json:"CastMemberVideos" toml:"CastMemberVideos" yaml:"CastMemberVideos"`
+	Categories       CategorySlice        `boil:"Categories" json:"Categories" toml:"Categories" yaml:"Categories"`
+	Genres           GenreSlice           `boil:"Genres" json:"Genres" toml:"Genres" yaml:"Genres"`
+	VideoAssets      VideoAssetSlice      `boil:"VideoAssets" json:"VideoAssets" toml:"VideoAssets" yaml:"VideoAssets"`
 }
 
 // NewStruct creates a new relationship struct
@@ -141,9 +157,9 @@ func (*videoR) NewStruct() *videoR {
 type videoL struct{}
 
 var (
-	videoAllColumns            = []string{"id", "title", "description", "year_launched", "opened", "rating", "duration", "video_file", "created_at", "updated_at", "deleted_at"}
-	videoColumnsWithoutDefault = []string{"id", "title", "description", "year_launched", "rating", "duration", "video_file", "created_at", "updated_at", "deleted_at"}
-	videoColumnsWithDefault    = []string{"opened"}
+	videoAllColumns            = []string{"id", "title", "description", "year_launched", "opened", "rating", "duration", "created_at", "updated_at", "deleted_at", "language", "search", "version"}
+	videoColumnsWithoutDefault = []string{"id", "title", "description", "year_launched", "rating", "duration", "updated_at", "deleted_at"}
+	videoColumnsWithDefault    = []string{"opened", "created_at", "language", "search", "version"}
 	videoPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -442,6 +458,27 @@ func (q videoQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool
 	return count > 0, nil
 }
 
+// CastMemberVideos retrieves all the cast_member_video's CastMemberVideos with an executor.
+func (o *Video) CastMemberVideos(mods ...qm.QueryMod) castMemberVideoQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"cast_member_video\".\"video_id\"=?", o.ID),
+	)
+
+	query := CastMemberVideos(queryMods...)
+	queries.SetFrom(query.Query, "\"cast_member_video\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"cast_member_video\".*"})
+	}
+
+	return query
+}
+
 // Categories retrieves all the category's Categories with an executor.
 func (o *Video) Categories(mods ...qm.QueryMod) categoryQuery {
 	var queryMods []qm.QueryMod
@@ -486,6 +523,125 @@ func (o *Video) Genres(mods ...qm.QueryMod) genreQuery {
 	return query
 }
 
+// VideoAssets retrieves all the video_asset's VideoAssets with an executor.
+func (o *Video) VideoAssets(mods ...qm.QueryMod) videoAssetQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"video_assets\".\"video_id\"=?", o.ID),
+	)
+
+	query := VideoAssets(queryMods...)
+	queries.SetFrom(query.Query, "\"video_assets\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"video_assets\".*"})
+	}
+
+	return query
+}
+
+// LoadCastMemberVideos allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (videoL) LoadCastMemberVideos(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVideo interface{}, mods queries.Applicator) error {
+	var slice []*Video
+	var object *Video
+
+	if singular {
+		object = maybeVideo.(*Video)
+	} else {
+		slice = *maybeVideo.(*[]*Video)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &videoR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &videoR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`cast_member_video`),
+		qm.WhereIn(`cast_member_video.video_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load cast_member_video")
+	}
+
+	var resultSlice []*CastMemberVideo
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice cast_member_video")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on cast_member_video")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for cast_member_video")
+	}
+
+	if len(castMemberVideoAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.CastMemberVideos = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &castMemberVideoR{}
+			}
+			foreign.R.Video = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.VideoID {
+				local.R.CastMemberVideos = append(local.R.CastMemberVideos, foreign)
+				if foreign.R == nil {
+					foreign.R = &castMemberVideoR{}
+				}
+				foreign.R.Video = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCategories allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (videoL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVideo interface{}, mods queries.Applicator) error {
@@ -530,6 +686,7 @@ func (videoL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singul
 		qm.From("\"categories\""),
 		qm.InnerJoin("\"category_video\" as \"a\" on \"categories\".\"id\" = \"a\".\"category_id\""),
 		qm.WhereIn("\"a\".\"video_id\" in ?", args...),
+		qmhelper.WhereIsNull("\"categories\".\"deleted_at\""),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -547,7 +704,7 @@ func (videoL) LoadCategories(ctx context.Context, e boil.ContextExecutor, singul
 		one := new(Category)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Name, &one.Description, &one.IsValidated, &one.CreatedAt, &one.UpdatedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Name, &one.Description, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for categories")
 		}
@@ -663,7 +820,7 @@ func (videoL) LoadGenres(ctx context.Context, e boil.ContextExecutor, singular b
 		one := new(Genre)
 		var localJoinCol string
 
-		err = results.Scan(&one.ID, &one.Name, &one.IsValidated, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Name, &one.CreatedAt, &one.UpdatedAt, &one.DeletedAt, &one.Version, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for genres")
 		}
@@ -717,6 +874,166 @@ func (videoL) LoadGenres(ctx context.Context, e boil.ContextExecutor, singular b
 	return nil
 }
 
+// LoadVideoAssets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (videoL) LoadVideoAssets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVideo interface{}, mods queries.Applicator) error {
+	var slice []*Video
+	var object *Video
+
+	if singular {
+		object = maybeVideo.(*Video)
+	} else {
+		slice = *maybeVideo.(*[]*Video)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &videoR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &videoR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`video_assets`),
+		qm.WhereIn(`video_assets.video_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load video_assets")
+	}
+
+	var resultSlice []*VideoAsset
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice video_assets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on video_assets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for video_assets")
+	}
+
+	if len(videoAssetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.VideoAssets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &videoAssetR{}
+			}
+			foreign.R.Video = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.VideoID {
+				local.R.VideoAssets = append(local.R.VideoAssets, foreign)
+				if foreign.R == nil {
+					foreign.R = &videoAssetR{}
+				}
+				foreign.R.Video = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddCastMemberVideosG adds the given related objects to the existing relationships
+// of the video, optionally inserting them as new records.
+// Appends related to o.R.CastMemberVideos.
+// Sets related.R.Video appropriately.
+// Uses the global database handle.
+func (o *Video) AddCastMemberVideosG(ctx context.Context, insert bool, related ...*CastMemberVideo) error {
+	return o.AddCastMemberVideos(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddCastMemberVideos adds the given related objects to the existing relationships
+// of the video, optionally inserting them as new records.
+// Appends related to o.R.CastMemberVideos.
+// Sets related.R.Video appropriately.
+func (o *Video) AddCastMemberVideos(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*CastMemberVideo) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.VideoID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"cast_member_video\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"video_id"}),
+				strmangle.WhereClause("\"", "\"", 2, castMemberVideoPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.CastMemberID, rel.VideoID, rel.Role}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.VideoID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &videoR{
+			CastMemberVideos: related,
+		}
+	} else {
+		o.R.CastMemberVideos = append(o.R.CastMemberVideos, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &castMemberVideoR{
+				Video: o,
+			}
+		} else {
+			rel.R.Video = o
+		}
+	}
+	return nil
+}
+
 // AddCategoriesG adds the given related objects to the existing relationships
 // of the video, optionally inserting them as new records.
 // Appends related to o.R.Categories.
@@ -1053,6 +1370,68 @@ func removeGenresFromVideosSlice(o *Video, related []*Genre) {
 	}
 }
 
+// AddVideoAssetsG adds the given related objects to the existing relationships
+// of the video, optionally inserting them as new records.
+// Appends related to o.R.VideoAssets.
+// Sets related.R.Video appropriately.
+// Uses the global database handle.
+func (o *Video) AddVideoAssetsG(ctx context.Context, insert bool, related ...*VideoAsset) error {
+	return o.AddVideoAssets(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddVideoAssets adds the given related objects to the existing relationships
+// of the video, optionally inserting them as new records.
+// Appends related to o.R.VideoAssets.
+// Sets related.R.Video appropriately.
+func (o *Video) AddVideoAssets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VideoAsset) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.VideoID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"video_assets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"video_id"}),
+				strmangle.WhereClause("\"", "\"", 2, videoAssetPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.VideoID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &videoR{
+			VideoAssets: related,
+		}
+	} else {
+		o.R.VideoAssets = append(o.R.VideoAssets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &videoAssetR{
+				Video: o,
+			}
+		} else {
+			rel.R.Video = o
+		}
+	}
+	return nil
+}
+
 // Videos retrieves all the records using an executor.
 func Videos(mods ...qm.QueryMod) videoQuery {
 	mods = append(mods, qm.From("\"videos\""), qmhelper.WhereIsNull("\"videos\".\"deleted_at\""))
@@ -1106,8 +1485,8 @@ func (o *Video) Insert(ctx context.Context, exec boil.ContextExecutor, columns b
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		if queries.MustTime(o.UpdatedAt).IsZero() {
 			queries.SetScanner(&o.UpdatedAt, currTime)
@@ -1348,8 +1727,8 @@ func (o *Video) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnC
 	if !boil.TimestampsAreSkipped(ctx) {
 		currTime := time.Now().In(boil.GetLocation())
 
-		if queries.MustTime(o.CreatedAt).IsZero() {
-			queries.SetScanner(&o.CreatedAt, currTime)
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
 		}
 		queries.SetScanner(&o.UpdatedAt, currTime)
 	}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// handleAPIKeyCreate answers with the new key and its secret, which is never told again
+func (s *server) handleAPIKeyCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dto := &auth.CreateAPIKeyDTO{}
+		if err := s.bodyToStruct(w, r, dto); err != nil {
+			return
+		}
+		key, err := s.apiKeys.Create(actorOf(r), *dto)
+		if err != nil {
+			if errors.Is(err, logger.ErrIsRequired) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Location", path.Join(r.URL.Path, key.ID))
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(key); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
+
+func (s *server) handleAPIKeysGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if err := checkParams(query, nil); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		keys, info, err := s.apiKeys.List(page)
+		if err != nil {
+			if errors.Is(err, logger.ErrIsNotValidated) || errors.Is(err, logger.ErrInvalidedLimit) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		s.writePage(w, r, keys, info)
+	}
+}
+
+// handleAPIKeyRevoke refuses the requests of the key named by the id parameter from now on
+func (s *server) handleAPIKeyRevoke() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		if err := s.apiKeys.Revoke(params.ByName("id")); err != nil {
+			if errors.Is(err, logger.ErrNotFound) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+	}
+}
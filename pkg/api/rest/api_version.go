@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/selmison/code-micro-videos/config"
+)
+
+// apiVersion is a set of handlers of the API served under /<name>. A breaking change of the DTOs ships as a new
+// version, the one it replaces is deprecated and served until its sunset.
+type apiVersion struct {
+	name        string
+	routes      func() []route
+	deprecation deprecation
+}
+
+// apiVersions are the versions of the API, the current one last
+func (s *server) apiVersions() []apiVersion {
+	return []apiVersion{
+		{name: "v1", routes: s.routesV1},
+	}
+}
+
+// rootVersion is the version the paths without a version alias
+const rootVersion = "v1"
+
+// newRootDeprecation leaves the paths without a version to their clients for the transition period of cfg, they
+// moved to the ones of rootVersion
+func newRootDeprecation(cfg config.Deprecation) deprecation {
+	return deprecation{at: cfg.At, sunset: cfg.Sunset, successor: "/" + rootVersion}
+}
+
+// deprecation tells the clients of a deprecated version since when it is, by the Deprecation header (RFC 9745),
+// until when it is served, by the Sunset header (RFC 8594), and where its routes moved to, by a Link header
+type deprecation struct {
+	at        time.Time
+	sunset    time.Time
+	successor string
+}
+
+// wrap adds the headers of the deprecation to the responses of next, which is served under prefix. next is left
+// as it is when there is no deprecation.
+func (d deprecation) wrap(prefix string, next http.HandlerFunc) http.HandlerFunc {
+	if d.at.IsZero() {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", fmt.Sprintf("@%d", d.at.Unix()))
+		if !d.sunset.IsZero() {
+			w.Header().Set("Sunset", d.sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.successor != "" {
+			successor := d.successor + strings.TrimPrefix(r.URL.Path, prefix)
+			w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+		}
+		next(w, r)
+	}
+}
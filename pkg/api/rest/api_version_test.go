@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/selmison/code-micro-videos/config"
+)
+
+func TestServer_apiVersions(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, newRootDeprecation(config.Deprecation{
+		At:     time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, time.April, 17, 0, 0, 0, 0, time.UTC),
+	}))
+	tests := []struct {
+		name            string
+		path            string
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{
+			name: "When the path has the version",
+			path: "/" + rootVersion + OpenAPIPath,
+		},
+		{
+			name:            "When the path has no version",
+			path:            OpenAPIPath,
+			wantDeprecation: "@1792195200",
+			wantSunset:      "Sat, 17 Apr 2027 00:00:00 GMT",
+			wantLink:        `</` + rootVersion + OpenAPIPath + `>; rel="successor-version"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Deprecation: %q, want: %q", got, tt.wantDeprecation)
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Sunset: %q, want: %q", got, tt.wantSunset)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Link: %q, want: %q", got, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestServer_apiVersions_NotDeprecated(t *testing.T) {
+	w := httptest.NewRecorder()
+	newServer(nil, nil, nil, nil, nil, nil, deprecation{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+	}
+	for _, header := range []string{"Deprecation", "Sunset", "Link"} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf("%s: %q, want none", header, got)
+		}
+	}
+}
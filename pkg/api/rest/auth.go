@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// The schemes of the Authorization header, a token of a user or a key of a machine client
+const (
+	BearerScheme = "Bearer"
+	APIKeyScheme = "ApiKey"
+)
+
+// newVerifier verifies the tokens against the JWKS of cfg. It fails without a JWKS, the requests are left
+// unauthenticated, by a nil verifier, only when cfg disables the authentication.
+func newVerifier(cfg auth.Config) (*auth.Verifier, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+	verifier, err := auth.NewVerifier(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify the tokens, the authentication has to be disabled to serve the requests without: %w", err)
+	}
+	return verifier, nil
+}
+
+// authenticate verifies the token or the API key of r and returns r with its principal in its context. A request
+// without credentials goes on anonymously, the routes it is not allowed refuse it. The API keys are checked
+// whether the tokens are verified or not, so a machine client is told apart when the authentication is disabled.
+func (s *server) authenticate(r *http.Request) (*http.Request, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return r, nil
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return r, fmt.Errorf("authorization header %w: it bears no credentials", logger.ErrIsNotAuthenticated)
+	}
+	credentials := strings.TrimSpace(parts[1])
+	var (
+		p   auth.Principal
+		err error
+	)
+	switch {
+	case strings.EqualFold(parts[0], BearerScheme) && s.auth != nil:
+		p, err = s.auth.Verify(credentials)
+	case strings.EqualFold(parts[0], APIKeyScheme) && s.apiKeys != nil:
+		p, err = s.apiKeys.Authenticate(credentials)
+	default:
+		err = fmt.Errorf("authorization scheme %s %w", parts[0], logger.ErrIsNotAuthenticated)
+	}
+	if err != nil {
+		return r, err
+	}
+	return r.WithContext(auth.NewContext(r.Context(), p)), nil
+}
+
+// authorize serves by next the requests of a principal allowed role or scope, every request when they are not
+// authenticated
+func (s *server) authorize(role auth.Role, scope auth.Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.auth == nil {
+			next(w, r)
+			return
+		}
+		p, ok := auth.FromContext(r.Context())
+		if !ok {
+			s.errUnauthorized(w, r, fmt.Errorf("request %w", logger.ErrIsNotAuthenticated))
+			return
+		}
+		if !p.Allows(role, scope) {
+			needs := fmt.Sprintf("the %s role", role)
+			if p.KeyID != "" {
+				needs = fmt.Sprintf("the %s scope", scope)
+				if scope == "" {
+					needs = "a token"
+				}
+			}
+			s.errForbidden(w, r, fmt.Errorf("%s %s %w to %s without %s", r.Method, r.URL.Path, logger.ErrIsForbidden, p.Subject, needs))
+			return
+		}
+		next(w, r)
+	}
+}
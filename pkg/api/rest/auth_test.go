@@ -0,0 +1,311 @@
+package rest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// newTestVerifier returns a verifier of the tokens signed by the returned func, which are given the roles
+func newTestVerifier(t *testing.T) (*auth.Verifier, func(roles ...string) string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("test: generate key: %v", err)
+	}
+	rootDir, err := ioutil.TempDir("", "rest-auth")
+	if err != nil {
+		t.Fatalf("test: create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(rootDir) })
+	jwk := jose.JSONWebKey{Key: key, KeyID: "fake", Algorithm: string(jose.ES256), Use: "sig"}
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk.Public()}})
+	if err != nil {
+		t.Fatalf("test: marshal jwks: %v", err)
+	}
+	jwks := filepath.Join(rootDir, "jwks.json")
+	if err := ioutil.WriteFile(jwks, b, 0644); err != nil {
+		t.Fatalf("test: write jwks: %v", err)
+	}
+	v, err := auth.NewVerifier(auth.Config{JWKS: jwks})
+	if err != nil {
+		t.Fatalf("test: new verifier: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jwk}, nil)
+	if err != nil {
+		t.Fatalf("test: new signer: %v", err)
+	}
+	sign := func(roles ...string) string {
+		claims := jwt.Claims{Subject: "fake", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}
+		token, err := jwt.Signed(signer).Claims(claims).Claims(map[string]interface{}{"roles": roles}).CompactSerialize()
+		if err != nil {
+			t.Fatalf("test: sign token: %v", err)
+		}
+		return token
+	}
+	return v, sign
+}
+
+// fakeAPIKeyStore keeps the API keys of the tests in memory
+type fakeAPIKeyStore map[string]auth.APIKey
+
+func (f fakeAPIKeyStore) AddAPIKey(key auth.APIKey, hash string) error {
+	f[hash] = key
+	return nil
+}
+
+func (f fakeAPIKeyStore) GetAPIKeys(crud.Page) ([]auth.APIKey, crud.PageInfo, error) {
+	return nil, crud.PageInfo{}, nil
+}
+
+func (f fakeAPIKeyStore) FetchAPIKeyByHash(hash string) (auth.APIKey, error) {
+	key, ok := f[hash]
+	if !ok {
+		return auth.APIKey{}, logger.ErrNotFound
+	}
+	return key, nil
+}
+
+func (f fakeAPIKeyStore) RevokeAPIKey(string, time.Time) error { return nil }
+
+func (f fakeAPIKeyStore) TouchAPIKey(string, time.Time) error { return nil }
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("When the authentication is disabled", func(t *testing.T) {
+		if v, err := newVerifier(auth.Config{Disabled: true}); v != nil || err != nil {
+			t.Errorf("newVerifier() = %v, error: %v, want no verifier", v, err)
+		}
+	})
+	t.Run("When no JWKS is configured", func(t *testing.T) {
+		if v, err := newVerifier(auth.Config{}); v != nil || !errors.Is(err, logger.ErrIsRequired) {
+			t.Errorf("newVerifier() = %v, error: %v, want: %v", v, err, logger.ErrIsRequired)
+		}
+	})
+}
+
+func TestServer_unauthenticatedWrites(t *testing.T) {
+	verifier, _ := newTestVerifier(t)
+	s := newServer(nil, nil, nil, verifier, nil, nil, deprecation{})
+	writes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/v1/categories"},
+		{http.MethodPut, "/v1/genres/fake"},
+		{http.MethodPatch, "/v1/cast_members/fake"},
+		{http.MethodDelete, "/v1/videos/fake"},
+		{http.MethodPost, "/v1/uploads"},
+		{http.MethodPost, "/categories"},
+	}
+	for _, write := range writes {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(write.method, write.path, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("statusCode of %s %s: %v, want: %v", write.method, write.path, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestServer_routesRoles(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, deprecation{})
+	for _, version := range s.apiVersions() {
+		for _, route := range version.routes() {
+			if !route.role.Valid() {
+				t.Errorf("route %s %s of %s: role %s, want a role", route.method, route.pattern, version.name, route.role)
+			}
+		}
+	}
+}
+
+func TestServer_authorize(t *testing.T) {
+	verifier, sign := newTestVerifier(t)
+	apiKeys := auth.NewAPIKeys(fakeAPIKeyStore{})
+	writer, err := apiKeys.Create("fake", auth.CreateAPIKeyDTO{Name: "writer", Scopes: []auth.Scope{auth.CatalogWrite}})
+	if err != nil {
+		t.Fatalf("test: create api key: %v", err)
+	}
+	reader, err := apiKeys.Create("fake", auth.CreateAPIKeyDTO{Name: "reader", Scopes: []auth.Scope{auth.CatalogRead}})
+	if err != nil {
+		t.Fatalf("test: create api key: %v", err)
+	}
+	tests := []struct {
+		name          string
+		verifier      *auth.Verifier
+		apiKeys       *auth.APIKeys
+		scope         auth.Scope
+		authorization string
+		wantStatus    int
+		wantChallenge string
+		wantActor     string
+	}{
+		{
+			name:       "When the requests are not authenticated",
+			wantStatus: http.StatusOK,
+			wantActor:  AnonymousActor,
+		},
+		{
+			name:          "When the authentication is disabled and the request bears an api key",
+			apiKeys:       apiKeys,
+			scope:         auth.CatalogWrite,
+			authorization: "ApiKey " + reader.Key,
+			wantStatus:    http.StatusOK,
+			wantActor:     "api_key:" + reader.ID,
+		},
+		{
+			name:          "When the authentication is disabled and the request bears a token",
+			apiKeys:       apiKeys,
+			authorization: "Bearer " + sign("editor"),
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Bearer error="invalid_token", ApiKey`,
+		},
+		{
+			name:          "When the request bears no token",
+			verifier:      verifier,
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: "Bearer",
+		},
+		{
+			name:          "When the request is authenticated by another scheme",
+			verifier:      verifier,
+			authorization: "Basic ZmFrZTpmYWtl",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:          "When the token is not verified",
+			verifier:      verifier,
+			authorization: "Bearer fake",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:          "When the token has a role below the one of the route",
+			verifier:      verifier,
+			authorization: "Bearer " + sign("viewer"),
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "When the token has the role of the route",
+			verifier:      verifier,
+			authorization: "Bearer " + sign("editor"),
+			wantStatus:    http.StatusOK,
+			wantActor:     "fake",
+		},
+		{
+			name:          "When the token has a role above the one of the route",
+			verifier:      verifier,
+			authorization: "bearer " + sign("viewer", "admin"),
+			wantStatus:    http.StatusOK,
+			wantActor:     "fake",
+		},
+		{
+			name:          "When the request bears an api key but the api keys are not served",
+			verifier:      verifier,
+			scope:         auth.CatalogWrite,
+			authorization: "ApiKey " + writer.Key,
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:          "When the api key is not known",
+			verifier:      verifier,
+			apiKeys:       apiKeys,
+			scope:         auth.CatalogWrite,
+			authorization: "ApiKey cmv_fake",
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: `Bearer error="invalid_token", ApiKey`,
+		},
+		{
+			name:          "When the api key has not the scope of the route",
+			verifier:      verifier,
+			apiKeys:       apiKeys,
+			scope:         auth.CatalogWrite,
+			authorization: "ApiKey " + reader.Key,
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "When the route has no scope",
+			verifier:      verifier,
+			apiKeys:       apiKeys,
+			authorization: "ApiKey " + writer.Key,
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "When the api key has the scope of the route",
+			verifier:      verifier,
+			apiKeys:       apiKeys,
+			scope:         auth.CatalogWrite,
+			authorization: "apikey " + writer.Key,
+			wantStatus:    http.StatusOK,
+			wantActor:     "api_key:" + writer.ID,
+		},
+		{
+			name:          "When a token is allowed the route of an api key",
+			verifier:      verifier,
+			apiKeys:       apiKeys,
+			scope:         auth.CatalogWrite,
+			authorization: "Bearer " + sign("editor"),
+			wantStatus:    http.StatusOK,
+			wantActor:     "fake",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(nil, nil, nil, tt.verifier, tt.apiKeys, nil, deprecation{})
+			s.router.HandlerFunc(http.MethodPost, "/fake", s.authorize(auth.Editor, tt.scope, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(actorOf(r)))
+			}))
+			r := httptest.NewRequest(http.MethodPost, "/fake", nil)
+			// the actor a client tells is not trusted
+			r.Header.Set("X-Actor", "spoofed")
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("statusCode: %v, want: %v", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != tt.wantChallenge {
+				t.Errorf("WWW-Authenticate: %q, want: %q", got, tt.wantChallenge)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var p Problem
+				if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+					t.Fatalf("test: decode body: %v", err)
+				}
+				if p.Status != tt.wantStatus {
+					t.Errorf("problem status: %v, want: %v", p.Status, tt.wantStatus)
+				}
+				return
+			}
+			if got := w.Body.String(); got != tt.wantActor {
+				t.Errorf("actor: %s, want: %s", got, tt.wantActor)
+			}
+		})
+	}
+	t.Run("When the document of the API is fetched without token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newServer(nil, nil, nil, verifier, nil, nil, deprecation{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1"+OpenAPIPath, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+		}
+	})
+}
@@ -3,9 +3,8 @@ package rest
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
-	"math"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
@@ -18,58 +17,61 @@ import (
 func (s *server) handleCastMemberCreate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		castMemberDTO := &crud.CastMemberDTO{}
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := r.Body.Close(); err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := json.Unmarshal(body, &castMemberDTO); err != nil {
-			s.errUnprocessableEntity(w, err)
-			if err := json.NewEncoder(w).Encode(err); err != nil {
-				s.errInternalServer(w, err)
-			}
+		if err := s.bodyToStruct(w, r, castMemberDTO); err != nil {
+			return
 		}
-		if err := s.svc.AddCastMember(*castMemberDTO); err != nil {
+		id, err := s.svcAs(r).AddCastMember(*castMemberDTO)
+		if err != nil {
 			if errors.Is(err, logger.ErrIsRequired) {
-				s.errBadRequest(w, err)
+				s.errBadRequest(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrAlreadyExists) {
-				s.errStatusConflict(w, err)
+				s.errStatusConflict(w, r, err)
 				return
 			}
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Location", path.Join(r.URL.Path, id.String()))
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(http.StatusText(http.StatusCreated)); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleCastMembersGet() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		castMembers, err := s.svc.GetCastMembers(math.MaxInt8)
+		filter, err := filterFromQuery(r.URL.Query(), castMemberFilterParams)
 		if err != nil {
-			s.errInternalServer(w, err)
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(r.URL.Query())
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		castMembers, info, err := s.svc.GetCastMembers(filter, page)
+		if err != nil {
+			if errors.Is(err, logger.ErrInvalidedLimit) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
 		castMembersDTO := make([]crud.CastMemberDTO, len(castMembers))
 		for i, castMember := range castMembers {
 			castMembersDTO[i] = crud.CastMemberDTO{
+				ID:   castMember.ID,
 				Name: castMember.Name,
 				Type: crud.CastMemberType(castMember.Type),
 			}
 		}
-		if err := json.NewEncoder(w).Encode(castMembersDTO); err != nil {
-			s.errInternalServer(w, err)
-		}
+		s.writePage(w, r, castMembersDTO, info)
 	}
 }
 
@@ -78,55 +80,66 @@ func (s *server) handleCastMemberGet() http.HandlerFunc {
 		var castMember models.CastMember
 		var err error
 		params := httprouter.ParamsFromContext(r.Context())
-		if castMemberName := params.ByName("name"); strings.TrimSpace(castMemberName) != "" {
-			castMember, err = s.svc.FetchCastMember(castMemberName)
+		if castMemberID := params.ByName("id"); strings.TrimSpace(castMemberID) != "" {
+			castMember, err = s.svc.FetchCastMember(castMemberID)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		setETag(w, castMember.Version)
 		w.WriteHeader(http.StatusOK)
 		castMemberDTO := crud.CastMemberDTO{
+			ID:   castMember.ID,
 			Name: castMember.Name,
+			Type: crud.CastMemberType(castMember.Type),
 		}
 		if err := json.NewEncoder(w).Encode(castMemberDTO); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleCastMemberUpdate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		castMemberDTO := &crud.CastMemberDTO{}
 		if err := s.bodyToStruct(w, r, castMemberDTO); err != nil {
 			return
 		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if castMemberName := params.ByName("name"); strings.TrimSpace(castMemberName) != "" {
-			err = s.svc.UpdateCastMember(castMemberName, *castMemberDTO)
+		if castMemberID := params.ByName("id"); strings.TrimSpace(castMemberID) != "" {
+			err = s.svcAs(r).UpdateCastMember(castMemberID, version, *castMemberDTO)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -134,23 +147,95 @@ func (s *server) handleCastMemberUpdate() http.HandlerFunc {
 
 func (s *server) handleCastMemberDelete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		svc := s.svcAs(r)
+		remove, err := removeOrPurge(r, svc.RemoveCastMember, svc.PurgeCastMember)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if castMemberName := params.ByName("name"); strings.TrimSpace(castMemberName) != "" {
-			err = s.svc.RemoveCastMember(castMemberName)
+		if castMemberID := params.ByName("id"); strings.TrimSpace(castMemberID) != "" {
+			err = remove(castMemberID, version)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
-				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsNotValidated) || errors.Is(err, logger.ErrIsRequired) {
+					s.errBadRequest(w, r, err)
 					return
 				}
+				s.errInternalServer(w, r, err)
+				return
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
 }
+
+// creditDTO is a video of a filmography with the role the cast member had in it
+type creditDTO struct {
+	*crud.VideoDTO
+	Role         crud.CastMemberType `json:"role"`
+	Character    string              `json:"character,omitempty"`
+	BillingOrder int16               `json:"billing_order"`
+}
+
+func (s *server) handleCastMemberVideosGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if err := checkParams(query, nil); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		params := httprouter.ParamsFromContext(r.Context())
+		credits, info, err := s.svc.GetCastMemberVideos(params.ByName("id"), page)
+		if err != nil {
+			if errors.Is(err, logger.ErrNotFound) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrIsRequired) ||
+				errors.Is(err, logger.ErrIsNotValidated) ||
+				errors.Is(err, logger.ErrInvalidedLimit) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		creditsDTO := make([]creditDTO, len(credits))
+		for i, credit := range credits {
+			video := credit.R.Video
+			dto, err := crud.MapVideoToDTO(*video)
+			if err != nil {
+				s.errInternalServer(w, r, err)
+				return
+			}
+			dto.Assets = videoAssetsToDTO(*video)
+			creditsDTO[i] = creditDTO{
+				VideoDTO:     dto,
+				Role:         crud.CastMemberType(credit.Role),
+				Character:    credit.CharacterName.String,
+				BillingOrder: credit.BillingOrder,
+			}
+		}
+		s.writePage(w, r, creditsDTO, info)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/bxcodec/faker/v3"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/testdata"
 )
@@ -117,7 +118,9 @@ func Test_RestApi_Post_CastMembers(t *testing.T) {
 			},
 			want: response{
 				status: http.StatusBadRequest,
-				body:   http.StatusText(http.StatusBadRequest),
+				body: `{"type":"urn:code-micro-videos:problem:validation_failed","title":"Bad Request","status":400,` +
+					`"detail":"'name' field is required","instance":"/cast_members","code":"validation_failed",` +
+					`"errors":[{"field":"name","rule":"not_blank"}]}`,
 			},
 			wantErr: false,
 		},
@@ -182,6 +185,17 @@ func Test_RestApi_Get_CastMembers(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "When type is unknown",
+			req: request{
+				url:         fakeUrl + "?type=stunt",
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusBadRequest,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -195,6 +209,9 @@ func Test_RestApi_Get_CastMembers(t *testing.T) {
 					t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.want.status)
 					return
 				}
+				if got.StatusCode != http.StatusOK {
+					return
+				}
 				body, err := ioutil.ReadAll(got.Body)
 				if err != nil {
 					t.Errorf("read body: %v", err)
@@ -220,11 +237,11 @@ func Test_RestApi_Get_CastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	fakeExistCastMemberDTO := testdata.FakeCastMembersDTO[0]
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", name)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", id)
 	}
 	type request struct {
 		url         string
@@ -241,9 +258,9 @@ func Test_RestApi_Get_CastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -253,9 +270,9 @@ func Test_RestApi_Get_CastMember(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -306,10 +323,10 @@ func Test_RestApi_Delete_CastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", name)
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", id)
 	}
 	type request struct {
 		url         string
@@ -326,9 +343,9 @@ func Test_RestApi_Delete_CastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -338,9 +355,9 @@ func Test_RestApi_Delete_CastMember(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -356,6 +373,7 @@ func Test_RestApi_Delete_CastMember(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -387,10 +405,10 @@ func Test_RestApi_Update_CastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", name)
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "cast_members", id)
 	}
 	type request struct {
 		url         string
@@ -408,9 +426,9 @@ func Test_RestApi_Update_CastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s"}`,
@@ -424,9 +442,9 @@ func Test_RestApi_Update_CastMember(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s", "avatar": "%s", "whatsapp": "%s", "bio": "%s"}`,
@@ -449,6 +467,7 @@ func Test_RestApi_Update_CastMember(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -472,3 +491,62 @@ func Test_RestApi_Update_CastMember(t *testing.T) {
 		})
 	}
 }
+
+func Test_RestApi_Get_CastMemberVideos(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeCastMembers)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s/%s", cfg.AddressServer, "cast_members", id, "videos")
+	}
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{
+			name:       "When id doesn't exist",
+			url:        fakeUrl(uuid.New().String()),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "When a cursor is given",
+			url:        fakeUrl(testdata.FakeCastMembers[0].ID) + "?cursor=" + faker.Word(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "When id exists",
+			url:        fakeUrl(testdata.FakeCastMembers[0].ID),
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := http.Get(tt.url)
+			if err != nil {
+				t.Errorf("error: %v", err)
+				return
+			}
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.wantStatus)
+				return
+			}
+			if got.StatusCode != http.StatusOK {
+				return
+			}
+			var page struct {
+				Data  []json.RawMessage `json:"data"`
+				Total int               `json:"total"`
+			}
+			if err := json.NewDecoder(got.Body).Decode(&page); err != nil {
+				t.Fatalf("test: decode body: %v", err)
+			}
+			if len(page.Data) != 0 || page.Total != 0 {
+				t.Errorf("filmography: %d of %d, want none", len(page.Data), page.Total)
+			}
+		})
+	}
+}
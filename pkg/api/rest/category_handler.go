@@ -3,9 +3,8 @@ package rest
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
-	"math"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
@@ -18,62 +17,65 @@ import (
 func (s *server) handleCategoryCreate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		categoryDTO := &crud.CategoryDTO{}
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := r.Body.Close(); err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := json.Unmarshal(body, &categoryDTO); err != nil {
-			s.errUnprocessableEntity(w, err)
-			if err := json.NewEncoder(w).Encode(err); err != nil {
-				s.errInternalServer(w, err)
-			}
+		if err := s.bodyToStruct(w, r, categoryDTO); err != nil {
+			return
 		}
-		if err := s.svc.AddCategory(*categoryDTO); err != nil {
+		id, err := s.svcAs(r).AddCategory(*categoryDTO)
+		if err != nil {
 			if errors.Is(err, logger.ErrIsRequired) {
-				s.errBadRequest(w, err)
+				s.errBadRequest(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrAlreadyExists) {
-				s.errStatusConflict(w, err)
+				s.errStatusConflict(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrNotFound) {
-				s.errNotFound(w, err)
+				s.errNotFound(w, r, err)
 				return
 			}
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Location", path.Join(r.URL.Path, id.String()))
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(http.StatusText(http.StatusCreated)); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleCategoriesGet() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		categories, err := s.svc.GetCategories(math.MaxInt8)
+		filter, err := filterFromQuery(r.URL.Query(), categoryFilterParams)
 		if err != nil {
-			s.errInternalServer(w, err)
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(r.URL.Query())
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		categories, info, err := s.svc.GetCategories(filter, page)
+		if err != nil {
+			if errors.Is(err, logger.ErrInvalidedLimit) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
 		categoriesDTO := make([]crud.CategoryDTO, len(categories))
 		for i, category := range categories {
 			categoriesDTO[i] = crud.CategoryDTO{
+				ID:          category.ID,
 				Name:        category.Name,
 				Description: category.Description.String,
 			}
 		}
-		if err := json.NewEncoder(w).Encode(categoriesDTO); err != nil {
-			s.errInternalServer(w, err)
-		}
+		s.writePage(w, r, categoriesDTO, info)
 	}
 }
 
@@ -82,56 +84,66 @@ func (s *server) handleCategoryGet() http.HandlerFunc {
 		var category models.Category
 		var err error
 		params := httprouter.ParamsFromContext(r.Context())
-		if categoryName := params.ByName("name"); strings.TrimSpace(categoryName) != "" {
-			category, err = s.svc.FetchCategory(categoryName)
+		if categoryID := params.ByName("id"); strings.TrimSpace(categoryID) != "" {
+			category, err = s.svc.FetchCategory(categoryID)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		setETag(w, category.Version)
 		w.WriteHeader(http.StatusOK)
 		categoryDTO := crud.CategoryDTO{
+			ID:          category.ID,
 			Name:        category.Name,
 			Description: category.Description.String,
 		}
 		if err := json.NewEncoder(w).Encode(categoryDTO); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleCategoryUpdate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		categoryDTO := &crud.CategoryDTO{}
 		if err := s.bodyToStruct(w, r, categoryDTO); err != nil {
 			return
 		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if categoryName := params.ByName("name"); strings.TrimSpace(categoryName) != "" {
-			err = s.svc.UpdateCategory(categoryName, *categoryDTO)
+		if categoryID := params.ByName("id"); strings.TrimSpace(categoryID) != "" {
+			err = s.svcAs(r).UpdateCategory(categoryID, version, *categoryDTO)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -139,22 +151,38 @@ func (s *server) handleCategoryUpdate() http.HandlerFunc {
 
 func (s *server) handleCategoryDelete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		svc := s.svcAs(r)
+		remove, err := removeOrPurge(r, svc.RemoveCategory, svc.PurgeCategory)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if categoryName := params.ByName("name"); strings.TrimSpace(categoryName) != "" {
-			err = s.svc.RemoveCategory(categoryName)
+		if categoryID := params.ByName("id"); strings.TrimSpace(categoryID) != "" {
+			err = remove(categoryID, version)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
-				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsNotValidated) || errors.Is(err, logger.ErrIsRequired) {
+					s.errBadRequest(w, r, err)
 					return
 				}
+				s.errInternalServer(w, r, err)
+				return
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -8,12 +8,14 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 
 	_ "github.com/lib/pq"
 
 	"github.com/bxcodec/faker/v3"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/testdata"
 )
@@ -56,7 +58,9 @@ func Test_RestApi_Post_Categories(t *testing.T) {
 			},
 			want: response{
 				status: http.StatusBadRequest,
-				body:   http.StatusText(http.StatusBadRequest),
+				body: `{"type":"urn:code-micro-videos:problem:validation_failed","title":"Bad Request","status":400,` +
+					`"detail":"'name' field is required","instance":"/categories","code":"validation_failed",` +
+					`"errors":[{"field":"name","rule":"not_blank"}]}`,
 			},
 			wantErr: false,
 		},
@@ -102,6 +106,7 @@ func Test_RestApi_Get_Categories(t *testing.T) {
 	type response struct {
 		status int
 		body   []byte
+		link   string
 	}
 	tests := []struct {
 		name    string
@@ -121,6 +126,53 @@ func Test_RestApi_Get_Categories(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "When a page of one category is asked",
+			req: request{
+				url:         fakeUrl + "?page=1&per_page=1",
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusOK,
+				body:   toJSON(testdata.FakeCategoriesDTO[:1]),
+				link:   `</categories?page=2&per_page=1>; rel="next"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When name is given",
+			req: request{
+				url:         fakeUrl + "?name=" + url.QueryEscape(testdata.FakeCategories[0].Name),
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusOK,
+				body:   toJSON(testdata.FakeCategoriesDTO[:1]),
+			},
+			wantErr: false,
+		},
+		{
+			name: "When per_page is not a number",
+			req: request{
+				url:         fakeUrl + "?per_page=all",
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusBadRequest,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When cursor is not valid",
+			req: request{
+				url:         fakeUrl + "?cursor=" + faker.Word(),
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusBadRequest,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -134,6 +186,9 @@ func Test_RestApi_Get_Categories(t *testing.T) {
 					t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.want.status)
 					return
 				}
+				if link := got.Header.Get("Link"); !strings.Contains(link, tt.want.link) {
+					t.Errorf("link: %v, want: %v", link, tt.want.link)
+				}
 				bs, err := ioutil.ReadAll(got.Body)
 				if err != nil {
 					t.Errorf("read body: %v", err)
@@ -154,11 +209,11 @@ func Test_RestApi_Get_Category(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCategories[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
+	fakeExistID := testdata.FakeCategories[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	fakeExistCategoryDTO := testdata.FakeCategoriesDTO[0]
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", name)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", id)
 	}
 	type request struct {
 		url         string
@@ -175,9 +230,9 @@ func Test_RestApi_Get_Category(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -187,9 +242,9 @@ func Test_RestApi_Get_Category(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -230,10 +285,10 @@ func Test_RestApi_Delete_Category(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCategories[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", name)
+	fakeExistID := testdata.FakeCategories[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", id)
 	}
 	type request struct {
 		url         string
@@ -250,9 +305,9 @@ func Test_RestApi_Delete_Category(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -262,9 +317,9 @@ func Test_RestApi_Delete_Category(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -280,6 +335,7 @@ func Test_RestApi_Delete_Category(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -311,10 +367,10 @@ func Test_RestApi_Update_Category(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCategories[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", name)
+	fakeExistID := testdata.FakeCategories[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "categories", id)
 	}
 	type request struct {
 		url         string
@@ -332,9 +388,9 @@ func Test_RestApi_Update_Category(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s"}`,
@@ -348,9 +404,9 @@ func Test_RestApi_Update_Category(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s", "avatar": "%s", "whatsapp": "%s", "bio": "%s" }`,
@@ -373,6 +429,7 @@ func Test_RestApi_Update_Category(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
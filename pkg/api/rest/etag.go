@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// The ETag of an item is its version, a write of the item has to name the version it was based on by If-Match.
+
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatch returns the version the If-Match header of r asks for, * asks for any. A tag no version has, like a
+// weak one, can only be stale.
+func ifMatch(r *http.Request) (int64, error) {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if tag == "" {
+		return 0, fmt.Errorf("If-Match header %w", logger.ErrIsRequired)
+	}
+	if tag == "*" {
+		return crud.AnyVersion, nil
+	}
+	if unquoted, err := strconv.Unquote(tag); err == nil {
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil && version > crud.AnyVersion {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("entity tag %s %w", tag, logger.ErrIsStale)
+}
+
+// errPrecondition answers a write whose If-Match header is missing or stale
+func (s *server) errPrecondition(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, logger.ErrIsRequired) {
+		s.errPreconditionRequired(w, r, err)
+		return
+	}
+	s.errPreconditionFailed(w, r, err)
+}
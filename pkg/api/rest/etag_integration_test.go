@@ -0,0 +1,101 @@
+// +build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_ETag_Genres(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeGenres)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeGenre := testdata.FakeGenres[0]
+	genreUrl := fmt.Sprintf("http://%s/genres/%s", cfg.AddressServer, fakeGenre.ID)
+	body := toJSON(crud.GenreDTO{Name: fakeGenre.Name})
+	// the steps run in order, each one on the version left by the previous ones
+	steps := []struct {
+		name    string
+		method  string
+		ifMatch string
+		status  int
+		etag    string
+	}{
+		{
+			name:   "When the genre is fetched",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			etag:   `"1"`,
+		},
+		{
+			name:   "When If-Match is not given",
+			method: http.MethodPut,
+			status: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "When If-Match is the current version",
+			method:  http.MethodPut,
+			ifMatch: `"1"`,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "When If-Match is an older version",
+			method:  http.MethodPut,
+			ifMatch: `"1"`,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			name:    "When If-Match is a weak tag",
+			method:  http.MethodDelete,
+			ifMatch: `W/"2"`,
+			status:  http.StatusPreconditionFailed,
+		},
+		{
+			name:   "When the genre is fetched again",
+			method: http.MethodGet,
+			status: http.StatusOK,
+			etag:   `"2"`,
+		},
+		{
+			name:    "When the genre is removed at its version",
+			method:  http.MethodDelete,
+			ifMatch: `"2"`,
+			status:  http.StatusOK,
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			var reqBody []byte
+			if step.method == http.MethodPut {
+				reqBody = body
+			}
+			req, err := http.NewRequest(step.method, genreUrl, bytes.NewReader(reqBody))
+			if err != nil {
+				t.Fatalf("test: new request: %v", err)
+			}
+			if step.ifMatch != "" {
+				req.Header.Set("If-Match", step.ifMatch)
+			}
+			got, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			defer got.Body.Close()
+			if got.StatusCode != step.status {
+				t.Fatalf("statusCode: %v, want: %v", got.StatusCode, step.status)
+			}
+			if etag := got.Header.Get("ETag"); etag != step.etag {
+				t.Errorf("ETag: %s, want: %s", etag, step.etag)
+			}
+		})
+	}
+}
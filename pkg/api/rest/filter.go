@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// pageParams are read by pageFromQuery and accepted by every list
+var pageParams = []string{"cursor", "page", "per_page"}
+
+// the query parameters each list reads into its filter
+var (
+	castMemberFilterParams = []string{"name", "name_prefix", "type"}
+	categoryFilterParams   = []string{"name", "name_prefix", "has_videos", "sort"}
+	genreFilterParams      = []string{"name", "name_prefix", "has_videos", "sort"}
+	videoFilterParams      = []string{
+		"name",
+		"name_prefix",
+		"category",
+		"genre",
+		"rating",
+		"max_rating",
+		"year_launched",
+		"min_year_launched",
+		"max_year_launched",
+		"opened",
+		"duration",
+		"min_duration",
+		"max_duration",
+		"has_file",
+		"sort",
+	}
+)
+
+// filterFromQuery reads the filter of a list from the query, refusing the parameters the list does not know
+func filterFromQuery(query url.Values, params []string) (crud.Filter, error) {
+	if err := checkParams(query, params); err != nil {
+		return crud.Filter{}, err
+	}
+	var filter crud.Filter
+	var err error
+	filter.Name = query.Get("name")
+	filter.NamePrefix = query.Get("name_prefix")
+	filter.Category = query.Get("category")
+	filter.Genre = query.Get("genre")
+	if v := query.Get("type"); v != "" {
+		t, err := crud.ParseCastMemberType(v)
+		if err != nil {
+			return crud.Filter{}, err
+		}
+		filter.Type = &t
+	}
+	if filter.HasVideos, err = boolParam(query, "has_videos"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.Opened, err = boolParam(query, "opened"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.HasFile, err = boolParam(query, "has_file"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.Rating, err = ratingParam(query, "rating"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.MaxRating, err = ratingParam(query, "max_rating"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.YearLaunched, err = rangeParams(query, "year_launched"); err != nil {
+		return crud.Filter{}, err
+	}
+	if filter.Duration, err = rangeParams(query, "duration"); err != nil {
+		return crud.Filter{}, err
+	}
+	if v := query.Get("sort"); v != "" {
+		if filter.Sort, err = crud.ParseSort(v); err != nil {
+			return crud.Filter{}, err
+		}
+	}
+	return filter, nil
+}
+
+// checkParams refuses the query parameters that are neither in params nor page ones
+func checkParams(query url.Values, params []string) error {
+	known := make(map[string]bool, len(params)+len(pageParams))
+	for _, param := range params {
+		known[param] = true
+	}
+	for _, param := range pageParams {
+		known[param] = true
+	}
+	for param := range query {
+		if !known[param] {
+			return fmt.Errorf("query parameter '%s' %w", param, logger.ErrIsNotValidated)
+		}
+	}
+	return nil
+}
+
+func boolParam(query url.Values, param string) (*bool, error) {
+	v := query.Get(param)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' %w", param, logger.ErrIsNotValidated)
+	}
+	return &b, nil
+}
+
+func intParam(query url.Values, param string) (*int, error) {
+	v := query.Get(param)
+	if v == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' %w", param, logger.ErrIsNotValidated)
+	}
+	return &i, nil
+}
+
+func ratingParam(query url.Values, param string) (*crud.VideoRating, error) {
+	i, err := intParam(query, param)
+	if err != nil || i == nil {
+		return nil, err
+	}
+	rating := crud.VideoRating(*i)
+	return &rating, nil
+}
+
+// rangeParams reads the bounds of a range from the min_ and max_ parameters, or both from the exact one
+func rangeParams(query url.Values, param string) (crud.Range, error) {
+	exact, err := intParam(query, param)
+	if err != nil {
+		return crud.Range{}, err
+	}
+	min, err := intParam(query, "min_"+param)
+	if err != nil {
+		return crud.Range{}, err
+	}
+	max, err := intParam(query, "max_"+param)
+	if err != nil {
+		return crud.Range{}, err
+	}
+	if exact != nil {
+		if min != nil || max != nil {
+			return crud.Range{}, fmt.Errorf("'%s' with its bounds %w", param, logger.ErrIsNotValidated)
+		}
+		min, max = exact, exact
+	}
+	return crud.Range{Min: min, Max: max}, nil
+}
@@ -3,9 +3,8 @@ package rest
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
-	"math"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
@@ -18,57 +17,60 @@ import (
 func (s *server) handleGenreCreate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		genreDTO := &crud.GenreDTO{}
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := r.Body.Close(); err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := json.Unmarshal(body, &genreDTO); err != nil {
-			s.errUnprocessableEntity(w, err)
-			if err := json.NewEncoder(w).Encode(err); err != nil {
-				s.errInternalServer(w, err)
-			}
+		if err := s.bodyToStruct(w, r, genreDTO); err != nil {
+			return
 		}
-		if err := s.svc.AddGenre(*genreDTO); err != nil {
+		id, err := s.svcAs(r).AddGenre(*genreDTO)
+		if err != nil {
 			if errors.Is(err, logger.ErrIsRequired) {
-				s.errBadRequest(w, err)
+				s.errBadRequest(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrAlreadyExists) {
-				s.errStatusConflict(w, err)
+				s.errStatusConflict(w, r, err)
 				return
 			}
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Location", path.Join(r.URL.Path, id.String()))
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(http.StatusText(http.StatusCreated)); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleGenresGet() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		genres, err := s.svc.GetGenres(math.MaxInt8)
+		filter, err := filterFromQuery(r.URL.Query(), genreFilterParams)
 		if err != nil {
-			s.errInternalServer(w, err)
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(r.URL.Query())
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		genres, info, err := s.svc.GetGenres(filter, page)
+		if err != nil {
+			if errors.Is(err, logger.ErrInvalidedLimit) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
 		genresDTO := make([]crud.GenreDTO, len(genres))
 		for i, genre := range genres {
 			genresDTO[i] = crud.GenreDTO{
+				ID:   genre.ID,
 				Name: genre.Name,
 			}
 		}
-		if err := json.NewEncoder(w).Encode(genresDTO); err != nil {
-			s.errInternalServer(w, err)
-		}
+		s.writePage(w, r, genresDTO, info)
 	}
 }
 
@@ -77,55 +79,65 @@ func (s *server) handleGenreGet() http.HandlerFunc {
 		var genre models.Genre
 		var err error
 		params := httprouter.ParamsFromContext(r.Context())
-		if genreName := params.ByName("name"); strings.TrimSpace(genreName) != "" {
-			genre, err = s.svc.FetchGenre(genreName)
+		if genreID := params.ByName("id"); strings.TrimSpace(genreID) != "" {
+			genre, err = s.svc.FetchGenre(genreID)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		setETag(w, genre.Version)
 		w.WriteHeader(http.StatusOK)
 		genreDTO := crud.GenreDTO{
+			ID:   genre.ID,
 			Name: genre.Name,
 		}
 		if err := json.NewEncoder(w).Encode(genreDTO); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleGenreUpdate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		genreDTO := &crud.GenreDTO{}
 		if err := s.bodyToStruct(w, r, genreDTO); err != nil {
 			return
 		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if genreName := params.ByName("name"); strings.TrimSpace(genreName) != "" {
-			err = s.svc.UpdateGenre(genreName, *genreDTO)
+		if genreID := params.ByName("id"); strings.TrimSpace(genreID) != "" {
+			err = s.svcAs(r).UpdateGenre(genreID, version, *genreDTO)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -133,22 +145,38 @@ func (s *server) handleGenreUpdate() http.HandlerFunc {
 
 func (s *server) handleGenreDelete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		svc := s.svcAs(r)
+		remove, err := removeOrPurge(r, svc.RemoveGenre, svc.PurgeGenre)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if genreName := params.ByName("name"); strings.TrimSpace(genreName) != "" {
-			err = s.svc.RemoveGenre(genreName)
+		if genreID := params.ByName("id"); strings.TrimSpace(genreID) != "" {
+			err = remove(genreID, version)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
-				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsNotValidated) || errors.Is(err, logger.ErrIsRequired) {
+					s.errBadRequest(w, r, err)
 					return
 				}
+				s.errInternalServer(w, r, err)
+				return
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
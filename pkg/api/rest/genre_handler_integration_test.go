@@ -14,6 +14,7 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/bxcodec/faker/v3"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/testdata"
 )
@@ -119,7 +120,9 @@ func Test_RestApi_Post_Genres(t *testing.T) {
 			},
 			want: response{
 				status: http.StatusBadRequest,
-				body:   http.StatusText(http.StatusBadRequest),
+				body: `{"type":"urn:code-micro-videos:problem:validation_failed","title":"Bad Request","status":400,` +
+					`"detail":"'name' field is required","instance":"/genres","code":"validation_failed",` +
+					`"errors":[{"field":"name","rule":"not_blank"}]}`,
 			},
 			wantErr: false,
 		},
@@ -217,11 +220,11 @@ func Test_RestApi_Get_Genre(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeGenres[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
+	fakeExistID := testdata.FakeGenres[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	fakeExistGenreDTO := testdata.FakeGenresDTO[0]
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", name)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", id)
 	}
 	type request struct {
 		url         string
@@ -238,9 +241,9 @@ func Test_RestApi_Get_Genre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -250,9 +253,9 @@ func Test_RestApi_Get_Genre(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -293,10 +296,10 @@ func Test_RestApi_Delete_Genre(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeGenres[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", name)
+	fakeExistID := testdata.FakeGenres[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", id)
 	}
 	type request struct {
 		url         string
@@ -313,9 +316,9 @@ func Test_RestApi_Delete_Genre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -325,9 +328,9 @@ func Test_RestApi_Delete_Genre(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -343,6 +346,7 @@ func Test_RestApi_Delete_Genre(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -374,10 +378,10 @@ func Test_RestApi_Update_Genre(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeGenres[0].Name
-	fakeDoesNotExistName := "doesNotExistName"
-	fakeUrl := func(name string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", name)
+	fakeExistID := testdata.FakeGenres[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "genres", id)
 	}
 	type request struct {
 		url         string
@@ -395,9 +399,9 @@ func Test_RestApi_Update_Genre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistName),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s"}`,
@@ -411,9 +415,9 @@ func Test_RestApi_Update_Genre(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When name exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistName),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(fmt.Sprintf(
 					`{"name": "%s", "avatar": "%s", "whatsapp": "%s", "bio": "%s" }`,
@@ -436,6 +440,7 @@ func Test_RestApi_Update_Genre(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
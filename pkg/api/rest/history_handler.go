@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// AnonymousActor makes the changes of the requests that are not authenticated, the changes of an authenticated
+// request are made by its principal
+const AnonymousActor = "anonymous"
+
+func actorOf(r *http.Request) string {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		return p.Subject
+	}
+	return AnonymousActor
+}
+
+// svcAs returns the service making the changes of r on behalf of its actor
+func (s *server) svcAs(r *http.Request) crud.Service {
+	return s.svc.As(actorOf(r))
+}
+
+// handleHistoryGet answers with a page of the changes of the entity named by the id parameter, the last first
+func (s *server) handleHistoryGet(entity crud.EntityType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if err := checkParams(query, nil); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		params := httprouter.ParamsFromContext(r.Context())
+		events, info, err := s.svc.GetHistory(entity, params.ByName("id"), page)
+		if err != nil {
+			if errors.Is(err, logger.ErrIsRequired) ||
+				errors.Is(err, logger.ErrIsNotValidated) ||
+				errors.Is(err, logger.ErrInvalidedLimit) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrNotFound) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		s.writePage(w, r, events, info)
+	}
+}
@@ -0,0 +1,96 @@
+// +build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/selmison/code-micro-videos/pkg/api/rest"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_History_Categories(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeCategories)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeCategory := testdata.FakeCategories[0]
+	fakeUrl := func(path string) string {
+		return fmt.Sprintf("http://%s/%s", cfg.AddressServer, path)
+	}
+	do := func(method, url string, body interface{}) *http.Response {
+		var b []byte
+		if body != nil {
+			b = toJSON(body)
+		}
+		req, err := http.NewRequest(method, url, bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("test: new request: %v", err)
+		}
+		req.Header.Set("If-Match", "*")
+		got, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return got
+	}
+	categoryUrl := fakeUrl("categories/" + fakeCategory.ID)
+	updated := crud.CategoryDTO{Name: fakeCategory.Name, Description: "updated"}
+	for _, got := range []*http.Response{
+		do(http.MethodPut, categoryUrl, updated),
+		do(http.MethodDelete, categoryUrl, nil),
+	} {
+		_ = got.Body.Close()
+		if got.StatusCode != http.StatusOK {
+			t.Fatalf("test: change of the category statusCode: %v", got.StatusCode)
+		}
+	}
+	t.Run("When the history is listed", func(t *testing.T) {
+		got := do(http.MethodGet, categoryUrl+"/history", nil)
+		defer got.Body.Close()
+		if got.StatusCode != http.StatusOK {
+			t.Fatalf("statusCode: %v, want: %v", got.StatusCode, http.StatusOK)
+		}
+		var body struct {
+			Data []crud.AuditEvent `json:"data"`
+		}
+		if err := json.NewDecoder(got.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body.Data) != 2 {
+			t.Fatalf("events: %d, want: 2", len(body.Data))
+		}
+		removed, changed := body.Data[0], body.Data[1]
+		assert.Equal(t, crud.RemoveOperation, removed.Operation, "the last change should come first")
+		assert.Equal(t, rest.AnonymousActor, removed.Actor, "they should be equal")
+		assert.Equal(t, crud.UpdateOperation, changed.Operation, "they should be equal")
+		assert.Equal(t, rest.AnonymousActor, changed.Actor, "the changes of the requests without token should be anonymous")
+		assert.Equal(t, crud.CategoryEntity, changed.EntityType, "they should be equal")
+		assert.Equal(t, fakeCategory.ID, changed.EntityID, "they should be equal")
+		assert.Equal(t, "updated", changed.Diff["description"].After, "the diff should hold the new description")
+		assert.NotContains(t, changed.Diff, "name", "the diff should leave the unchanged fields out")
+	})
+	t.Run("When id is not an uuid", func(t *testing.T) {
+		got := do(http.MethodGet, fakeUrl("categories/fake/history"), nil)
+		defer got.Body.Close()
+		if got.StatusCode != http.StatusNotFound {
+			t.Errorf("statusCode: %v, want: %v", got.StatusCode, http.StatusNotFound)
+		}
+	})
+	t.Run("When the history is asked by a cursor", func(t *testing.T) {
+		got := do(http.MethodGet, categoryUrl+"/history?cursor=fake", nil)
+		defer got.Body.Close()
+		if got.StatusCode != http.StatusBadRequest {
+			t.Errorf("statusCode: %v, want: %v", got.StatusCode, http.StatusBadRequest)
+		}
+	})
+}
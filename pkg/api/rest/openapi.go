@@ -0,0 +1,366 @@
+package rest
+
+//go:generate go test -run TestOpenAPI -update .
+//go:generate curl -fsSL -o redoc/redoc.standalone.js https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+const (
+	// OpenAPIVersion is the version of the specification the document of the API follows
+	OpenAPIVersion = "3.1.0"
+	// OpenAPIPath serves the document of a version of the API under its path, api/<version>/openapi.json keeps a
+	// copy of it for its clients
+	OpenAPIPath = "/openapi.json"
+)
+
+// bearerAuthScheme names the security scheme of the tokens, the roles an operation needs are its scopes.
+// apiKeyScheme names the one of the API keys, whose scopes are the ones of the keys.
+const (
+	bearerAuthScheme = "bearerAuth"
+	apiKeyScheme     = "apiKey"
+)
+
+// queryParamTypes are the types of the query parameters that are not strings
+var queryParamTypes = map[string]reflect.Type{
+	"page":              reflect.TypeOf(0),
+	"per_page":          reflect.TypeOf(0),
+	"has_videos":        reflect.TypeOf(false),
+	"opened":            reflect.TypeOf(false),
+	"has_file":          reflect.TypeOf(false),
+	"purge":             reflect.TypeOf(false),
+	"type":              reflect.TypeOf(crud.CastMemberType(0)),
+	"rating":            reflect.TypeOf(crud.VideoRating(0)),
+	"max_rating":        reflect.TypeOf(crud.VideoRating(0)),
+	"year_launched":     reflect.TypeOf(int16(0)),
+	"min_year_launched": reflect.TypeOf(int16(0)),
+	"max_year_launched": reflect.TypeOf(int16(0)),
+	"duration":          reflect.TypeOf(int16(0)),
+	"min_duration":      reflect.TypeOf(int16(0)),
+	"max_duration":      reflect.TypeOf(int16(0)),
+}
+
+// requiredQueryParams are the query parameters a route cannot do without
+var requiredQueryParams = map[string]bool{"q": true}
+
+// pathParamTypes are the types of the path parameters that are not strings
+var pathParamTypes = map[string]reflect.Type{
+	"kind": reflect.TypeOf(crud.AssetKind("")),
+}
+
+// headerTypes are the types of the headers that are not strings
+var headerTypes = map[string]reflect.Type{
+	"Upload-Length": reflect.TypeOf(int64(0)),
+	"Upload-Offset": reflect.TypeOf(int64(0)),
+	"Tus-Max-Size":  reflect.TypeOf(int64(0)),
+}
+
+// openAPI builds the OpenAPI document of the routes of version, each one has to be described by its operation
+// and each operation has to describe a route
+func openAPI(version string, routes []route) (jsonObject, error) {
+	reg := newSchemaRegistry()
+	paths := jsonObject{}
+	documented := make(map[string]bool, len(operations))
+	for _, rt := range routes {
+		key := rt.method + " " + rt.pattern
+		op, ok := operations[key]
+		if !ok {
+			return nil, fmt.Errorf("route %s has no operation", key)
+		}
+		documented[key] = true
+		path, params := openAPIPath(rt.pattern)
+		item, ok := paths[path].(jsonObject)
+		if !ok {
+			item = jsonObject{}
+			paths[path] = item
+		}
+		item[strings.ToLower(rt.method)] = reg.operation(rt.method, rt.pattern, params, rt.role, rt.scope, op)
+	}
+	var undocumented []string
+	for key := range operations {
+		if !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		return nil, fmt.Errorf("operations %s have no route", strings.Join(undocumented, ", "))
+	}
+	problem := reg.schemaOf(reflect.TypeOf(Problem{}))
+	return jsonObject{
+		"openapi": OpenAPIVersion,
+		"info": jsonObject{
+			"title":   "Code Micro Videos",
+			"version": "1.0.0",
+			"description": "The catalogue of the videos with their categories, genres and cast members. " +
+				"The errors are answered with problem details. The reads and the writes of each client are rate " +
+				"limited apart, the RateLimit headers tell what is left and Retry-After how long to wait after a 429.",
+		},
+		"servers": []jsonObject{{"url": "/" + version}},
+		"paths":   paths,
+		"components": jsonObject{
+			"schemas": reg.schemas,
+			"responses": jsonObject{
+				"Problem": jsonObject{
+					"description": "The problem details of an error",
+					"content":     jsonObject{ProblemMediaType: jsonObject{"schema": problem}},
+				},
+			},
+			"parameters": jsonObject{
+				"IfMatch": jsonObject{
+					"name":        "If-Match",
+					"in":          "header",
+					"required":    true,
+					"description": "The ETag of the version of the item the write is based on, or * for any version",
+					"schema":      jsonObject{"type": "string"},
+				},
+			},
+			"securitySchemes": jsonObject{
+				bearerAuthScheme: jsonObject{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+					"description": "A JWT signed by RS256 or ES256. Its subject makes the changes and its roles, " +
+						"viewer, editor or admin, tell the operations it is allowed, each role is allowed the " +
+						"operations of the roles below it.",
+				},
+				apiKeyScheme: jsonObject{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "Authorization",
+					"description": "An API key of a machine client given as ApiKey <key>. Its scopes tell the " +
+						"operations it is allowed, the operations without scope are only allowed to the tokens.",
+				},
+			},
+			"headers": jsonObject{
+				"ETag": jsonObject{
+					"description": "The version of the item, to be given by If-Match to write it",
+					"schema":      jsonObject{"type": "string"},
+				},
+				"Link": jsonObject{
+					"description": "The links to the pages around the page",
+					"schema":      jsonObject{"type": "string"},
+				},
+			},
+		},
+	}, nil
+}
+
+// openAPIPath writes the pattern of a route as an OpenAPI path and returns the names of its parameters
+func openAPIPath(pattern string) (string, []string) {
+	segments := strings.Split(pattern, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operation writes the operation object of the route of method and pattern, which role or scope is allowed
+func (reg *schemaRegistry) operation(method, pattern string, pathParams []string, role auth.Role, scope auth.Scope, op operation) jsonObject {
+	tag := strings.Split(strings.TrimPrefix(strings.TrimPrefix(pattern, "/trash"), "/"), "/")[0]
+	var params []interface{}
+	for _, name := range pathParams {
+		params = append(params, jsonObject{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   reg.typeSchema(pathParamTypes[name]),
+		})
+	}
+	for _, name := range op.query {
+		param := jsonObject{"name": name, "in": "query", "schema": reg.typeSchema(queryParamTypes[name])}
+		if requiredQueryParams[name] {
+			param["required"] = true
+		}
+		params = append(params, param)
+	}
+	for _, name := range op.headers {
+		params = append(params, jsonObject{
+			"name":     name,
+			"in":       "header",
+			"required": true,
+			"schema":   reg.typeSchema(headerTypes[name]),
+		})
+	}
+	if op.ifMatch {
+		params = append(params, jsonObject{"$ref": "#/components/parameters/IfMatch"})
+	}
+	security := []jsonObject{{bearerAuthScheme: []string{role.String()}}}
+	if scope != "" {
+		security = append(security, jsonObject{apiKeyScheme: []string{string(scope)}})
+	}
+	object := jsonObject{
+		"operationId": op.id,
+		"summary":     op.summary,
+		"tags":        []string{tag},
+		"security":    security,
+		"responses": jsonObject{
+			fmt.Sprint(op.status): reg.response(op),
+			"default":             jsonObject{"$ref": "#/components/responses/Problem"},
+		},
+	}
+	if len(params) > 0 {
+		object["parameters"] = params
+	}
+	if len(op.bodyTypes) > 0 {
+		content := jsonObject{}
+		for _, mediaType := range op.bodyTypes {
+			var schema jsonObject
+			switch {
+			case op.body == nil:
+				schema = jsonObject{"type": "string", "format": "binary"}
+			case mediaType == multipartMediaType:
+				files := assetFileFields()
+				sort.Strings(files)
+				schema = reg.formSchema(reflect.TypeOf(op.body), files)
+			default:
+				schema = reg.schemaOf(reflect.TypeOf(op.body))
+			}
+			content[mediaType] = jsonObject{"schema": schema}
+		}
+		object["requestBody"] = jsonObject{"required": true, "content": content}
+	}
+	return object
+}
+
+// response writes the response object of a success of op
+func (reg *schemaRegistry) response(op operation) jsonObject {
+	response := jsonObject{"description": http.StatusText(op.status)}
+	headers := jsonObject{}
+	if op.etag {
+		headers["ETag"] = jsonObject{"$ref": "#/components/headers/ETag"}
+	}
+	if op.page {
+		headers["Link"] = jsonObject{"$ref": "#/components/headers/Link"}
+	}
+	for _, name := range op.responseHeaders {
+		headers[name] = jsonObject{"schema": reg.typeSchema(headerTypes[name])}
+	}
+	if len(headers) > 0 {
+		response["headers"] = headers
+	}
+	switch {
+	case op.response != nil && op.page:
+		response["content"] = jsonObject{
+			jsonMediaType: jsonObject{"schema": reg.pageSchema(reflect.TypeOf(op.response))},
+		}
+	case op.response != nil:
+		response["content"] = jsonObject{
+			jsonMediaType: jsonObject{"schema": reg.schemaOf(reflect.TypeOf(op.response))},
+		}
+	case op.responseType != "":
+		response["content"] = jsonObject{
+			op.responseType: jsonObject{"schema": jsonObject{"type": "string", "format": "binary"}},
+		}
+	}
+	return response
+}
+
+// typeSchema writes the schema of t, a string when it is nil
+func (reg *schemaRegistry) typeSchema(t reflect.Type) jsonObject {
+	if t == nil {
+		return jsonObject{"type": "string"}
+	}
+	return reg.schemaOf(t)
+}
+
+// marshalOpenAPI writes the OpenAPI document of the routes of version as it is served
+func marshalOpenAPI(version string, routes []route) ([]byte, error) {
+	doc, err := openAPI(version, routes)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// handleOpenAPI answers with the OpenAPI document of the routes of version
+func (s *server) handleOpenAPI(version string, routes []route) http.HandlerFunc {
+	doc, err := marshalOpenAPI(version, routes)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			s.errInternalServer(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(doc); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
+
+// RedocVersion is the version of the Redoc bundle the docs page is rendered with, go generate fetches it in redoc
+const RedocVersion = "v2.1.5"
+
+// redocBundle is the file of the Redoc bundle, served under the docs page
+const redocBundle = "redoc.standalone.js"
+
+// redoc holds the Redoc bundle, the server serves it so the docs page loads nothing from the network
+//
+//go:embed redoc
+var redoc embed.FS
+
+// docsPage renders the OpenAPI document with the Redoc bundle of the server
+const docsPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Code Micro Videos API</title>
+</head>
+<body>
+  <redoc spec-url="openapi.json"></redoc>
+  <script src="docs/` + redocBundle + `"></script>
+</body>
+</html>
+`
+
+// handleDocs answers with the page that documents the API
+func (s *server) handleDocs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprint(w, docsPage); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
+
+// handleRedoc answers with the Redoc bundle embedded in the server
+func (s *server) handleRedoc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bundle, err := redoc.ReadFile("redoc/" + redocBundle)
+		if err != nil {
+			s.errNotFound(w, r, fmt.Errorf("redoc bundle %s %w, go generate fetches it", RedocVersion, logger.ErrNotFound))
+			return
+		}
+		w.Header().Set("Content-Type", "application/javascript; charset=UTF-8")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(bundle); err != nil {
+			s.logger.Error(err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+// operation documents a route of the table in the OpenAPI document
+type operation struct {
+	id      string
+	summary string
+	// query are the query parameters the route reads and headers the request headers, besides If-Match
+	query   []string
+	headers []string
+	// ifMatch tells whether the route writes an item at the version given by If-Match
+	ifMatch bool
+	// body is the DTO the route reads from the request body in bodyTypes, a binary body when it is nil
+	body      interface{}
+	bodyTypes []string
+	// status is the status of a success, the route answers it with response in responseType, JSON by default,
+	// a page of its items when page is set or a binary body when it is nil and responseType is set
+	status       int
+	response     interface{}
+	responseType string
+	page         bool
+	// etag tells whether the response gives the version of the item by ETag, responseHeaders are the others
+	etag            bool
+	responseHeaders []string
+}
+
+const (
+	jsonMediaType      = "application/json"
+	multipartMediaType = "multipart/form-data"
+	binaryMediaType    = "application/octet-stream"
+)
+
+// resourceOperations documents the routes that every resource of the catalogue has under path, the resource is
+// named by name in the identifiers of its operations and described by noun
+func resourceOperations(path, name, names, noun, nouns string, dto, trashed interface{}, filterParams []string) map[string]operation {
+	item := path + "/:id"
+	return map[string]operation{
+		"GET " + path: {
+			id:       "list" + names,
+			summary:  "List the " + nouns,
+			query:    append(append([]string{}, filterParams...), pageParams...),
+			status:   http.StatusOK,
+			response: dto,
+			page:     true,
+		},
+		"GET " + item: {
+			id:       "get" + name,
+			summary:  "Fetch a " + noun,
+			status:   http.StatusOK,
+			response: dto,
+			etag:     true,
+		},
+		"POST " + path: {
+			id:              "add" + name,
+			summary:         "Add a " + noun,
+			body:            dto,
+			bodyTypes:       []string{jsonMediaType},
+			status:          http.StatusCreated,
+			responseHeaders: []string{"Location"},
+		},
+		"PUT " + item: {
+			id:        "update" + name,
+			summary:   "Update a " + noun,
+			ifMatch:   true,
+			body:      dto,
+			bodyTypes: []string{jsonMediaType},
+			status:    http.StatusOK,
+		},
+		"PATCH " + item: {
+			id:        "patch" + name,
+			summary:   "Update the fields of a " + noun + " given by a merge patch",
+			ifMatch:   true,
+			body:      dto,
+			bodyTypes: []string{MergePatchMediaType},
+			status:    http.StatusOK,
+		},
+		"DELETE " + item: {
+			id:      "remove" + name,
+			summary: "Move a " + noun + " to the trash, or purge it from the trash",
+			query:   []string{"purge"},
+			ifMatch: true,
+			status:  http.StatusOK,
+		},
+		"POST " + item + "/restore": {
+			id:      "restore" + name,
+			summary: "Restore a " + noun + " from the trash",
+			status:  http.StatusOK,
+		},
+		"GET " + item + "/history": {
+			id:       "get" + name + "History",
+			summary:  "List the changes of a " + noun + ", the last ones first",
+			query:    pageParams,
+			status:   http.StatusOK,
+			response: crud.AuditEvent{},
+			page:     true,
+		},
+		"GET /trash" + path: {
+			id:       "listTrashed" + names,
+			summary:  "List the " + nouns + " in the trash",
+			query:    pageParams,
+			status:   http.StatusOK,
+			response: trashed,
+			page:     true,
+		},
+	}
+}
+
+// operations document the routes of the table by their method and pattern
+var operations = func() map[string]operation {
+	ops := map[string]operation{
+		"GET /cast_members/:id/videos": {
+			id:       "listCastMemberVideos",
+			summary:  "List the videos a cast member is credited in",
+			query:    pageParams,
+			status:   http.StatusOK,
+			response: creditDTO{},
+			page:     true,
+		},
+		"GET /videos/search": {
+			id:       "searchVideos",
+			summary:  "Search the videos by their title and description, the most relevant first",
+			query:    append(append([]string{}, videoSearchParams...), pageParams...),
+			status:   http.StatusOK,
+			response: videoMatchDTO{},
+			page:     true,
+		},
+		"GET /videos/:id/file": {
+			id:           "getVideoFile",
+			summary:      "Download the main file of a video",
+			status:       http.StatusOK,
+			responseType: binaryMediaType,
+		},
+		"GET /videos/:id/assets/:kind": {
+			id:           "getVideoAsset",
+			summary:      "Download an asset of a video",
+			status:       http.StatusOK,
+			responseType: binaryMediaType,
+		},
+		"OPTIONS /uploads": {
+			id:              "getUploadsCapabilities",
+			summary:         "Tell the tus version and extensions of the resumable uploads",
+			status:          http.StatusNoContent,
+			responseHeaders: []string{"Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size"},
+		},
+		"POST /uploads": {
+			id:              "createUpload",
+			summary:         "Start a resumable upload of an asset of a video",
+			headers:         []string{"Tus-Resumable", "Upload-Length", "Upload-Metadata"},
+			status:          http.StatusCreated,
+			responseHeaders: []string{"Tus-Resumable", "Location", "Upload-Expires"},
+		},
+		"HEAD /uploads/:id": {
+			id:              "getUploadOffset",
+			summary:         "Tell how much of a resumable upload was received",
+			headers:         []string{"Tus-Resumable"},
+			status:          http.StatusOK,
+			responseHeaders: []string{"Tus-Resumable", "Upload-Offset", "Upload-Length", "Upload-Expires"},
+		},
+		"PATCH /uploads/:id": {
+			id:              "resumeUpload",
+			summary:         "Append a chunk to a resumable upload, which becomes the asset once it is complete",
+			headers:         []string{"Tus-Resumable", "Upload-Offset"},
+			bodyTypes:       []string{offsetContentType},
+			status:          http.StatusNoContent,
+			responseHeaders: []string{"Tus-Resumable", "Upload-Offset"},
+		},
+		"DELETE /uploads/:id": {
+			id:              "terminateUpload",
+			summary:         "Discard a resumable upload",
+			headers:         []string{"Tus-Resumable"},
+			status:          http.StatusNoContent,
+			responseHeaders: []string{"Tus-Resumable"},
+		},
+		"POST /api_keys": {
+			id:              "createAPIKey",
+			summary:         "Create an API key of a machine client, its secret is only answered this once",
+			body:            auth.CreateAPIKeyDTO{},
+			bodyTypes:       []string{jsonMediaType},
+			status:          http.StatusCreated,
+			response:        auth.NewAPIKey{},
+			responseHeaders: []string{"Location"},
+		},
+		"GET /api_keys": {
+			id:       "listAPIKeys",
+			summary:  "List the API keys, the revoked and expired ones included",
+			query:    pageParams,
+			status:   http.StatusOK,
+			response: auth.APIKey{},
+			page:     true,
+		},
+		"DELETE /api_keys/:id": {
+			id:      "revokeAPIKey",
+			summary: "Revoke an API key, its requests are refused from now on",
+			status:  http.StatusOK,
+		},
+	}
+	resources := []map[string]operation{
+		resourceOperations(
+			"/categories", "Category", "Categories", "category", "categories",
+			crud.CategoryDTO{}, trashedCategoryDTO{}, categoryFilterParams,
+		),
+		resourceOperations(
+			"/genres", "Genre", "Genres", "genre", "genres",
+			crud.GenreDTO{}, trashedGenreDTO{}, genreFilterParams,
+		),
+		resourceOperations(
+			"/cast_members", "CastMember", "CastMembers", "cast member", "cast members",
+			crud.CastMemberDTO{}, trashedCastMemberDTO{}, castMemberFilterParams,
+		),
+		resourceOperations(
+			"/videos", "Video", "Videos", "video", "videos",
+			crud.VideoDTO{}, trashedVideoDTO{}, videoFilterParams,
+		),
+	}
+	for _, resource := range resources {
+		for key, op := range resource {
+			ops[key] = op
+		}
+	}
+	// the videos are added with their files and may be updated with them
+	create, update := ops["POST /videos"], ops["PUT /videos/:id"]
+	create.bodyTypes = []string{multipartMediaType}
+	update.bodyTypes = []string{jsonMediaType, multipartMediaType}
+	ops["POST /videos"], ops["PUT /videos/:id"] = create, update
+	return ops
+}()
@@ -0,0 +1,216 @@
+package rest
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+// jsonObject is an object of the OpenAPI document, its keys are written sorted so the document is stable
+type jsonObject map[string]interface{}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaEnums are the values of the types that only take a few, as they are written in JSON
+var schemaEnums = map[reflect.Type][]interface{}{
+	reflect.TypeOf(crud.CastMemberType(0)): castMemberTypeNames(),
+	reflect.TypeOf(crud.VideoRating(0)): {
+		crud.FreeRating,
+		crud.TenRating,
+		crud.TwelveRating,
+		crud.FourteenRating,
+		crud.SixteenRating,
+		crud.EighteenRating,
+	},
+	reflect.TypeOf(crud.AssetKind("")): {crud.ThumbnailAsset, crud.BannerAsset, crud.TrailerAsset, crud.VideoAsset},
+	reflect.TypeOf(crud.EntityType("")): {
+		crud.CategoryEntity,
+		crud.GenreEntity,
+		crud.CastMemberEntity,
+		crud.VideoEntity,
+	},
+	reflect.TypeOf(crud.AuditOperation("")): {
+		crud.AddOperation,
+		crud.UpdateOperation,
+		crud.RemoveOperation,
+		crud.RestoreOperation,
+		crud.PurgeOperation,
+	},
+	reflect.TypeOf(auth.Scope("")): scopeNames(),
+}
+
+func castMemberTypeNames() []interface{} {
+	var names []interface{}
+	for t := crud.CastMemberType(0); t.Validate() == nil; t++ {
+		names = append(names, t.String())
+	}
+	return names
+}
+
+func scopeNames() []interface{} {
+	var names []interface{}
+	for _, scope := range auth.Scopes() {
+		names = append(names, scope)
+	}
+	return names
+}
+
+// validateRequired are the validate tags of the fields a request has to give
+var validateRequired = map[string]bool{"required": true, "not_blank": true}
+
+// schemaRegistry writes the JSON schemas of Go types, the structs and the enums are kept as components and
+// referenced by the name of their type without its DTO suffix
+type schemaRegistry struct {
+	schemas jsonObject
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: jsonObject{}}
+}
+
+func schemaName(t reflect.Type) string {
+	name := []rune(strings.TrimSuffix(t.Name(), "DTO"))
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
+
+// ref returns the reference to the component of t, which build makes the first time it is referenced
+func (reg *schemaRegistry) ref(t reflect.Type, name string, build func() jsonObject) jsonObject {
+	if _, ok := reg.schemas[name]; !ok {
+		reg.schemas[name] = nil
+		reg.schemas[name] = build()
+	}
+	return jsonObject{"$ref": "#/components/schemas/" + name}
+}
+
+// schemaOf writes the schema of the values of t in JSON
+func (reg *schemaRegistry) schemaOf(t reflect.Type) jsonObject {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if enum, ok := schemaEnums[t]; ok {
+		return reg.ref(t, schemaName(t), func() jsonObject {
+			schema := jsonObject{"enum": enum}
+			if t.Implements(textMarshalerType) {
+				schema["type"] = "string"
+			} else {
+				schema["type"] = kindSchema(t)["type"]
+			}
+			return schema
+		})
+	}
+	if t == timeType {
+		return jsonObject{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return reg.ref(t, schemaName(t), func() jsonObject {
+			return reg.structSchema(t, "json")
+		})
+	case reflect.Slice, reflect.Array:
+		return jsonObject{"type": "array", "items": reg.schemaOf(t.Elem())}
+	case reflect.Map:
+		return jsonObject{"type": "object", "additionalProperties": reg.schemaOf(t.Elem())}
+	}
+	if t.Implements(textMarshalerType) {
+		return jsonObject{"type": "string"}
+	}
+	return kindSchema(t)
+}
+
+// kindSchema writes the schema of the values of a basic type, any value for the others
+func kindSchema(t reflect.Type) jsonObject {
+	switch t.Kind() {
+	case reflect.Bool:
+		return jsonObject{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return jsonObject{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return jsonObject{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return jsonObject{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return jsonObject{"type": "number", "format": "double"}
+	case reflect.String:
+		return jsonObject{"type": "string"}
+	}
+	return jsonObject{}
+}
+
+// structSchema writes the schema of an object with the fields of t named by their tag, json or schema
+func (reg *schemaRegistry) structSchema(t reflect.Type, tag string) jsonObject {
+	properties := jsonObject{}
+	var required []string
+	reg.addFields(t, tag, properties, &required)
+	schema := jsonObject{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds the fields of t to properties, the ones of its embedded structs as its own
+func (reg *schemaRegistry) addFields(t reflect.Type, tag string, properties jsonObject, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				reg.addFields(embedded, tag, properties, required)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = reg.schemaOf(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if validateRequired[rule] {
+				*required = append(*required, name)
+				break
+			}
+		}
+	}
+}
+
+// pageSchema writes the schema of a page of the items of t
+func (reg *schemaRegistry) pageSchema(t reflect.Type) jsonObject {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reg.ref(t, schemaName(t)+"Page", func() jsonObject {
+		schema := reg.structSchema(reflect.TypeOf(pageDTO{}), "json")
+		schema["properties"].(jsonObject)["data"] = jsonObject{"type": "array", "items": reg.schemaOf(t)}
+		return schema
+	})
+}
+
+// formSchema writes the schema of a multipart body with the fields of t named by their schema tag and files
+func (reg *schemaRegistry) formSchema(t reflect.Type, files []string) jsonObject {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	schema := reg.structSchema(t, "schema")
+	for _, file := range files {
+		schema["properties"].(jsonObject)[file] = jsonObject{"type": "string", "format": "binary"}
+	}
+	return schema
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// openAPIDir keeps a copy of the OpenAPI document of each version of the API for its clients
+const openAPIDir = "../../../api"
+
+var update = flag.Bool("update", false, "regenerate the documents in "+openAPIDir)
+
+func TestOpenAPI(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, deprecation{})
+	for _, version := range s.apiVersions() {
+		t.Run(version.name, func(t *testing.T) {
+			file := filepath.Join(openAPIDir, version.name, "openapi.json")
+			got, err := marshalOpenAPI(version.name, version.routes())
+			if err != nil {
+				t.Fatalf("marshalOpenAPI() error: %v", err)
+			}
+			if *update {
+				if err := ioutil.WriteFile(file, got, 0644); err != nil {
+					t.Fatalf("test: write %s: %v", file, err)
+				}
+			}
+			want, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("test: read %s: %v", file, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s does not match the routes and the DTOs, regenerate it by go generate ./pkg/api/rest", file)
+			}
+			t.Run("When the document is fetched", func(t *testing.T) {
+				w := httptest.NewRecorder()
+				s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+version.name+OpenAPIPath, nil))
+				if w.Code != http.StatusOK {
+					t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+				}
+				var doc struct {
+					OpenAPI string                 `json:"openapi"`
+					Servers []struct{ URL string } `json:"servers"`
+					Paths   map[string]interface{} `json:"paths"`
+				}
+				if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+					t.Fatalf("test: decode body: %v", err)
+				}
+				if doc.OpenAPI != OpenAPIVersion {
+					t.Errorf("openapi: %s, want: %s", doc.OpenAPI, OpenAPIVersion)
+				}
+				if len(doc.Servers) != 1 || doc.Servers[0].URL != "/"+version.name {
+					t.Errorf("servers: %v, want: /%s", doc.Servers, version.name)
+				}
+				if _, ok := doc.Paths["/videos/{id}/assets/{kind}"]; !ok {
+					t.Errorf("paths: %v, want: /videos/{id}/assets/{kind}", doc.Paths)
+				}
+			})
+		})
+	}
+}
+
+func Test_openAPI(t *testing.T) {
+	routes := newServer(nil, nil, nil, nil, nil, nil, deprecation{}).routesV1()
+	tests := []struct {
+		name   string
+		routes []route
+	}{
+		{
+			name:   "When a route has no operation",
+			routes: append(routes, route{method: http.MethodGet, pattern: "/fake"}),
+		},
+		{
+			name:   "When an operation has no route",
+			routes: routes[1:],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := openAPI("v1", tt.routes); err == nil {
+				t.Errorf("openAPI() error: nil, want an error")
+			}
+		})
+	}
+}
+
+func TestServer_handleDocs(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, deprecation{})
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/docs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+	}
+	scripts := regexp.MustCompile(`<script src="([^"]+)"`).FindAllStringSubmatch(w.Body.String(), -1)
+	if len(scripts) == 0 {
+		t.Fatal("the docs page loads no script")
+	}
+	for _, script := range scripts {
+		src, err := url.Parse(script[1])
+		if err != nil {
+			t.Fatalf("test: parse %s: %v", script[1], err)
+		}
+		if src.IsAbs() || src.Host != "" {
+			t.Errorf("script %s is loaded from the network, want it served by the API", src)
+			continue
+		}
+		if _, err := redoc.Open("redoc/" + redocBundle); err != nil {
+			t.Skipf("the redoc bundle %s is not vendored, go generate fetches it", RedocVersion)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/"+src.Path, nil))
+		if w.Code != http.StatusOK || w.Body.Len() == 0 {
+			t.Errorf("statusCode of %s: %v, want: %v with the bundle", src, w.Code, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// pageDTO wraps the items of a list with the position of their page
+type pageDTO struct {
+	Data    interface{} `json:"data"`
+	Total   int64       `json:"total"`
+	Page    int         `json:"page,omitempty"`
+	PerPage int         `json:"per_page"`
+	Next    string      `json:"next,omitempty"`
+	Prev    string      `json:"prev,omitempty"`
+}
+
+// pageFromQuery reads the page asked by the cursor, page and per_page query parameters
+func pageFromQuery(query url.Values) (crud.Page, error) {
+	var page crud.Page
+	var err error
+	if v := query.Get("cursor"); v != "" {
+		if page.Cursor, err = crud.ParseCursor(v); err != nil {
+			return crud.Page{}, err
+		}
+	}
+	if v := query.Get("page"); v != "" {
+		if page.Number, err = strconv.Atoi(v); err != nil || page.Number < 1 {
+			return crud.Page{}, fmt.Errorf("'page' %w", logger.ErrIsNotValidated)
+		}
+	}
+	if v := query.Get("per_page"); v != "" {
+		if page.PerPage, err = strconv.Atoi(v); err != nil || page.PerPage < 1 {
+			return crud.Page{}, fmt.Errorf("'per_page' %w", logger.ErrIsNotValidated)
+		}
+	}
+	return page, nil
+}
+
+// writePage answers with the items of a page and links the pages around it in the Link header
+func (s *server) writePage(w http.ResponseWriter, r *http.Request, items interface{}, info crud.PageInfo) {
+	var links []string
+	link := func(rel string, params map[string]string) {
+		query := r.URL.Query()
+		query.Del("cursor")
+		query.Del("page")
+		query.Set("per_page", strconv.Itoa(info.PerPage))
+		for k, v := range params {
+			query.Set(k, v)
+		}
+		u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel))
+	}
+	if info.Number > 0 {
+		last := int((info.Total + int64(info.PerPage) - 1) / int64(info.PerPage))
+		if last == 0 {
+			last = 1
+		}
+		link("first", map[string]string{"page": "1"})
+		if info.Number > 1 {
+			link("prev", map[string]string{"page": strconv.Itoa(info.Number - 1)})
+		}
+		if info.Number < last {
+			link("next", map[string]string{"page": strconv.Itoa(info.Number + 1)})
+		}
+		link("last", map[string]string{"page": strconv.Itoa(last)})
+	} else {
+		if info.Prev != "" {
+			link("prev", map[string]string{"cursor": info.Prev})
+		}
+		if info.Next != "" {
+			link("next", map[string]string{"cursor": info.Next})
+		}
+	}
+	if len(links) > 0 {
+		w.Header().Add("Link", strings.Join(links, ", "))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	body := pageDTO{
+		Data:    items,
+		Total:   info.Total,
+		Page:    info.Number,
+		PerPage: info.PerPage,
+		Next:    info.Next,
+		Prev:    info.Prev,
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error(err)
+	}
+}
@@ -0,0 +1,211 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// MergePatchMediaType is the media type of the bodies of the PATCH requests, JSON merge patches (RFC 7386)
+const MergePatchMediaType = "application/merge-patch+json"
+
+// errPatch reports a patch that is not JSON or that makes an item its resource cannot read
+var errPatch = errors.New("could not be applied")
+
+// patchError is errPatch for the decode error err, which tells the field the patch went wrong on
+type patchError struct {
+	err error
+}
+
+func (e *patchError) Error() string {
+	return fmt.Sprintf("merge patch %s: %v", errPatch, e.err)
+}
+
+func (e *patchError) Is(target error) bool {
+	return target == errPatch
+}
+
+func (e *patchError) Unwrap() error {
+	return e.err
+}
+
+// patchFunc applies the merge patch doc to the item with id and updates it by svc at version
+type patchFunc func(svc crud.Service, id string, version int64, doc []byte) error
+
+// handlePatch updates the fields of the item named by the id parameter that its merge patch gives, leaving
+// the others as they are. A patch replaces the lists it gives, like the genres of a video, as a whole.
+func (s *server) handlePatch(patch patchFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != MergePatchMediaType {
+			w.Header().Set("Accept-Patch", MergePatchMediaType)
+			s.errUnsupportedMediaType(w, r, fmt.Errorf("content type of a patch should be %s", MergePatchMediaType))
+			return
+		}
+		doc, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			s.errInternalServer(w, r, err)
+			return
+		}
+		params := httprouter.ParamsFromContext(r.Context())
+		if err := patch(s.svcAs(r), params.ByName("id"), version, doc); err != nil {
+			switch {
+			case errors.Is(err, errPatch):
+				s.errUnprocessableEntity(w, r, err)
+			case errors.Is(err, logger.ErrNotFound):
+				s.errNotFound(w, r, err)
+			case errors.Is(err, logger.ErrIsStale):
+				s.errPreconditionFailed(w, r, err)
+			case errors.Is(err, logger.ErrAlreadyExists):
+				s.errStatusConflict(w, r, err)
+			case errors.Is(err, logger.ErrIsRequired), errors.Is(err, logger.ErrIsNotValidated):
+				s.errBadRequest(w, r, err)
+			default:
+				s.errInternalServer(w, r, err)
+			}
+			return
+		}
+	}
+}
+
+// patchedVersion is the version the update of a patched item is made at: the one asked, which the item
+// read has to be at, or the one of the item read, so that it is not changed between the read and the update
+func patchedVersion(asked, current int64) (int64, error) {
+	if asked != crud.AnyVersion && asked != current {
+		return 0, fmt.Errorf("version %d of %d %w", asked, current, logger.ErrIsStale)
+	}
+	return current, nil
+}
+
+// applyPatch merges the patch doc into item and decodes the result into patched
+func applyPatch(item interface{}, doc []byte, patched interface{}) error {
+	var patch interface{}
+	if err := json.Unmarshal(doc, &patch); err != nil {
+		return &patchError{err}
+	}
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	var target interface{}
+	if err := json.Unmarshal(b, &target); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(mergePatch(target, patch)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, patched); err != nil {
+		return &patchError{err}
+	}
+	return nil
+}
+
+// mergePatch applies patch to target as RFC 7386 does: the members of an object are merged one by one, a null
+// removes its member and any other value replaces the target
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+func (s *server) patchCategory(svc crud.Service, id string, version int64, doc []byte) error {
+	category, err := s.svc.FetchCategory(id)
+	if err != nil {
+		return err
+	}
+	if version, err = patchedVersion(version, category.Version); err != nil {
+		return err
+	}
+	dto, err := crud.MapCategoryToDTO(category)
+	if err != nil {
+		return err
+	}
+	var patched crud.CategoryDTO
+	if err := applyPatch(dto, doc, &patched); err != nil {
+		return err
+	}
+	return svc.UpdateCategory(id, version, patched)
+}
+
+func (s *server) patchGenre(svc crud.Service, id string, version int64, doc []byte) error {
+	genre, err := s.svc.FetchGenre(id)
+	if err != nil {
+		return err
+	}
+	if version, err = patchedVersion(version, genre.Version); err != nil {
+		return err
+	}
+	dto, err := crud.MapGenreToDTO(genre)
+	if err != nil {
+		return err
+	}
+	var patched crud.GenreDTO
+	if err := applyPatch(dto, doc, &patched); err != nil {
+		return err
+	}
+	return svc.UpdateGenre(id, version, patched)
+}
+
+func (s *server) patchCastMember(svc crud.Service, id string, version int64, doc []byte) error {
+	castMember, err := s.svc.FetchCastMember(id)
+	if err != nil {
+		return err
+	}
+	if version, err = patchedVersion(version, castMember.Version); err != nil {
+		return err
+	}
+	dto, err := crud.MapCastMemberToDTO(castMember)
+	if err != nil {
+		return err
+	}
+	var patched crud.CastMemberDTO
+	if err := applyPatch(dto, doc, &patched); err != nil {
+		return err
+	}
+	return svc.UpdateCastMember(id, version, patched)
+}
+
+func (s *server) patchVideo(svc crud.Service, id string, version int64, doc []byte) error {
+	video, err := s.svc.FetchVideo(id)
+	if err != nil {
+		return err
+	}
+	if version, err = patchedVersion(version, video.Version); err != nil {
+		return err
+	}
+	dto, err := crud.MapVideoToDTO(video)
+	if err != nil {
+		return err
+	}
+	var patched crud.VideoDTO
+	if err := applyPatch(dto, doc, &patched); err != nil {
+		return err
+	}
+	_, err = svc.UpdateVideo(id, version, patched)
+	return err
+}
@@ -0,0 +1,127 @@
+// +build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/selmison/code-micro-videos/pkg/api/rest"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_Patch_Videos(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeVideo, otherVideo := testdata.FakeVideosDTO[0], testdata.FakeVideosDTO[1]
+	videoUrl := fmt.Sprintf("http://%s/videos/%s", cfg.AddressServer, fakeVideo.ID)
+	genres := make([]map[string]string, len(otherVideo.Genres))
+	for i, genre := range otherVideo.Genres {
+		genres[i] = map[string]string{"name": genre.Name}
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		ifMatch     string
+		body        interface{}
+		status      int
+	}{
+		{
+			name:        "When the genres are patched",
+			contentType: rest.MergePatchMediaType,
+			ifMatch:     "*",
+			body:        map[string]interface{}{"genres": genres},
+			status:      http.StatusOK,
+		},
+		{
+			name:        "When the patch is not a merge patch",
+			contentType: "application/json",
+			ifMatch:     "*",
+			body:        map[string]interface{}{"description": "fake"},
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "When If-Match is not given",
+			contentType: rest.MergePatchMediaType,
+			body:        map[string]interface{}{"description": "fake"},
+			status:      http.StatusPreconditionRequired,
+		},
+		{
+			name:        "When If-Match is an older version",
+			contentType: rest.MergePatchMediaType,
+			ifMatch:     `"1"`,
+			body:        map[string]interface{}{"description": "fake"},
+			status:      http.StatusPreconditionFailed,
+		},
+		{
+			name:        "When the patch removes the title",
+			contentType: rest.MergePatchMediaType,
+			ifMatch:     "*",
+			body:        map[string]interface{}{"title": nil},
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "When the patch gives a field a value of another type",
+			contentType: rest.MergePatchMediaType,
+			ifMatch:     "*",
+			body:        map[string]interface{}{"duration": "fake"},
+			status:      http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPatch, videoUrl, bytes.NewReader(toJSON(tt.body)))
+			if err != nil {
+				t.Fatalf("test: new request: %v", err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			got, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			defer got.Body.Close()
+			if got.StatusCode != tt.status {
+				t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.status)
+			}
+			if got.StatusCode == http.StatusUnsupportedMediaType {
+				assert.Equal(t, rest.MergePatchMediaType, got.Header.Get("Accept-Patch"), "they should be equal")
+			}
+		})
+	}
+	t.Run("When the patched video is fetched", func(t *testing.T) {
+		got, err := http.Get(videoUrl)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		defer got.Body.Close()
+		var video crud.VideoDTO
+		if err := json.NewDecoder(got.Body).Decode(&video); err != nil {
+			t.Fatalf("test: decode body: %v", err)
+		}
+		gotGenres := make([]string, len(video.Genres))
+		for i, genre := range video.Genres {
+			gotGenres[i] = genre.Name
+		}
+		wantGenres := make([]string, len(genres))
+		for i, genre := range genres {
+			wantGenres[i] = genre["name"]
+		}
+		assert.ElementsMatch(t, wantGenres, gotGenres, "the genres should be replaced")
+		assert.Equal(t, fakeVideo.Title, video.Title, "the title should be left as it was")
+		assert.Equal(t, fakeVideo.Description, video.Description, "the description should be left as it was")
+		assert.Len(t, video.Categories, len(fakeVideo.Categories), "the categories should be left as they were")
+	})
+}
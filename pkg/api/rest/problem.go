@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/schema"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// ProblemMediaType is the media type of the bodies of the error responses, problem details (RFC 7807)
+const ProblemMediaType = "application/problem+json"
+
+// ProblemTypeBase prefixes the code of a problem to make its type URI
+const ProblemTypeBase = "urn:code-micro-videos:problem:"
+
+// The codes of the problems that their status does not tell apart
+const (
+	ValidationFailedCode = "validation_failed"
+	MalformedRequestCode = "malformed_request"
+	InvalidAssetCode     = "invalid_asset"
+)
+
+// Problem details an error response. Its code is stable, so clients can rely on it, and its errors list the
+// fields of the request that are not valid with the rules they break.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Errors   []crud.FieldError `json:"errors,omitempty"`
+}
+
+// statusCodes are the codes of the problems told by their status alone
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusPreconditionRequired:  "precondition_required",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+}
+
+// newProblem details the error err of r answered with status. The detail of a server error is left out, it
+// would only expose the internals of the server.
+func newProblem(r *http.Request, status int, err error) Problem {
+	p := Problem{
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: r.URL.Path,
+		Code:     statusCodes[status],
+	}
+	if status < http.StatusInternalServerError && err != nil {
+		p.Detail = err.Error()
+		p.Code, p.Errors = problemFields(err, p.Code)
+	}
+	if p.Code == "" {
+		p.Code = statusCodes[http.StatusInternalServerError]
+	}
+	p.Type = ProblemTypeBase + p.Code
+	return p
+}
+
+// problemFields finds the fields of the request that err is about and the code of their problem, which is code
+// when err is about no field
+func problemFields(err error, code string) (string, []crud.FieldError) {
+	var vErr *crud.ValidationError
+	if errors.As(err, &vErr) {
+		return ValidationFailedCode, vErr.Fields
+	}
+	var assetErr *crud.AssetError
+	if errors.As(err, &assetErr) {
+		rule := "asset_format"
+		if errors.Is(err, logger.ErrIsTooLarge) {
+			rule = "asset_size"
+		}
+		fields := make([]crud.FieldError, len(assetErr.Reasons))
+		for i, reason := range assetErr.Reasons {
+			fields[i] = crud.FieldError{Field: string(assetErr.Kind), Rule: rule, Detail: reason}
+		}
+		return InvalidAssetCode, fields
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := crud.FieldError{Field: typeErr.Field, Rule: "type", Detail: "want " + typeErr.Type.String()}
+		return MalformedRequestCode, []crud.FieldError{field}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return MalformedRequestCode, nil
+	}
+	var multiErr schema.MultiError
+	if errors.As(err, &multiErr) {
+		fields := make([]crud.FieldError, 0, len(multiErr))
+		for key, err := range multiErr {
+			field := crud.FieldError{Field: key, Rule: "invalid", Detail: err.Error()}
+			switch err.(type) {
+			case schema.ConversionError:
+				field.Rule = "type"
+			case schema.EmptyFieldError:
+				field.Rule = "required"
+			case schema.UnknownKeyError:
+				field.Rule = "unknown"
+			}
+			fields = append(fields, field)
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		return MalformedRequestCode, fields
+	}
+	return code, nil
+}
+
+// writeProblem answers r with the problem of err
+func (s *server) writeProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.Header().Set("Content-Type", ProblemMediaType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(newProblem(r, status, err)); err != nil {
+		s.logger.Error(err)
+	}
+}
@@ -0,0 +1,102 @@
+// +build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/selmison/code-micro-videos/pkg/api/rest"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_Problems(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeVideo := testdata.FakeVideosDTO[0]
+	fakeUrl := func(path string) string {
+		return fmt.Sprintf("http://%s%s", cfg.AddressServer, path)
+	}
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   rest.Problem
+	}{
+		{
+			name:   "When the fields of a video are not valid",
+			method: http.MethodPut,
+			path:   "/videos/" + fakeVideo.ID,
+			body:   `{"title": " ", "year_launched": 2020, "duration": 90, "rating": 9, "categories": [{"name": "fake"}]}`,
+			want: rest.Problem{
+				Status: http.StatusBadRequest,
+				Code:   rest.ValidationFailedCode,
+				Errors: []crud.FieldError{
+					{Field: "title", Rule: "not_blank"},
+					{Field: "genres", Rule: "not_blank"},
+					{Field: "rating", Rule: "video_rating"},
+				},
+			},
+		},
+		{
+			name:   "When a field of a video has a value of another type",
+			method: http.MethodPut,
+			path:   "/videos/" + fakeVideo.ID,
+			body:   `{"title": "fake", "duration": "fake"}`,
+			want: rest.Problem{
+				Status: http.StatusUnprocessableEntity,
+				Code:   rest.MalformedRequestCode,
+				Errors: []crud.FieldError{{Field: "duration", Rule: "type", Detail: "want int16"}},
+			},
+		},
+		{
+			name:   "When the method is not allowed",
+			method: http.MethodDelete,
+			path:   "/videos",
+			want: rest.Problem{
+				Status: http.StatusMethodNotAllowed,
+				Code:   "method_not_allowed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, fakeUrl(tt.path), bytes.NewReader([]byte(tt.body)))
+			if err != nil {
+				t.Fatalf("test: new request: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			req.Header.Set("If-Match", "*")
+			got, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			defer got.Body.Close()
+			if got.StatusCode != tt.want.Status {
+				t.Fatalf("statusCode: %v, want: %v", got.StatusCode, tt.want.Status)
+			}
+			assert.Equal(t, rest.ProblemMediaType, got.Header.Get("Content-Type"), "they should be equal")
+			var problem rest.Problem
+			if err := json.NewDecoder(got.Body).Decode(&problem); err != nil {
+				t.Fatalf("test: decode body: %v", err)
+			}
+			assert.Equal(t, rest.ProblemTypeBase+tt.want.Code, problem.Type, "they should be equal")
+			assert.Equal(t, http.StatusText(tt.want.Status), problem.Title, "they should be equal")
+			assert.Equal(t, tt.want.Status, problem.Status, "they should be equal")
+			assert.Equal(t, tt.path, problem.Instance, "they should be equal")
+			assert.Equal(t, tt.want.Code, problem.Code, "they should be equal")
+			assert.Equal(t, tt.want.Errors, problem.Errors, "they should be equal")
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/ratelimit"
+)
+
+// rateLimitClass tells whether r counts against the limit of the writes, the uploads do whatever their method
+func (s *server) rateLimitClass(r *http.Request) ratelimit.Class {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+		return ratelimit.Write
+	}
+	path := r.URL.Path
+	for _, version := range s.apiVersions() {
+		path = strings.TrimPrefix(path, "/"+version.name)
+	}
+	if path == "/uploads" || strings.HasPrefix(path, "/uploads/") {
+		return ratelimit.Write
+	}
+	return ratelimit.Read
+}
+
+// rateLimitClient names the client of r by its API key or the subject of its token, by its address when it was
+// not authenticated
+func rateLimitClient(r *http.Request) string {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		if p.KeyID != "" {
+			return "api_key:" + p.KeyID
+		}
+		return "user:" + p.Subject
+	}
+	return rateLimitAddress(r)
+}
+
+// rateLimitAddress names the remote address of r
+func rateLimitAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// seconds rounds d up to whole seconds as the headers tell it
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+// rateLimit is what the limit of class leaves to client after its request
+type rateLimit struct {
+	ratelimit.Result
+	class  ratelimit.Class
+	client string
+}
+
+// takeRateLimit takes a token of class for client. It returns nil when the request is not limited, the request
+// goes on when the store fails.
+func (s *server) takeRateLimit(class ratelimit.Class, client string) *rateLimit {
+	if s.limiter == nil {
+		return nil
+	}
+	result, err := s.limiter.Allow(client, class)
+	if err != nil {
+		s.logger.Error(err)
+		return nil
+	}
+	if !result.Limit.Enabled() {
+		return nil
+	}
+	return &rateLimit{Result: result, class: class, client: client}
+}
+
+// restricts tells whether l leaves less to its client than other: it refuses the request, it leaves fewer requests
+// or as many that come back later
+func (l *rateLimit) restricts(other *rateLimit) bool {
+	if l.Allowed != other.Allowed {
+		return !l.Allowed
+	}
+	if l.Remaining != other.Remaining {
+		return l.Remaining < other.Remaining
+	}
+	return l.Reset > other.Reset
+}
+
+// mostRestrictive returns the limit of limits that restricts the request the most, nil when none limits it
+func mostRestrictive(limits ...*rateLimit) *rateLimit {
+	var most *rateLimit
+	for _, l := range limits {
+		if l != nil && (most == nil || l.restricts(most)) {
+			most = l
+		}
+	}
+	return most
+}
+
+// limit tells by the RateLimit headers what l leaves to the client of r. It answers 429 and returns false when l
+// refuses the request.
+func (s *server) limit(w http.ResponseWriter, r *http.Request, l *rateLimit) bool {
+	if l == nil {
+		return true
+	}
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(l.Limit.Requests))
+	h.Set("RateLimit-Remaining", strconv.Itoa(l.Remaining))
+	h.Set("RateLimit-Reset", seconds(l.Reset))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", l.Limit.Requests, seconds(l.Limit.Period)))
+	if l.Allowed {
+		return true
+	}
+	retryAfter := seconds(l.RetryAfter)
+	h.Set("Retry-After", retryAfter)
+	s.errTooManyRequests(w, r, fmt.Errorf(
+		"%s requests of %s %w to %s, retry after %s seconds", l.class, l.client, logger.ErrIsRateLimited, l.Limit, retryAfter,
+	))
+	return false
+}
@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/ratelimit"
+)
+
+func TestServer_rateLimitClass(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, deprecation{})
+	tests := []struct {
+		method string
+		path   string
+		want   ratelimit.Class
+	}{
+		{http.MethodGet, "/v1/videos", ratelimit.Read},
+		{http.MethodOptions, "/v1/uploads", ratelimit.Write},
+		{http.MethodPost, "/v1/videos", ratelimit.Write},
+		{http.MethodPatch, "/categories/fake", ratelimit.Write},
+		{http.MethodDelete, "/v1/genres/fake", ratelimit.Write},
+		{http.MethodHead, "/v1/uploads/fake", ratelimit.Write},
+		{http.MethodHead, "/uploads/fake", ratelimit.Write},
+		{http.MethodGet, "/v1/uploadsfake", ratelimit.Read},
+	}
+	for _, tt := range tests {
+		if got := s.rateLimitClass(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+			t.Errorf("rateLimitClass(%s %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestServer_limit(t *testing.T) {
+	verifier, sign := newTestVerifier(t)
+	limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+	addressLimit := ratelimit.Limit{Requests: 3, Period: time.Minute}
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Read: limit, Write: limit, Address: addressLimit}, ratelimit.NewMemoryStore())
+	s := newServer(nil, nil, nil, verifier, nil, limiter, deprecation{})
+	s.router.HandlerFunc(http.MethodGet, "/fake", s.authorize(auth.Viewer, auth.CatalogRead, func(w http.ResponseWriter, r *http.Request) {}))
+	s.router.HandlerFunc(http.MethodPost, "/fake", s.authorize(auth.Editor, auth.CatalogWrite, func(w http.ResponseWriter, r *http.Request) {}))
+	token := sign("editor")
+	serve := func(method, remoteAddr, authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/fake", nil)
+		r.RemoteAddr = remoteAddr
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		return w
+	}
+	t.Run("When the client makes its first read", func(t *testing.T) {
+		w := serve(http.MethodGet, "192.0.2.1:1234", "Bearer "+token)
+		if w.Code != http.StatusOK {
+			t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+		}
+		want := map[string]string{
+			"RateLimit-Limit":     "1",
+			"RateLimit-Remaining": "0",
+			"RateLimit-Reset":     "60",
+			"RateLimit-Policy":    "1;w=60",
+		}
+		for header, value := range want {
+			if got := w.Header().Get(header); got != value {
+				t.Errorf("%s: %q, want: %q", header, got, value)
+			}
+		}
+	})
+	t.Run("When the client reads over its limit from another address", func(t *testing.T) {
+		w := serve(http.MethodGet, "192.0.2.2:1234", "Bearer "+token)
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusTooManyRequests)
+		}
+		if got := w.Header().Get("Retry-After"); got != "60" {
+			t.Errorf("Retry-After: %q, want: %q", got, "60")
+		}
+		var p Problem
+		if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+			t.Fatalf("test: decode body: %v", err)
+		}
+		if p.Code != "too_many_requests" {
+			t.Errorf("problem code: %s, want: too_many_requests", p.Code)
+		}
+	})
+	t.Run("When the client writes after reading over its limit", func(t *testing.T) {
+		if w := serve(http.MethodPost, "192.0.2.1:1234", "Bearer "+token); w.Code != http.StatusOK {
+			t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+		}
+	})
+	t.Run("When an address is limited before its credentials are checked", func(t *testing.T) {
+		if w := serve(http.MethodGet, "192.0.2.1:1234", "Bearer fake"); w.Code != http.StatusUnauthorized {
+			t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusUnauthorized)
+		}
+		for _, authorization := range []string{"Bearer fake", "Bearer " + sign("admin")} {
+			w := serve(http.MethodPost, "192.0.2.1:4321", authorization)
+			if w.Code != http.StatusTooManyRequests {
+				t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusTooManyRequests)
+			}
+			if got := w.Header().Get("RateLimit-Limit"); got != "3" {
+				t.Errorf("RateLimit-Limit: %q, want the one of the address", got)
+			}
+		}
+	})
+	t.Run("When anonymous clients are limited by their address", func(t *testing.T) {
+		if w := serve(http.MethodGet, "192.0.2.3:1234", ""); w.Code != http.StatusUnauthorized {
+			t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusUnauthorized)
+		}
+		if w := serve(http.MethodGet, "192.0.2.3:4321", ""); w.Code != http.StatusTooManyRequests {
+			t.Errorf("statusCode: %v, want: %v", w.Code, http.StatusTooManyRequests)
+		}
+	})
+}
+
+func TestServer_limit_MostRestrictive(t *testing.T) {
+	verifier, sign := newTestVerifier(t)
+	clientLimit := ratelimit.Limit{Requests: 10, Period: time.Minute}
+	addressLimit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Read: clientLimit, Write: clientLimit, Address: addressLimit}, ratelimit.NewMemoryStore())
+	s := newServer(nil, nil, nil, verifier, nil, limiter, deprecation{})
+	s.router.HandlerFunc(http.MethodGet, "/fake", s.authorize(auth.Viewer, auth.CatalogRead, func(w http.ResponseWriter, r *http.Request) {}))
+	r := httptest.NewRequest(http.MethodGet, "/fake", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("Authorization", "Bearer "+sign("viewer"))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("statusCode: %v, want: %v", w.Code, http.StatusOK)
+	}
+	want := map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Policy":    "2;w=60",
+	}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("%s: %q, want: %q, the ones of the address", header, got, value)
+		}
+	}
+}
@@ -1,118 +1,458 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (s *server) routes() {
-	routes := []struct {
-		method      string
-		pattern     string
-		handlerFunc http.HandlerFunc
-	}{
+// route is a handler of the API, the OpenAPI document describes it by the operation of its method and pattern.
+// Its role is the one a request needs to be served when the requests are authenticated by a token, its scope the
+// one they need when they are authenticated by an API key. The routes without scope refuse the API keys.
+type route struct {
+	method      string
+	pattern     string
+	handlerFunc http.HandlerFunc
+	role        auth.Role
+	scope       auth.Scope
+}
+
+// routesV1 are the handlers of the version 1 of the API
+func (s *server) routesV1() []route {
+	return []route{
 		{
 			"GET",
 			"/categories",
 			s.handleCategoriesGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
-			"/categories/:name",
+			"/categories/:id",
 			s.handleCategoryGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"POST",
 			"/categories",
 			s.handleCategoryCreate(),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"PUT",
-			"/categories/:name",
+			"/categories/:id",
 			s.handleCategoryUpdate(),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"PATCH",
+			"/categories/:id",
+			s.handlePatch(s.patchCategory),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"DELETE",
-			"/categories/:name",
+			"/categories/:id",
 			s.handleCategoryDelete(),
+			auth.Admin,
+			auth.CatalogDelete,
+		},
+		{
+			"POST",
+			"/categories/:id/restore",
+			s.handleRestore(crud.Service.RestoreCategory),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"GET",
+			"/categories/:id/history",
+			s.handleHistoryGet(crud.CategoryEntity),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/trash/categories",
+			s.handleTrashGet(s.categoriesInTrash),
+			auth.Editor,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
 			"/genres",
 			s.handleGenresGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
-			"/genres/:name",
+			"/genres/:id",
 			s.handleGenreGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"POST",
 			"/genres",
 			s.handleGenreCreate(),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"PUT",
-			"/genres/:name",
+			"/genres/:id",
 			s.handleGenreUpdate(),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"PATCH",
+			"/genres/:id",
+			s.handlePatch(s.patchGenre),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"DELETE",
-			"/genres/:name",
+			"/genres/:id",
 			s.handleGenreDelete(),
+			auth.Admin,
+			auth.CatalogDelete,
+		},
+		{
+			"POST",
+			"/genres/:id/restore",
+			s.handleRestore(crud.Service.RestoreGenre),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"GET",
+			"/genres/:id/history",
+			s.handleHistoryGet(crud.GenreEntity),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/trash/genres",
+			s.handleTrashGet(s.genresInTrash),
+			auth.Editor,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
 			"/cast_members",
 			s.handleCastMembersGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
-			"/cast_members/:name",
+			"/cast_members/:id",
 			s.handleCastMemberGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"POST",
 			"/cast_members",
 			s.handleCastMemberCreate(),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"PUT",
-			"/cast_members/:name",
+			"/cast_members/:id",
 			s.handleCastMemberUpdate(),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"PATCH",
+			"/cast_members/:id",
+			s.handlePatch(s.patchCastMember),
+			auth.Editor,
+			auth.CatalogWrite,
 		},
 		{
 			"DELETE",
-			"/cast_members/:name",
+			"/cast_members/:id",
 			s.handleCastMemberDelete(),
+			auth.Admin,
+			auth.CatalogDelete,
+		},
+		{
+			"POST",
+			"/cast_members/:id/restore",
+			s.handleRestore(crud.Service.RestoreCastMember),
+			auth.Editor,
+			auth.CatalogWrite,
+		},
+		{
+			"GET",
+			"/cast_members/:id/history",
+			s.handleHistoryGet(crud.CastMemberEntity),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/trash/cast_members",
+			s.handleTrashGet(s.castMembersInTrash),
+			auth.Editor,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/cast_members/:id/videos",
+			s.handleCastMemberVideosGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
 			"/videos",
 			s.handleVideosGet(),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/videos/search",
+			s.handleVideosSearch(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"GET",
-			"/videos/:title",
+			"/videos/:id",
 			s.handleVideoGet(),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/videos/:id/file",
+			s.handleVideoAssetGet(),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/videos/:id/assets/:kind",
+			s.handleVideoAssetGet(),
+			auth.Viewer,
+			auth.CatalogRead,
 		},
 		{
 			"POST",
 			"/videos",
 			s.handleVideoCreate(),
+			auth.Editor,
+			auth.VideosWrite,
 		},
 		{
 			"PUT",
-			"/videos/:title",
+			"/videos/:id",
 			s.handleVideoUpdate(),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"PATCH",
+			"/videos/:id",
+			s.handlePatch(s.patchVideo),
+			auth.Editor,
+			auth.VideosWrite,
 		},
 		{
 			"DELETE",
-			"/videos/:title",
+			"/videos/:id",
 			s.handleVideoDelete(),
+			auth.Admin,
+			auth.CatalogDelete,
+		},
+		{
+			"POST",
+			"/videos/:id/restore",
+			s.handleRestore(crud.Service.RestoreVideo),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"GET",
+			"/videos/:id/history",
+			s.handleHistoryGet(crud.VideoEntity),
+			auth.Viewer,
+			auth.CatalogRead,
+		},
+		{
+			"GET",
+			"/trash/videos",
+			s.handleTrashGet(s.videosInTrash),
+			auth.Editor,
+			auth.CatalogRead,
+		},
+		{
+			"OPTIONS",
+			"/uploads",
+			s.handleUploadOptions(),
+			auth.Viewer,
+			auth.VideosWrite,
 		},
+		{
+			"POST",
+			"/uploads",
+			s.handleUploadCreate(),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"HEAD",
+			"/uploads/:id",
+			s.handleUploadHead(),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"PATCH",
+			"/uploads/:id",
+			s.handleUploadPatch(),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"DELETE",
+			"/uploads/:id",
+			s.handleUploadDelete(),
+			auth.Editor,
+			auth.VideosWrite,
+		},
+		{
+			"POST",
+			"/api_keys",
+			s.handleAPIKeyCreate(),
+			auth.Admin,
+			"",
+		},
+		{
+			"GET",
+			"/api_keys",
+			s.handleAPIKeysGet(),
+			auth.Admin,
+			"",
+		},
+		{
+			"DELETE",
+			"/api_keys/:id",
+			s.handleAPIKeyRevoke(),
+			auth.Admin,
+			"",
+		},
+	}
+}
+
+func (s *server) routes() {
+	for _, version := range s.apiVersions() {
+		s.handleVersion("/"+version.name, version, version.deprecation)
+		if version.name == rootVersion {
+			s.handleVersion("", version, s.rootDeprecation)
+		}
 	}
+	s.router.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.errNotFound(w, r, fmt.Errorf("route %s %w", r.URL.Path, logger.ErrNotFound))
+	})
+	s.router.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.errMethodNotAllowed(w, r, fmt.Errorf("method %s of %s is not allowed", r.Method, r.URL.Path))
+	})
+}
 
+// handleVersion serves the routes of version under prefix with their OpenAPI document and its docs page
+func (s *server) handleVersion(prefix string, version apiVersion, deprecation deprecation) {
+	routes := version.routes()
+	handlers := make(map[string]http.HandlerFunc, len(routes))
 	for _, route := range routes {
-		s.router.HandlerFunc(route.method, route.pattern, route.handlerFunc)
+		handlers[route.method+" "+route.pattern] = deprecation.wrap(prefix, s.authorize(route.role, route.scope, route.handlerFunc))
+	}
+	shadowed := shadowedRoutes(routes)
+	for _, route := range routes {
+		key := route.method + " " + route.pattern
+		if _, ok := shadowed[key]; ok {
+			continue
+		}
+		handler, statics := handlers[key], make(map[string]http.HandlerFunc)
+		for static, by := range shadowed {
+			if by == key {
+				statics[prefix+strings.SplitN(static, " ", 2)[1]] = handlers[static]
+			}
+		}
+		if len(statics) > 0 {
+			handler = dispatchStatic(statics, handler)
+		}
+		s.router.HandlerFunc(route.method, prefix+route.pattern, handler)
+	}
+	s.router.HandlerFunc("GET", prefix+OpenAPIPath, deprecation.wrap(prefix, s.handleOpenAPI(version.name, routes)))
+	s.router.HandlerFunc("GET", prefix+"/docs", deprecation.wrap(prefix, s.handleDocs()))
+	s.router.HandlerFunc("GET", prefix+"/docs/"+redocBundle, deprecation.wrap(prefix, s.handleRedoc()))
+}
+
+// shadowedRoutes maps the static routes that a route of the same method has a parameter in place of to the key
+// of this route. The router refuses to register both, so the static ones are served by the other. It panics when
+// a shadowed route has parameters, they could not be told to its handler.
+func shadowedRoutes(routes []route) map[string]string {
+	shadowed := make(map[string]string)
+	for _, static := range routes {
+		for _, param := range routes {
+			if param.method != static.method || !shadows(param.pattern, static.pattern) {
+				continue
+			}
+			if strings.Contains(static.pattern, ":") {
+				panic(fmt.Sprintf("route %s %s is shadowed by %s", static.method, static.pattern, param.pattern))
+			}
+			shadowed[static.method+" "+static.pattern] = param.method + " " + param.pattern
+		}
+	}
+	return shadowed
+}
+
+// shadows reports whether the pattern param has a parameter where static has a static segment, and the same
+// segments elsewhere
+func shadows(param, static string) bool {
+	params, statics := strings.Split(param, "/"), strings.Split(static, "/")
+	if len(params) != len(statics) {
+		return false
+	}
+	shadows := false
+	for i, segment := range params {
+		switch {
+		case segment == statics[i]:
+		case strings.HasPrefix(segment, ":") && !strings.HasPrefix(statics[i], ":"):
+			shadows = true
+		default:
+			return false
+		}
+	}
+	return shadows
+}
+
+// dispatchStatic serves the paths of statics by their handlers, the others by next
+func dispatchStatic(statics map[string]http.HandlerFunc, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if handler, ok := statics[r.URL.Path]; ok {
+			handler(w, r)
+			return
+		}
+		next(w, r)
 	}
 }
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+)
+
+func TestShadowedRoutes(t *testing.T) {
+	routes := []route{
+		{method: "GET", pattern: "/videos/search"},
+		{method: "GET", pattern: "/videos/:id"},
+		{method: "POST", pattern: "/videos/search"},
+		{method: "GET", pattern: "/videos/:id/file"},
+		{method: "GET", pattern: "/videos"},
+	}
+	want := map[string]string{"GET /videos/search": "GET /videos/:id"}
+	if got := shadowedRoutes(routes); !reflect.DeepEqual(got, want) {
+		t.Errorf("shadowedRoutes() = %v, want %v", got, want)
+	}
+	t.Run("When a shadowed route has parameters", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("shadowedRoutes() did not panic")
+			}
+		}()
+		shadowedRoutes([]route{
+			{method: "GET", pattern: "/videos/:id/assets/:kind"},
+			{method: "GET", pattern: "/videos/search/assets/:kind"},
+		})
+	})
+}
+
+func TestServer_handleVersion(t *testing.T) {
+	s := newServer(nil, nil, nil, nil, nil, nil, deprecation{})
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(name)) }
+	}
+	version := apiVersion{name: "fake", routes: func() []route {
+		return []route{
+			{"GET", "/fakes/search", handler("search"), auth.Viewer, auth.CatalogRead},
+			{"GET", "/fakes/:id", handler("id"), auth.Viewer, auth.CatalogRead},
+		}
+	}}
+	s.handleVersion("/fake", version, deprecation{})
+	for path, want := range map[string]string{"/fake/fakes/search": "search", "/fake/fakes/fake": "id"} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := w.Body.String(); got != want {
+			t.Errorf("GET %s served by %q, want %q", path, got, want)
+		}
+	}
+}
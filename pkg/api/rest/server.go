@@ -1,27 +1,50 @@
 package rest
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/selmison/code-micro-videos/config"
+	"github.com/selmison/code-micro-videos/pkg/auth"
 	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/ratelimit"
 	"github.com/selmison/code-micro-videos/pkg/storage/sqlboiler"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
 )
 
+const expiredUploadsInterval = time.Hour
+
 type server struct {
-	router *httprouter.Router
-	svc    crud.Service
-	logger *zap.SugaredLogger
+	router  *httprouter.Router
+	svc     crud.Service
+	uploads uploads.Store
+	assets  *crud.AssetValidator
+	// auth verifies the tokens of the requests, every request is allowed when it is nil, which only a config
+	// that disables the authentication leaves it. apiKeys authenticates the machine clients, whether the tokens
+	// are verified or not.
+	auth    *auth.Verifier
+	apiKeys *auth.APIKeys
+	// limiter limits the requests of each client, they are not limited when it is nil
+	limiter *ratelimit.Limiter
+	// rootDeprecation deprecates the paths without a version
+	rootDeprecation deprecation
+	logger          *zap.SugaredLogger
 }
 
 func InitApp(ctx context.Context, cfg *config.Config) error {
@@ -35,12 +58,33 @@ func InitApp(ctx context.Context, cfg *config.Config) error {
 		}
 	}()
 	r := sqlboiler.NewRepository(ctx, db, cfg.RepoFiles)
-	svc := crud.NewService(r)
-	return initHttpServer(cfg.AddressServer, svc)
+	assets, err := crud.NewAssetValidator(cfg.AssetMaxSizes)
+	if err != nil {
+		return err
+	}
+	opts := []crud.ServiceOption{crud.WithAssetValidator(assets), crud.WithAuditLog(r)}
+	if cfg.SearchLanguage != "" {
+		opts = append(opts, crud.WithSearchLanguage(cfg.SearchLanguage))
+	}
+	svc := crud.NewService(r, opts...)
+	verifier, err := newVerifier(cfg.Auth)
+	if err != nil {
+		return err
+	}
+	apiKeys := auth.NewAPIKeys(r)
+	limiter := ratelimit.NewLimiter(cfg.RateLimit, ratelimit.NewMemoryStore())
+	s := newServer(svc, cfg.RepoUploads, assets, verifier, apiKeys, limiter, newRootDeprecation(cfg.RootDeprecation))
+	if verifier == nil {
+		s.logger.Warn("the authentication is disabled, every request is allowed")
+	}
+	if cfg.FilesGC.Interval > 0 {
+		go s.collectOrphanFiles(ctx, r, cfg.FilesGC)
+	}
+	return initHttpServer(ctx, cfg.AddressServer, s)
 }
 
-func initHttpServer(address string, crud crud.Service) error {
-	s := newServer(crud)
+func initHttpServer(ctx context.Context, address string, s *server) error {
+	go s.removeExpiredUploads(ctx, expiredUploadsInterval)
 	fmt.Printf("The server is on tap now: http://%s\n", address)
 	if err := http.ListenAndServe(address, s); err != nil {
 		return err
@@ -62,66 +106,299 @@ func initLogger() *zap.SugaredLogger {
 	return sugar
 }
 
-func newServer(svc crud.Service) *server {
+func newServer(
+	svc crud.Service,
+	uploads uploads.Store,
+	assets *crud.AssetValidator,
+	verifier *auth.Verifier,
+	apiKeys *auth.APIKeys,
+	limiter *ratelimit.Limiter,
+	rootDeprecation deprecation,
+) *server {
 	r := httprouter.New()
 	r.GET("/", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		if _, err := fmt.Fprint(w, "Welcome!\n"); err != nil {
 			log.Println(err)
 		}
 	})
-	s := &server{router: r, svc: svc, logger: initLogger()}
+	s := &server{
+		router:          r,
+		svc:             svc,
+		uploads:         uploads,
+		assets:          assets,
+		auth:            verifier,
+		apiKeys:         apiKeys,
+		limiter:         limiter,
+		rootDeprecation: rootDeprecation,
+		logger:          initLogger(),
+	}
 	s.routes()
 	return s
 }
 
+// removeExpiredUploads periodically discards the resumable uploads that were abandoned
+func (s *server) removeExpiredUploads(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.uploads.RemoveExpired()
+			if err != nil {
+				s.logger.Error(err)
+			}
+			if len(removed) > 0 {
+				s.logger.Infof("removed %d expired uploads", len(removed))
+			}
+		}
+	}
+}
+
+// collectOrphanFiles periodically removes the stored files that no video references, or only logs them on a dry run
+func (s *server) collectOrphanFiles(ctx context.Context, r *sqlboiler.Repository, gc config.FilesGC) {
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := r.CollectOrphanFiles(gc.MinAge, gc.DryRun)
+			if err != nil {
+				s.logger.Error(err)
+			}
+			orphans := len(report.Orphans) + len(report.OrphanBlobs)
+			if orphans == 0 {
+				continue
+			}
+			if report.DryRun {
+				for _, f := range report.Orphans {
+					s.logger.Infof("orphan file %s/%s of %d bytes", f.VideoID, f.Name, f.Size)
+				}
+				for _, blob := range report.OrphanBlobs {
+					s.logger.Infof("orphan blob %s of %d bytes", blob.Hash, blob.Size)
+				}
+				s.logger.Infof("found %d orphan files of %d bytes", orphans, report.Freed)
+				continue
+			}
+			s.logger.Infof("removed %d orphan files of %d bytes", orphans, report.Freed)
+		}
+	}
+}
+
 func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.logger.Info(r.Method, r.URL.Path)
+	// the requests of an address are limited before their credentials are checked, so they cannot be guessed
+	// nor verified at the cost of the server
+	address := s.takeRateLimit(ratelimit.Address, rateLimitAddress(r))
+	if address != nil && !address.Allowed {
+		s.limit(w, r, address)
+		return
+	}
+	r, err := s.authenticate(r)
+	if err != nil {
+		s.limit(w, r, address)
+		if errors.Is(err, logger.ErrIsNotAuthenticated) {
+			s.errUnauthorized(w, r, err)
+			return
+		}
+		s.errInternalServer(w, r, err)
+		return
+	}
+	// the headers tell the limit that leaves the client the least, whether it is the one of its address or its own
+	if !s.limit(w, r, mostRestrictive(address, s.takeRateLimit(s.rateLimitClass(r), rateLimitClient(r)))) {
+		return
+	}
 	s.router.ServeHTTP(w, r)
 }
 
 func (s *server) bodyToStruct(w http.ResponseWriter, r *http.Request, dto interface{}) error {
 	bytes, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		s.errInternalServer(w, err)
+		s.errInternalServer(w, r, err)
 		return err
 	}
 	if err := r.Body.Close(); err != nil {
-		s.errInternalServer(w, err)
+		s.errInternalServer(w, r, err)
 		return err
 	}
 	if err := json.Unmarshal(bytes, &dto); err != nil {
-		s.errUnprocessableEntity(w, err)
-		if err := json.NewEncoder(w).Encode(err); err != nil {
-			s.errInternalServer(w, err)
-			return err
-		}
+		s.errUnprocessableEntity(w, r, err)
 		return err
 	}
 	return nil
 }
 
-func (s *server) errBadRequest(w http.ResponseWriter, err error) {
+// multipartFiles streams the file parts of a multipart body one at a time
+type multipartFiles struct {
+	mr     *multipart.Reader
+	next   *multipart.Part
+	fields map[string]bool
+}
+
+// NextFile returns the next non empty part named by one of the file fields, the reader is only valid until
+// the next call and io.EOF reports the end of the body
+func (f *multipartFiles) NextFile() (string, io.Reader, error) {
+	for {
+		part := f.next
+		f.next = nil
+		if part == nil {
+			var err error
+			if part, err = f.mr.NextPart(); err != nil {
+				if err == io.EOF {
+					return "", nil, err
+				}
+				return "", nil, fmt.Errorf("multipart body %w: %v", logger.ErrIsNotValidated, err)
+			}
+		}
+		if !f.fields[part.FormName()] {
+			continue
+		}
+		br := bufio.NewReader(part)
+		if _, err := br.Peek(1); err == io.EOF {
+			continue
+		} else if err != nil {
+			return "", nil, fmt.Errorf("multipart body %w: %v", logger.ErrIsNotValidated, err)
+		}
+		return part.FormName(), br, nil
+	}
+}
+
+// multipartToStruct decodes the form fields of a multipart body into dto and returns its files still unread,
+// so they are streamed instead of buffered. The form fields must precede the files and their total size is
+// bounded by MaxMemory.
+func (s *server) multipartToStruct(w http.ResponseWriter, r *http.Request, dto interface{}, fileFields ...string) (*multipartFiles, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		s.errBadRequest(w, r, err)
+		return nil, err
+	}
+	files := &multipartFiles{mr: mr, fields: make(map[string]bool, len(fileFields))}
+	for _, field := range fileFields {
+		files.fields[field] = true
+	}
+	form := url.Values{}
+	remaining := int64(MaxMemory)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return nil, err
+		}
+		if files.fields[part.FormName()] {
+			files.next = part
+			break
+		}
+		if part.FileName() != "" {
+			continue
+		}
+		value, err := ioutil.ReadAll(io.LimitReader(part, remaining+1))
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return nil, err
+		}
+		remaining -= int64(len(value))
+		if remaining < 0 {
+			err := errors.New("multipart form fields too large")
+			s.errRequestEntityTooLarge(w, r, err)
+			return nil, err
+		}
+		form.Add(part.FormName(), string(value))
+	}
+	if err := decoder.Decode(dto, form); err != nil {
+		s.errUnprocessableEntity(w, r, err)
+		return nil, err
+	}
+	return files, nil
+}
+
+func (s *server) errBadRequest(w http.ResponseWriter, r *http.Request, err error) {
 	s.logger.Warn(err)
-	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	s.writeProblem(w, r, http.StatusBadRequest, err)
 }
 
-func (s *server) errInternalServer(w http.ResponseWriter, err error) {
+func (s *server) errInternalServer(w http.ResponseWriter, r *http.Request, err error) {
 	s.logger.Error(err)
-	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	s.writeProblem(w, r, http.StatusInternalServerError, err)
+}
+
+// errUnauthorized answers a request whose credentials are missing or were not verified, the challenge tells the
+// client to bear a token (RFC 6750) or an API key
+func (s *server) errUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Info(err)
+	challenge := BearerScheme
+	if r.Header.Get("Authorization") != "" {
+		challenge += ` error="invalid_token"`
+	}
+	if s.apiKeys != nil {
+		challenge += ", " + APIKeyScheme
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	s.writeProblem(w, r, http.StatusUnauthorized, err)
+}
+
+func (s *server) errForbidden(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusForbidden, err)
 }
 
-func (s *server) errNotFound(w http.ResponseWriter, err error) {
+func (s *server) errTooManyRequests(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusTooManyRequests, err)
+}
+
+func (s *server) errNotFound(w http.ResponseWriter, r *http.Request, err error) {
 	s.logger.Info(err)
-	http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	s.writeProblem(w, r, http.StatusNotFound, err)
+}
+
+func (s *server) errUnprocessableEntity(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusUnprocessableEntity, err)
+}
+
+// errInvalidAsset answers with the reasons why an asset file was refused
+func (s *server) errInvalidAsset(w http.ResponseWriter, r *http.Request, err *crud.AssetError) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusUnprocessableEntity, err)
+}
+
+func (s *server) errMethodNotAllowed(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Info(err)
+	s.writeProblem(w, r, http.StatusMethodNotAllowed, err)
+}
+
+func (s *server) errStatusConflict(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusConflict, err)
+}
+
+func (s *server) errGone(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Info(err)
+	s.writeProblem(w, r, http.StatusGone, err)
+}
+
+func (s *server) errPreconditionFailed(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusPreconditionFailed, err)
+}
+
+func (s *server) errPreconditionRequired(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.Warn(err)
+	s.writeProblem(w, r, http.StatusPreconditionRequired, err)
 }
 
-func (s *server) errUnprocessableEntity(w http.ResponseWriter, err error) {
+func (s *server) errRequestEntityTooLarge(w http.ResponseWriter, r *http.Request, err error) {
 	s.logger.Warn(err)
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	http.Error(w, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
+	s.writeProblem(w, r, http.StatusRequestEntityTooLarge, err)
 }
 
-func (s *server) errStatusConflict(w http.ResponseWriter, err error) {
+func (s *server) errUnsupportedMediaType(w http.ResponseWriter, r *http.Request, err error) {
 	s.logger.Warn(err)
-	http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+	s.writeProblem(w, r, http.StatusUnsupportedMediaType, err)
 }
@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// the items of the trashes carry the time they were removed
+type (
+	trashedCategoryDTO struct {
+		crud.CategoryDTO
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+	trashedGenreDTO struct {
+		crud.GenreDTO
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+	trashedCastMemberDTO struct {
+		crud.CastMemberDTO
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+	trashedVideoDTO struct {
+		*crud.VideoDTO
+		DeletedAt time.Time `json:"deleted_at"`
+	}
+)
+
+// handleTrashGet answers with a page of a trash, list reads it from the service as DTOs
+func (s *server) handleTrashGet(list func(page crud.Page) (interface{}, crud.PageInfo, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if err := checkParams(query, nil); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		items, info, err := list(page)
+		if err != nil {
+			if errors.Is(err, logger.ErrInvalidedLimit) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		s.writePage(w, r, items, info)
+	}
+}
+
+// handleRestore takes the item named by the id parameter out of its trash, restore is a method of the service
+func (s *server) handleRestore(restore func(svc crud.Service, id string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		if err := restore(s.svcAs(r), params.ByName("id")); err != nil {
+			if errors.Is(err, logger.ErrIsRequired) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrNotFound) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrAlreadyExists) {
+				s.errStatusConflict(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+	}
+}
+
+// removeOrPurge returns purge when the purge query parameter asks for it, remove otherwise
+func removeOrPurge(r *http.Request, remove, purge func(id string, version int64) error) (func(id string, version int64) error, error) {
+	v, err := boolParam(r.URL.Query(), "purge")
+	if err != nil {
+		return nil, err
+	}
+	if v != nil && *v {
+		return purge, nil
+	}
+	return remove, nil
+}
+
+func (s *server) categoriesInTrash(page crud.Page) (interface{}, crud.PageInfo, error) {
+	categories, info, err := s.svc.GetCategoriesInTrash(page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	categoriesDTO := make([]trashedCategoryDTO, len(categories))
+	for i, category := range categories {
+		categoriesDTO[i] = trashedCategoryDTO{
+			CategoryDTO: crud.CategoryDTO{
+				ID:          category.ID,
+				Name:        category.Name,
+				Description: category.Description.String,
+			},
+			DeletedAt: category.DeletedAt.Time,
+		}
+	}
+	return categoriesDTO, info, nil
+}
+
+func (s *server) genresInTrash(page crud.Page) (interface{}, crud.PageInfo, error) {
+	genres, info, err := s.svc.GetGenresInTrash(page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	genresDTO := make([]trashedGenreDTO, len(genres))
+	for i, genre := range genres {
+		genresDTO[i] = trashedGenreDTO{
+			GenreDTO: crud.GenreDTO{
+				ID:   genre.ID,
+				Name: genre.Name,
+			},
+			DeletedAt: genre.DeletedAt.Time,
+		}
+	}
+	return genresDTO, info, nil
+}
+
+func (s *server) castMembersInTrash(page crud.Page) (interface{}, crud.PageInfo, error) {
+	castMembers, info, err := s.svc.GetCastMembersInTrash(page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	castMembersDTO := make([]trashedCastMemberDTO, len(castMembers))
+	for i, castMember := range castMembers {
+		castMembersDTO[i] = trashedCastMemberDTO{
+			CastMemberDTO: crud.CastMemberDTO{
+				ID:   castMember.ID,
+				Name: castMember.Name,
+				Type: crud.CastMemberType(castMember.Type),
+			},
+			DeletedAt: castMember.DeletedAt.Time,
+		}
+	}
+	return castMembersDTO, info, nil
+}
+
+func (s *server) videosInTrash(page crud.Page) (interface{}, crud.PageInfo, error) {
+	videos, info, err := s.svc.GetVideosInTrash(page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	videosDTO := make([]trashedVideoDTO, len(videos))
+	for i, video := range videos {
+		dto, err := crud.MapVideoToDTO(*video)
+		if err != nil {
+			return nil, crud.PageInfo{}, err
+		}
+		dto.Assets = videoAssetsToDTO(*video)
+		videosDTO[i] = trashedVideoDTO{VideoDTO: dto, DeletedAt: video.DeletedAt.Time}
+	}
+	return videosDTO, info, nil
+}
@@ -0,0 +1,129 @@
+// +build integration
+
+package rest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_Trash_Categories(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeCategories)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeID := testdata.FakeCategories[0].ID
+	fakeUrl := func(path string) string {
+		return fmt.Sprintf("http://%s/%s", cfg.AddressServer, path)
+	}
+	// the steps run in order, each one on the trash left by the previous ones
+	steps := []struct {
+		name      string
+		method    string
+		url       string
+		status    int
+		inTrash   []string
+		checkList bool
+	}{
+		{
+			name:   "When purge is not a boolean",
+			method: http.MethodDelete,
+			url:    fakeUrl("categories/" + fakeID + "?purge=maybe"),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "When the category is removed",
+			method: http.MethodDelete,
+			url:    fakeUrl("categories/" + fakeID),
+			status: http.StatusOK,
+		},
+		{
+			name:      "When the trash is listed",
+			method:    http.MethodGet,
+			url:       fakeUrl("trash/categories"),
+			status:    http.StatusOK,
+			inTrash:   []string{fakeID},
+			checkList: true,
+		},
+		{
+			name:   "When the trash is asked by a cursor",
+			method: http.MethodGet,
+			url:    fakeUrl("trash/categories?cursor=fake"),
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "When the removed category is fetched",
+			method: http.MethodGet,
+			url:    fakeUrl("categories/" + fakeID),
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "When the category is restored",
+			method: http.MethodPost,
+			url:    fakeUrl("categories/" + fakeID + "/restore"),
+			status: http.StatusOK,
+		},
+		{
+			name:      "When the trash is empty",
+			method:    http.MethodGet,
+			url:       fakeUrl("trash/categories"),
+			status:    http.StatusOK,
+			checkList: true,
+		},
+		{
+			name:   "When the category is purged",
+			method: http.MethodDelete,
+			url:    fakeUrl("categories/" + fakeID + "?purge=true"),
+			status: http.StatusOK,
+		},
+		{
+			name:   "When the purged category is restored",
+			method: http.MethodPost,
+			url:    fakeUrl("categories/" + fakeID + "/restore"),
+			status: http.StatusNotFound,
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			req, err := http.NewRequest(step.method, step.url, nil)
+			if err != nil {
+				t.Fatalf("test: new request: %v", err)
+			}
+			req.Header.Set("If-Match", "*")
+			got, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			defer got.Body.Close()
+			if got.StatusCode != step.status {
+				t.Fatalf("statusCode: %v, want: %v", got.StatusCode, step.status)
+			}
+			if !step.checkList {
+				return
+			}
+			var body struct {
+				Data []struct {
+					ID        string `json:"id"`
+					DeletedAt string `json:"deleted_at"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(got.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			ids := make([]string, len(body.Data))
+			for i, item := range body.Data {
+				ids[i] = item.ID
+				assert.NotEmpty(t, item.DeletedAt, "the items of the trash should have their removal time")
+			}
+			assert.ElementsMatch(t, step.inTrash, ids, "they should have the same categories in the trash")
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package rest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
+)
+
+const (
+	TusVersion    = "1.0.0"
+	TusExtensions = "creation,expiration,termination"
+	TusMaxSize    = 50 << 30
+	// TusVideoMetadata names the id of the video an upload is attached to
+	TusVideoMetadata = "video_id"
+	// TusKindMetadata names the kind of asset an upload becomes, the main video when it is omitted
+	TusKindMetadata   = "kind"
+	offsetContentType = "application/offset+octet-stream"
+)
+
+func (s *server) handleUploadOptions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Tus-Resumable", TusVersion)
+		w.Header().Set("Tus-Version", TusVersion)
+		w.Header().Set("Tus-Extension", TusExtensions)
+		w.Header().Set("Tus-Max-Size", strconv.FormatInt(TusMaxSize, 10))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *server) handleUploadCreate() http.HandlerFunc {
+	return s.tusResumable(func(w http.ResponseWriter, r *http.Request) {
+		length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+		if err != nil || length < 0 {
+			s.errBadRequest(w, r, fmt.Errorf("'Upload-Length' %w", logger.ErrIsNotValidated))
+			return
+		}
+		if length > TusMaxSize {
+			s.errRequestEntityTooLarge(w, r, fmt.Errorf("'Upload-Length' %d exceeds %d", length, TusMaxSize))
+			return
+		}
+		metadata, err := parseUploadMetadata(r.Header.Get("Upload-Metadata"))
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		videoID := metadata[TusVideoMetadata]
+		if strings.TrimSpace(videoID) == "" {
+			s.errBadRequest(w, r, fmt.Errorf("'%s' metadata %w", TusVideoMetadata, logger.ErrIsRequired))
+			return
+		}
+		kind := uploadAssetKind(metadata)
+		if err := kind.Validate(); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		if length > s.assets.MaxSize(kind) {
+			s.errRequestEntityTooLarge(w, r, fmt.Errorf("'Upload-Length' %d exceeds the %s size", length, kind))
+			return
+		}
+		if _, err := s.svc.FetchVideo(videoID); err != nil {
+			if errors.Is(err, logger.ErrNotFound) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		info, err := s.uploads.Create(length, metadata)
+		if err != nil {
+			s.errInternalServer(w, r, err)
+			return
+		}
+		w.Header().Set("Location", path.Join(r.URL.Path, info.ID))
+		w.Header().Set("Upload-Expires", info.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+func (s *server) handleUploadHead() http.HandlerFunc {
+	return s.tusResumable(func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		info, err := s.uploads.GetInfo(params.ByName("id"))
+		if err != nil {
+			s.errUpload(w, r, err)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Upload-Offset", strconv.FormatInt(info.Offset, 10))
+		w.Header().Set("Upload-Length", strconv.FormatInt(info.Length, 10))
+		w.Header().Set("Upload-Metadata", formatUploadMetadata(info.Metadata))
+		w.Header().Set("Upload-Expires", info.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *server) handleUploadPatch() http.HandlerFunc {
+	return s.tusResumable(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != offsetContentType {
+			s.errUnsupportedMediaType(w, r, fmt.Errorf("'Content-Type' should be %s", offsetContentType))
+			return
+		}
+		offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+		if err != nil || offset < 0 {
+			s.errBadRequest(w, r, fmt.Errorf("'Upload-Offset' %w", logger.ErrIsNotValidated))
+			return
+		}
+		params := httprouter.ParamsFromContext(r.Context())
+		id := params.ByName("id")
+		info, err := s.uploads.WriteChunk(id, offset, r.Body)
+		if err != nil {
+			s.errUpload(w, r, err)
+			return
+		}
+		if info.IsComplete() {
+			if err := s.attachUpload(r, info); err != nil {
+				var assetErr *crud.AssetError
+				if errors.As(err, &assetErr) {
+					s.errInvalidAsset(w, r, assetErr)
+					return
+				}
+				if errors.Is(err, logger.ErrNotFound) {
+					s.errNotFound(w, r, err)
+					return
+				}
+				if errors.Is(err, logger.ErrIsNotValidated) {
+					s.errUnprocessableEntity(w, r, err)
+					return
+				}
+				s.errInternalServer(w, r, err)
+				return
+			}
+		}
+		w.Header().Set("Upload-Offset", strconv.FormatInt(info.Offset, 10))
+		w.Header().Set("Upload-Expires", info.ExpiresAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *server) handleUploadDelete() http.HandlerFunc {
+	return s.tusResumable(func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		if err := s.uploads.Remove(params.ByName("id")); err != nil {
+			s.errUpload(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// attachUpload stores a completed upload as the asset of the video identified in its metadata on behalf of the
+// actor of r, the request that completed it, and discards it. An upload refused by the spec of its kind is
+// discarded as well since resuming it cannot fix it.
+func (s *server) attachUpload(r *http.Request, info uploads.Info) error {
+	f, err := s.uploads.Open(info.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.svcAs(r).AttachVideoAsset(info.Metadata[TusVideoMetadata], uploadAssetKind(info.Metadata), f); err != nil {
+		_ = f.Close()
+		if errors.Is(err, logger.ErrIsNotValidated) {
+			_ = s.uploads.Remove(info.ID)
+		}
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return s.uploads.Remove(info.ID)
+}
+
+func uploadAssetKind(metadata map[string]string) crud.AssetKind {
+	if kind := metadata[TusKindMetadata]; kind != "" {
+		return crud.AssetKind(kind)
+	}
+	return crud.VideoAsset
+}
+
+// tusResumable rejects requests made with a tus version other than the supported one
+func (s *server) tusResumable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Tus-Resumable", TusVersion)
+		if r.Header.Get("Tus-Resumable") != TusVersion {
+			w.Header().Set("Tus-Version", TusVersion)
+			s.errPreconditionFailed(w, r, fmt.Errorf("'Tus-Resumable' should be %s", TusVersion))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *server) errUpload(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, logger.ErrNotFound):
+		s.errNotFound(w, r, err)
+	case errors.Is(err, uploads.ErrExpired):
+		s.errGone(w, r, err)
+	case errors.Is(err, uploads.ErrOffsetMismatch):
+		s.errStatusConflict(w, r, err)
+	case errors.Is(err, uploads.ErrExceedsLength):
+		s.errRequestEntityTooLarge(w, r, err)
+	default:
+		s.errInternalServer(w, r, err)
+	}
+}
+
+// parseUploadMetadata decodes the comma separated "key base64value" pairs of the Upload-Metadata header
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.Fields(pair)
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("'Upload-Metadata' %w", logger.ErrIsNotValidated)
+		}
+		value := ""
+		if len(parts) == 2 {
+			bs, err := base64.StdEncoding.DecodeString(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("'Upload-Metadata' %w", logger.ErrIsNotValidated)
+			}
+			value = string(bs)
+		}
+		metadata[parts[0]] = value
+	}
+	return metadata, nil
+}
+
+func formatUploadMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for k, v := range metadata {
+		pairs = append(pairs, fmt.Sprintf("%s %s", k, base64.StdEncoding.EncodeToString([]byte(v))))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,177 @@
+// +build integration
+
+package rest_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/selmison/code-micro-videos/pkg/api/rest"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_RestApi_Upload_VideoFile(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeVideoID := testdata.FakeVideos[0].ID
+	fakeData := testdata.FakeMP4(256)
+	fakeUrl := fmt.Sprintf("http://%s/%s", cfg.AddressServer, "uploads")
+	tusRequest := func(method, url string, headers map[string]string, body []byte) (*http.Response, error) {
+		req, err := http.NewRequest(method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Tus-Resumable", rest.TusVersion)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return http.DefaultClient.Do(req)
+	}
+	fakeMetadata := func(videoID string) string {
+		return fmt.Sprintf("%s %s", rest.TusVideoMetadata, base64.StdEncoding.EncodeToString([]byte(videoID)))
+	}
+	t.Run("When the video does not exist", func(t *testing.T) {
+		got, err := tusRequest(http.MethodPost, fakeUrl, map[string]string{
+			"Upload-Length":   strconv.Itoa(len(fakeData)),
+			"Upload-Metadata": fakeMetadata(uuid.New().String()),
+		}, nil)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusNotFound, got.StatusCode, "they should be equal")
+	})
+	t.Run("When the kind is unknown", func(t *testing.T) {
+		got, err := tusRequest(http.MethodPost, fakeUrl, map[string]string{
+			"Upload-Length": strconv.Itoa(len(fakeData)),
+			"Upload-Metadata": fmt.Sprintf(
+				"%s,%s %s",
+				fakeMetadata(fakeVideoID),
+				rest.TusKindMetadata,
+				base64.StdEncoding.EncodeToString([]byte("fakeKind")),
+			),
+		}, nil)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusBadRequest, got.StatusCode, "they should be equal")
+	})
+	t.Run("When the file is not valid for its kind", func(t *testing.T) {
+		got, err := tusRequest(http.MethodPost, fakeUrl, map[string]string{
+			"Upload-Length": strconv.Itoa(len(fakeData)),
+			"Upload-Metadata": fmt.Sprintf(
+				"%s,%s %s",
+				fakeMetadata(fakeVideoID),
+				rest.TusKindMetadata,
+				base64.StdEncoding.EncodeToString([]byte(crud.ThumbnailAsset)),
+			),
+		}, nil)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		if got.StatusCode != http.StatusCreated {
+			t.Errorf("statusCode: %v, want: %v", got.StatusCode, http.StatusCreated)
+			return
+		}
+		uploadUrl := fmt.Sprintf("http://%s%s", cfg.AddressServer, got.Header.Get("Location"))
+		got, err = tusRequest(http.MethodPatch, uploadUrl, map[string]string{
+			"Content-Type":  "application/offset+octet-stream",
+			"Upload-Offset": "0",
+		}, fakeData)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusUnprocessableEntity, got.StatusCode, "they should be equal")
+		assert.Equal(t, rest.ProblemMediaType, got.Header.Get("Content-Type"), "they should be equal")
+		var body rest.Problem
+		if err := json.NewDecoder(got.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		assert.Equal(t, rest.InvalidAssetCode, body.Code, "they should be equal")
+		assert.Equal(
+			t,
+			[]crud.FieldError{{
+				Field:  string(crud.ThumbnailAsset),
+				Rule:   "asset_format",
+				Detail: "content type 'video/mp4' is not allowed, want image/jpeg, image/png",
+			}},
+			body.Errors,
+			"they should be equal",
+		)
+	})
+	t.Run("When the upload is resumed after an interruption", func(t *testing.T) {
+		got, err := tusRequest(http.MethodPost, fakeUrl, map[string]string{
+			"Upload-Length":   strconv.Itoa(len(fakeData)),
+			"Upload-Metadata": fakeMetadata(fakeVideoID),
+		}, nil)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		if got.StatusCode != http.StatusCreated {
+			t.Errorf("statusCode: %v, want: %v", got.StatusCode, http.StatusCreated)
+			return
+		}
+		uploadUrl := fmt.Sprintf("http://%s%s", cfg.AddressServer, got.Header.Get("Location"))
+		half := len(fakeData) / 2
+		patchHeaders := func(offset int) map[string]string {
+			return map[string]string{
+				"Content-Type":  "application/offset+octet-stream",
+				"Upload-Offset": strconv.Itoa(offset),
+			}
+		}
+		got, err = tusRequest(http.MethodPatch, uploadUrl, patchHeaders(0), fakeData[:half])
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusNoContent, got.StatusCode, "they should be equal")
+		got, err = tusRequest(http.MethodPatch, uploadUrl, patchHeaders(0), fakeData)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusConflict, got.StatusCode, "they should be equal")
+		got, err = tusRequest(http.MethodHead, uploadUrl, nil, nil)
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, strconv.Itoa(half), got.Header.Get("Upload-Offset"), "they should be equal")
+		got, err = tusRequest(http.MethodPatch, uploadUrl, patchHeaders(half), fakeData[half:])
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		assert.Equal(t, http.StatusNoContent, got.StatusCode, "they should be equal")
+		got, err = http.Get(fmt.Sprintf("http://%s/%s/%s/%s", cfg.AddressServer, "videos", fakeVideoID, "file"))
+		if err != nil {
+			t.Errorf("error: %v", err)
+			return
+		}
+		data, err := ioutil.ReadAll(got.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+			return
+		}
+		assert.Equal(t, fakeData, data, "they should be equal")
+	})
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/crud/mock"
+	"github.com/selmison/code-micro-videos/pkg/storage/uploads"
+)
+
+func TestServer_handleUploadPatch_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	verifier, sign := newTestVerifier(t)
+	store := uploads.NewStore(afero.NewMemMapFs(), time.Hour)
+	fakeData := []byte("fake")
+	info, err := store.Create(int64(len(fakeData)), map[string]string{TusVideoMetadata: "fake"})
+	if err != nil {
+		t.Fatalf("test: create upload: %v", err)
+	}
+	mockSvc := mock.NewMockService(ctrl)
+	mockSvcAs := mock.NewMockService(ctrl)
+	gomock.InOrder(
+		mockSvc.EXPECT().As("fake").Return(mockSvcAs),
+		mockSvcAs.EXPECT().AttachVideoAsset("fake", crud.VideoAsset, gomock.Any()).Return(nil),
+	)
+	s := newServer(mockSvc, store, nil, verifier, nil, nil, deprecation{})
+	r := httptest.NewRequest(http.MethodPatch, "/v1/uploads/"+info.ID, bytes.NewReader(fakeData))
+	r.Header.Set("Authorization", "Bearer "+sign("editor"))
+	r.Header.Set("Tus-Resumable", TusVersion)
+	r.Header.Set("Content-Type", offsetContentType)
+	r.Header.Set("Upload-Offset", "0")
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("statusCode: %v, want: %v, body: %s", w.Code, http.StatusNoContent, w.Body)
+	}
+}
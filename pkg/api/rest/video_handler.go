@@ -3,8 +3,12 @@ package rest
 import (
 	"encoding/json"
 	"errors"
-	"math"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
 	"strings"
 
 	"github.com/gorilla/schema"
@@ -16,74 +20,105 @@ import (
 )
 
 const (
-	MaxMemory      = 10 << 20
-	VideoFileField = "video_file"
+	// MaxMemory bounds the size of the form fields of a multipart body, the asset files themselves are streamed
+	MaxMemory          = 10 << 20
+	ThumbnailFileField = "thumbnail_file"
+	BannerFileField    = "banner_file"
+	TrailerFileField   = "trailer_file"
+	VideoFileField     = "video_file"
+	sniffLen           = 512
+	unknownContentType = "application/octet-stream"
 )
 
+// AssetFields are the multipart fields that carry each kind of asset of a video
+var AssetFields = map[string]crud.AssetKind{
+	ThumbnailFileField: crud.ThumbnailAsset,
+	BannerFileField:    crud.BannerAsset,
+	TrailerFileField:   crud.TrailerAsset,
+	VideoFileField:     crud.VideoAsset,
+}
+
 var decoder = schema.NewDecoder()
 
 func (s *server) handleVideoCreate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if err := r.ParseMultipartForm(MaxMemory); err != nil {
-			s.errInternalServer(w, err)
-			return
-		}
 		videoDTO := &crud.VideoDTO{}
-		if err := decoder.Decode(videoDTO, r.PostForm); err != nil {
-			s.errInternalServer(w, err)
-		}
-		if err := r.Body.Close(); err != nil {
-			s.errInternalServer(w, err)
-		}
-		_, videoFileHandler, err := r.FormFile(VideoFileField)
+		files, err := s.multipartToStruct(w, r, videoDTO, assetFileFields()...)
 		if err != nil {
-			s.errInternalServer(w, err)
+			return
 		}
-		videoDTO.VideoFileHandler = videoFileHandler
-		if _, err := s.svc.AddVideo(*videoDTO); err != nil {
+		videoDTO.Files = assetFiles{files}
+		id, err := s.svcAs(r).AddVideo(*videoDTO)
+		if err != nil {
+			var assetErr *crud.AssetError
+			if errors.As(err, &assetErr) {
+				s.errInvalidAsset(w, r, assetErr)
+				return
+			}
 			if errors.Is(err, logger.ErrIsRequired) {
-				s.errBadRequest(w, err)
+				s.errBadRequest(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrIsTooLarge) {
+				s.errRequestEntityTooLarge(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrIsNotValidated) {
+				s.errUnprocessableEntity(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrAlreadyExists) {
-				s.errStatusConflict(w, err)
+				s.errStatusConflict(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrNotFound) {
-				s.errNotFound(w, err)
+				s.errNotFound(w, r, err)
 				return
 			}
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Header().Set("Location", path.Join(r.URL.Path, id.String()))
 		w.WriteHeader(http.StatusCreated)
 		if _, err := w.Write([]byte(http.StatusText(http.StatusCreated))); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
 		}
 	}
 }
 
 func (s *server) handleVideosGet() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		videos, err := s.svc.GetVideos(math.MaxInt8)
+		filter, err := filterFromQuery(r.URL.Query(), videoFilterParams)
 		if err != nil {
-			s.errInternalServer(w, err)
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(r.URL.Query())
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		videos, info, err := s.svc.GetVideos(filter, page)
+		if err != nil {
+			if errors.Is(err, logger.ErrInvalidedLimit) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
 		videosDTO := make([]*crud.VideoDTO, len(videos))
 		for i, video := range videos {
 			dto, err := crud.MapVideoToDTO(*video)
 			if err != nil {
-				s.errBadRequest(w, err)
+				s.errBadRequest(w, r, err)
+				return
 			}
+			dto.Assets = videoAssetsToDTO(*video)
 			videosDTO[i] = dto
 		}
-		if err := json.NewEncoder(w).Encode(videosDTO); err != nil {
-			s.errInternalServer(w, err)
-		}
+		s.writePage(w, r, videosDTO, info)
 	}
 }
 
@@ -92,54 +127,228 @@ func (s *server) handleVideoGet() http.HandlerFunc {
 		var video models.Video
 		var err error
 		params := httprouter.ParamsFromContext(r.Context())
-		if videoTitle := params.ByName("title"); strings.TrimSpace(videoTitle) != "" {
-			video, err = s.svc.FetchVideo(videoTitle)
+		if videoID := params.ByName("id"); strings.TrimSpace(videoID) != "" {
+			video, err = s.svc.FetchVideo(videoID)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
 				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+					s.errInternalServer(w, r, err)
 					return
 				}
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
 		videoDTO, err := crud.MapVideoToDTO(video)
 		if err != nil {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
+			return
 		}
+		videoDTO.Assets = videoAssetsToDTO(video)
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		setETag(w, video.Version)
+		w.WriteHeader(http.StatusOK)
 		if err := json.NewEncoder(w).Encode(videoDTO); err != nil {
-			s.errInternalServer(w, err)
+			s.errInternalServer(w, r, err)
+		}
+	}
+}
+
+// videoSearchParams are the query parameters of a search besides the page ones
+var videoSearchParams = []string{"q", "lang"}
+
+// videoMatchDTO is a video found by a search, with its relevance and the headlines of its matching fields
+type videoMatchDTO struct {
+	*crud.VideoDTO
+	Rank       float32           `json:"rank"`
+	Highlights videoHighlightDTO `json:"highlights"`
+}
+
+type videoHighlightDTO struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (s *server) handleVideosSearch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if err := checkParams(query, videoSearchParams); err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		page, err := pageFromQuery(query)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		search := crud.VideoSearch{Query: query.Get("q"), Language: query.Get("lang")}
+		matches, info, err := s.svc.SearchVideos(search, page)
+		if err != nil {
+			if errors.Is(err, logger.ErrIsRequired) ||
+				errors.Is(err, logger.ErrIsNotValidated) ||
+				errors.Is(err, logger.ErrInvalidedLimit) {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
 		}
+		matchesDTO := make([]videoMatchDTO, len(matches))
+		for i, match := range matches {
+			dto, err := crud.MapVideoToDTO(*match.Video)
+			if err != nil {
+				s.errBadRequest(w, r, err)
+				return
+			}
+			dto.Assets = videoAssetsToDTO(*match.Video)
+			matchesDTO[i] = videoMatchDTO{
+				VideoDTO:   dto,
+				Rank:       match.Rank,
+				Highlights: videoHighlightDTO{Title: match.Title, Description: match.Description},
+			}
+		}
+		s.writePage(w, r, matchesDTO, info)
 	}
 }
 
+// handleVideoAssetGet streams the asset of the kind in the path, the main video when the route has no kind
+func (s *server) handleVideoAssetGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := httprouter.ParamsFromContext(r.Context())
+		videoID := params.ByName("id")
+		if strings.TrimSpace(videoID) == "" {
+			s.errBadRequest(w, r, fmt.Errorf("'id' %w", logger.ErrIsRequired))
+			return
+		}
+		kind := crud.VideoAsset
+		if k := params.ByName("kind"); k != "" {
+			kind = crud.AssetKind(k)
+		}
+		videoFile, err := s.svc.OpenVideoAsset(videoID, kind)
+		if err != nil {
+			if errors.Is(err, logger.ErrNotFound) || errors.Is(err, logger.ErrIsNotValidated) {
+				s.errNotFound(w, r, err)
+				return
+			}
+			s.errInternalServer(w, r, err)
+			return
+		}
+		defer func() {
+			if err := videoFile.Content.Close(); err != nil {
+				s.logger.Warn(err)
+			}
+		}()
+		contentType := videoFile.ContentType
+		if contentType == "" || contentType == unknownContentType {
+			if contentType, err = sniffContentType(videoFile.Content); err != nil {
+				s.errInternalServer(w, r, err)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("ETag", fmt.Sprintf("%q", videoFile.Name))
+		http.ServeContent(w, r, videoFile.Name, videoFile.ModTime, videoFile.Content)
+	}
+}
+
+// assetFiles yields the files of a multipart body as the assets named by their fields
+type assetFiles struct {
+	files *multipartFiles
+}
+
+func (a assetFiles) NextAsset() (crud.AssetKind, io.Reader, error) {
+	field, file, err := a.files.NextFile()
+	if err != nil {
+		return "", nil, err
+	}
+	return AssetFields[field], file, nil
+}
+
+func assetFileFields() []string {
+	fields := make([]string, 0, len(AssetFields))
+	for field := range AssetFields {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// videoAssetsToDTO describes the assets of a video with the URL each one is downloaded from, under the current
+// version of the API
+func videoAssetsToDTO(video models.Video) []crud.VideoAssetDTO {
+	if video.R == nil {
+		return nil
+	}
+	return crud.MapVideoAssetsToDTO(video.R.VideoAssets, func(kind crud.AssetKind) string {
+		return fmt.Sprintf("/%s/videos/%s/assets/%s", rootVersion, url.PathEscape(video.ID), kind)
+	})
+}
+
+// isMultipart reports whether the request body is a multipart form
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
+
+// sniffContentType detects the media type from the first bytes of content and rewinds it
+func sniffContentType(content io.ReadSeeker) (string, error) {
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
 func (s *server) handleVideoUpdate() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		videoDTO := &crud.VideoDTO{}
-		if err := s.bodyToStruct(w, r, videoDTO); err != nil {
+		if isMultipart(r) {
+			files, err := s.multipartToStruct(w, r, videoDTO, assetFileFields()...)
+			if err != nil {
+				return
+			}
+			videoDTO.Files = assetFiles{files}
+		} else if err := s.bodyToStruct(w, r, videoDTO); err != nil {
 			return
 		}
 		params := httprouter.ParamsFromContext(r.Context())
-		videoTitle := params.ByName("title")
-		_, err = s.svc.UpdateVideo(videoTitle, *videoDTO)
+		videoID := params.ByName("id")
+		_, err = s.svcAs(r).UpdateVideo(videoID, version, *videoDTO)
 		if err != nil {
+			var assetErr *crud.AssetError
+			if errors.As(err, &assetErr) {
+				s.errInvalidAsset(w, r, assetErr)
+				return
+			}
 			if errors.Is(err, logger.ErrNotFound) {
-				s.errNotFound(w, err)
+				s.errNotFound(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrIsStale) {
+				s.errPreconditionFailed(w, r, err)
 				return
 			}
 			if errors.Is(err, logger.ErrInternalApplication) {
-				s.errInternalServer(w, err)
+				s.errInternalServer(w, r, err)
+				return
+			}
+			if errors.Is(err, logger.ErrIsTooLarge) {
+				s.errRequestEntityTooLarge(w, r, err)
 				return
 			}
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -147,22 +356,38 @@ func (s *server) handleVideoUpdate() http.HandlerFunc {
 
 func (s *server) handleVideoDelete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
+		svc := s.svcAs(r)
+		remove, err := removeOrPurge(r, svc.RemoveVideo, svc.PurgeVideo)
+		if err != nil {
+			s.errBadRequest(w, r, err)
+			return
+		}
+		version, err := ifMatch(r)
+		if err != nil {
+			s.errPrecondition(w, r, err)
+			return
+		}
 		params := httprouter.ParamsFromContext(r.Context())
-		if videoTitle := params.ByName("title"); strings.TrimSpace(videoTitle) != "" {
-			err = s.svc.RemoveVideo(videoTitle)
+		if videoID := params.ByName("id"); strings.TrimSpace(videoID) != "" {
+			err = remove(videoID, version)
 			if err != nil {
 				if errors.Is(err, logger.ErrNotFound) {
-					s.errNotFound(w, err)
+					s.errNotFound(w, r, err)
 					return
 				}
-				if errors.Is(err, logger.ErrInternalApplication) {
-					s.errInternalServer(w, err)
+				if errors.Is(err, logger.ErrIsStale) {
+					s.errPreconditionFailed(w, r, err)
 					return
 				}
+				if errors.Is(err, logger.ErrIsNotValidated) || errors.Is(err, logger.ErrIsRequired) {
+					s.errBadRequest(w, r, err)
+					return
+				}
+				s.errInternalServer(w, r, err)
+				return
 			}
 		} else {
-			s.errBadRequest(w, err)
+			s.errBadRequest(w, r, err)
 			return
 		}
 	}
@@ -5,6 +5,7 @@ package rest_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,16 +13,20 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"github.com/bxcodec/faker/v3"
 
+	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/api/rest"
 	"github.com/selmison/code-micro-videos/pkg/crud"
 	"github.com/selmison/code-micro-videos/pkg/storage/files/memory"
@@ -284,6 +289,9 @@ func Test_RestApi_Post_Videos(t *testing.T) {
 					t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.want.status)
 					return
 				}
+				if location := got.Header.Get("Location"); got.StatusCode == http.StatusCreated && !strings.HasPrefix(location, "/videos/") {
+					t.Errorf("location: %v, want one under /videos/", location)
+				}
 				bs, err := ioutil.ReadAll(got.Body)
 				if err != nil {
 					t.Errorf("read body: %v", err)
@@ -332,6 +340,28 @@ func Test_RestApi_Get_Videos(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "When a query parameter is unknown",
+			req: request{
+				url:         fakeUrl + "?director=" + faker.Name(),
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusBadRequest,
+			},
+			wantErr: false,
+		},
+		{
+			name: "When sort field is unknown",
+			req: request{
+				url:         fakeUrl + "?sort=-description",
+				contentType: "application/json; charset=UTF-8",
+			},
+			want: response{
+				status: http.StatusBadRequest,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -345,18 +375,101 @@ func Test_RestApi_Get_Videos(t *testing.T) {
 					t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.want.status)
 					return
 				}
+				if got.StatusCode != http.StatusOK {
+					return
+				}
 				data, err := ioutil.ReadAll(got.Body)
 				if err != nil {
 					t.Errorf("read body: %v", err)
 					return
 				}
+				var page struct {
+					Data  json.RawMessage `json:"data"`
+					Total int             `json:"total"`
+				}
+				if err := json.Unmarshal(data, &page); err != nil {
+					t.Fatalf("test: unmarshal body: %v", err)
+				}
 				assert.Equal(
 					t,
-					strings.TrimSpace(string(data)),
 					strings.TrimSpace(string(tt.want.body)),
+					strings.TrimSpace(string(page.Data)),
 					"they should be equal",
 				)
+				assert.Equal(t, testdata.FakeVideosLength, page.Total, "they should be equal")
+			}
+		})
+	}
+}
+
+func Test_RestApi_Search_Videos(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeUrl := fmt.Sprintf("http://%s/%s", cfg.AddressServer, "videos/search")
+	fakeVideo := testdata.FakeVideosDTO[0]
+	tests := []struct {
+		name      string
+		url       string
+		status    int
+		wantTitle string
+	}{
+		{
+			name:      "When the query matches a title",
+			url:       fakeUrl + "?q=" + url.QueryEscape(fakeVideo.Title),
+			status:    http.StatusOK,
+			wantTitle: fakeVideo.Title,
+		},
+		{
+			name:   "When the query is omitted",
+			url:    fakeUrl,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "When the language is unknown",
+			url:    fakeUrl + "?q=fake&lang=klingon",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "When a query parameter is unknown",
+			url:    fakeUrl + "?q=fake&sort=title",
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := http.Get(tt.url)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			defer got.Body.Close()
+			if got.StatusCode != tt.status {
+				t.Fatalf("statusCode: %v, want: %v", got.StatusCode, tt.status)
+			}
+			if got.StatusCode != http.StatusOK {
+				return
+			}
+			var page struct {
+				Data []struct {
+					Title      string  `json:"title"`
+					Rank       float32 `json:"rank"`
+					Highlights struct {
+						Title string `json:"title"`
+					} `json:"highlights"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(got.Body).Decode(&page); err != nil {
+				t.Fatalf("test: decode body: %v", err)
 			}
+			if len(page.Data) == 0 {
+				t.Fatalf("search found nothing, want: %s", tt.wantTitle)
+			}
+			assert.Equal(t, tt.wantTitle, page.Data[0].Title, "they should be equal")
+			assert.Contains(t, page.Data[0].Highlights.Title, "<mark>", "it should be highlighted")
+			assert.Greater(t, page.Data[0].Rank, float32(0), "it should be ranked")
 		})
 	}
 }
@@ -368,11 +481,11 @@ func Test_RestApi_Get_Video(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistTitle := testdata.FakeVideos[0].Title
-	fakeDoesNotExistTitle := "fakeDoesNotExistTitle"
+	fakeExistID := testdata.FakeVideos[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	fakeExistVideoDTO := testdata.FakeVideosDTO[0]
-	fakeUrl := func(title string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", title)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", id)
 	}
 	type request struct {
 		url         string
@@ -389,9 +502,9 @@ func Test_RestApi_Get_Video(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistTitle),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -401,9 +514,9 @@ func Test_RestApi_Get_Video(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When title exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistTitle),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -429,7 +542,7 @@ func Test_RestApi_Get_Video(t *testing.T) {
 					t.Errorf("read body: %v", err)
 					return
 				}
-				if tt.name == "When title doesn't exist" {
+				if tt.name == "When id doesn't exist" {
 					assert.Equal(
 						t,
 						strings.TrimSpace(string(data)),
@@ -438,7 +551,7 @@ func Test_RestApi_Get_Video(t *testing.T) {
 					)
 					return
 				}
-				if tt.name == "When title exists" {
+				if tt.name == "When id exists" {
 					videoBody := crud.VideoDTO{}
 					if err := json.Unmarshal(data, &videoBody); err != nil {
 						t.Errorf("unmarshal data: %v", err)
@@ -452,6 +565,182 @@ func Test_RestApi_Get_Video(t *testing.T) {
 	}
 }
 
+func Test_RestApi_Get_VideoFile(t *testing.T) {
+	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
+	if err != nil {
+		t.Errorf("test: failed to setup test case: %v\n", err)
+		return
+	}
+	defer teardownTestCase(t)
+	fakeVideo := testdata.FakeVideos[0]
+	fakeVideoWithoutFile := testdata.FakeVideos[1]
+	fakeData := testdata.FakeMP4(64)
+	fakeFileName := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	fakeVideoID, err := uuid.Parse(fakeVideo.ID)
+	if err != nil {
+		t.Errorf("test: parse video id: %v", err)
+		return
+	}
+	fakeAssetFileName := path.Join(string(crud.VideoAsset), fakeFileName)
+	if err := cfg.RepoFiles.SaveFileToVideo(fakeVideoID, fakeAssetFileName, bytes.NewReader(fakeData)); err != nil {
+		t.Errorf("test: save video file: %v", err)
+		return
+	}
+	fakeAsset := models.VideoAsset{
+		ID:          uuid.New().String(),
+		VideoID:     fakeVideo.ID,
+		Kind:        string(crud.VideoAsset),
+		FileName:    fakeAssetFileName,
+		ContentType: "video/mp4",
+		Size:        int64(len(fakeData)),
+	}
+	if err := fakeAsset.InsertG(context.Background(), boil.Infer()); err != nil {
+		t.Errorf("test: insert video asset: %v", err)
+		return
+	}
+	fakeETag := fmt.Sprintf("%q", fakeFileName)
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s/%s", cfg.AddressServer, "videos", id, "file")
+	}
+	fakeAssetUrl := func(id string, kind crud.AssetKind) string {
+		return fmt.Sprintf("http://%s/%s/%s/%s/%s", cfg.AddressServer, "videos", id, "assets", kind)
+	}
+	type request struct {
+		url     string
+		headers map[string]string
+	}
+	type response struct {
+		status       int
+		body         []byte
+		contentRange string
+	}
+	tests := []struct {
+		name    string
+		req     request
+		want    response
+		wantErr bool
+	}{
+		{
+			name: "When id doesn't exist",
+			req: request{
+				url: fakeUrl(uuid.New().String()),
+			},
+			want: response{
+				status: http.StatusNotFound,
+				body:   []byte("Not Found"),
+			},
+		},
+		{
+			name: "When video has no file",
+			req: request{
+				url: fakeUrl(fakeVideoWithoutFile.ID),
+			},
+			want: response{
+				status: http.StatusNotFound,
+				body:   []byte("Not Found"),
+			},
+		},
+		{
+			name: "When the whole file is requested",
+			req: request{
+				url: fakeUrl(fakeVideo.ID),
+			},
+			want: response{
+				status: http.StatusOK,
+				body:   fakeData,
+			},
+		},
+		{
+			name: "When the video asset is requested",
+			req: request{
+				url: fakeAssetUrl(fakeVideo.ID, crud.VideoAsset),
+			},
+			want: response{
+				status: http.StatusOK,
+				body:   fakeData,
+			},
+		},
+		{
+			name: "When video has no asset of the kind",
+			req: request{
+				url: fakeAssetUrl(fakeVideo.ID, crud.TrailerAsset),
+			},
+			want: response{
+				status: http.StatusNotFound,
+				body:   []byte("Not Found"),
+			},
+		},
+		{
+			name: "When the kind is unknown",
+			req: request{
+				url: fakeAssetUrl(fakeVideo.ID, "fakeKind"),
+			},
+			want: response{
+				status: http.StatusNotFound,
+				body:   []byte("Not Found"),
+			},
+		},
+		{
+			name: "When a range is requested",
+			req: request{
+				url:     fakeUrl(fakeVideo.ID),
+				headers: map[string]string{"Range": "bytes=2-9"},
+			},
+			want: response{
+				status:       http.StatusPartialContent,
+				body:         fakeData[2:10],
+				contentRange: fmt.Sprintf("bytes 2-9/%d", len(fakeData)),
+			},
+		},
+		{
+			name: "When the ETag matches If-None-Match",
+			req: request{
+				url:     fakeUrl(fakeVideo.ID),
+				headers: map[string]string{"If-None-Match": fakeETag},
+			},
+			want: response{
+				status: http.StatusNotModified,
+				body:   []byte{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.req.url, nil)
+			if err != nil {
+				t.Errorf("test: new request: %v", err)
+				return
+			}
+			for k, v := range tt.req.headers {
+				req.Header.Set(k, v)
+			}
+			got, err := http.DefaultClient.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				if got.StatusCode != tt.want.status {
+					t.Errorf("statusCode: %v, want: %v", got.StatusCode, tt.want.status)
+					return
+				}
+				data, err := ioutil.ReadAll(got.Body)
+				if err != nil {
+					t.Errorf("read body: %v", err)
+					return
+				}
+				assert.Equal(t, strings.TrimSpace(string(tt.want.body)), strings.TrimSpace(string(data)), "they should be equal")
+				if got.StatusCode == http.StatusOK || got.StatusCode == http.StatusPartialContent {
+					assert.Equal(t, fakeETag, got.Header.Get("ETag"), "they should be equal")
+				}
+				if tt.want.contentRange != "" {
+					assert.Equal(t, tt.want.contentRange, got.Header.Get("Content-Range"), "they should be equal")
+				}
+			}
+		})
+	}
+}
+
 func Test_RestApi_Delete_Video(t *testing.T) {
 	cfg, teardownTestCase, err := setupTestCase(t, testdata.FakeVideos)
 	if err != nil {
@@ -459,10 +748,10 @@ func Test_RestApi_Delete_Video(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistTitle := testdata.FakeVideos[0].Title
-	fakeDoesNotExistTitle := "doesNotExistTitle"
-	fakeUrl := func(title string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", title)
+	fakeExistID := testdata.FakeVideos[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", id)
 	}
 	type request struct {
 		url         string
@@ -479,9 +768,9 @@ func Test_RestApi_Delete_Video(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistTitle),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -491,9 +780,9 @@ func Test_RestApi_Delete_Video(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "When title exists",
+			name: "When id exists",
 			req: request{
-				url:         fakeUrl(fakeExistTitle),
+				url:         fakeUrl(fakeExistID),
 				contentType: "application/json; charset=UTF-8",
 			},
 			want: response{
@@ -509,6 +798,7 @@ func Test_RestApi_Delete_Video(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -546,10 +836,10 @@ func Test_RestApi_Update_Video(t *testing.T) {
 		fakeGenreIndex    = 0
 	)
 	fakeExistVideo := testdata.FakeVideos[fakeVideosIndex]
-	fakeExistTitle := fakeExistVideo.Title
-	fakeDoesNotExistTitle := "doesNotExistTitle"
-	fakeUrl := func(title string) string {
-		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", title)
+	fakeExistID := fakeExistVideo.ID
+	fakeDoesNotExistID := uuid.New().String()
+	fakeUrl := func(id string) string {
+		return fmt.Sprintf("http://%s/%s/%s", cfg.AddressServer, "videos", id)
 	}
 	fakeExistCategoryDTO := toJSON([]crud.CategoryDTO{
 		{
@@ -589,9 +879,9 @@ func Test_RestApi_Update_Video(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title doesn't exist",
+			name: "When id doesn't exist",
 			req: request{
-				url:         fakeUrl(fakeDoesNotExistTitle),
+				url:         fakeUrl(fakeDoesNotExistID),
 				contentType: "application/json; charset=UTF-8",
 				body: strings.NewReader(
 					fmt.Sprintf(
@@ -615,7 +905,7 @@ func Test_RestApi_Update_Video(t *testing.T) {
 		{
 			name: "When VideoDTO is with wrong categories and genres",
 			req: request{
-				fakeUrl(fakeExistTitle),
+				fakeUrl(fakeExistID),
 				"application/json; charset=UTF-8",
 				strings.NewReader(
 					fmt.Sprintf(
@@ -639,7 +929,7 @@ func Test_RestApi_Update_Video(t *testing.T) {
 		{
 			name: "When everything is right",
 			req: request{
-				fakeUrl(fakeExistTitle),
+				fakeUrl(fakeExistID),
 				"application/json; charset=UTF-8",
 				strings.NewReader(
 					fmt.Sprintf(
@@ -668,6 +958,7 @@ func Test_RestApi_Update_Video(t *testing.T) {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
 				return
 			}
+			req.Header.Set("If-Match", "*")
 			got, err := client.Do(req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error: %v, wantErr: %v", err, tt.wantErr)
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud/mock"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestServer_handleVideoGet_NotValidated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSvc := mock.NewMockService(ctrl)
+	video := models.Video{ID: "fake"}
+	video.R = video.R.NewStruct()
+	mockSvc.EXPECT().FetchVideo("fake").Return(video, nil)
+	s := newServer(mockSvc, nil, nil, nil, nil, nil, deprecation{})
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/videos/fake", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("statusCode: %v, want: %v, body: %s", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if got := w.Header().Get("ETag"); got != "" {
+		t.Errorf("ETag: %q, want none", got)
+	}
+}
+
+func TestServer_handleVideoDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"When the video is removed", nil, http.StatusOK},
+		{"When the removal is not validated", fmt.Errorf("fake %w", logger.ErrIsNotValidated), http.StatusBadRequest},
+		{"When the removal fails unexpectedly", errors.New("fake"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSvc := mock.NewMockService(ctrl)
+			mockSvc.EXPECT().As(AnonymousActor).Return(mockSvc)
+			mockSvc.EXPECT().RemoveVideo("fake", int64(1)).Return(tt.err)
+			s := newServer(mockSvc, nil, nil, nil, nil, nil, deprecation{})
+			r := httptest.NewRequest(http.MethodDelete, "/v1/videos/fake", nil)
+			r.Header.Set("If-Match", `"1"`)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("statusCode: %v, want: %v, body: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
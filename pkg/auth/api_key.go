@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// Scope is what an API key is allowed to do, the machine clients are given scopes instead of roles
+type Scope string
+
+const (
+	// CatalogRead reads the catalogue, its trash and the history of its items
+	CatalogRead Scope = "catalog:read"
+	// CatalogWrite adds the categories, genres and cast members, updates and restores them
+	CatalogWrite Scope = "catalog:write"
+	// VideosWrite adds the videos, updates and restores them and uploads their files
+	VideosWrite Scope = "videos:write"
+	// CatalogDelete removes the items of the catalogue
+	CatalogDelete Scope = "catalog:delete"
+)
+
+// Scopes are the scopes an API key may be given
+func Scopes() []Scope {
+	return []Scope{CatalogRead, CatalogWrite, VideosWrite, CatalogDelete}
+}
+
+func (s Scope) Validate() error {
+	for _, scope := range Scopes() {
+		if s == scope {
+			return nil
+		}
+	}
+	return fmt.Errorf("scope '%s' %w", s, logger.ErrIsNotValidated)
+}
+
+const (
+	// apiKeyPrefix starts every key, so a leaked one is told apart by the secret scanners
+	apiKeyPrefix = "cmv_"
+	// apiKeyVisible is the length of the start of a key that is kept to tell it apart in the lists
+	apiKeyVisible = len(apiKeyPrefix) + 8
+	apiKeyBytes   = 32
+	// lastUsedResolution is how often at most the last use of a key is written
+	lastUsedResolution = time.Minute
+	// apiKeySubjectPrefix prefixes the id of a key to make the subject of its requests
+	apiKeySubjectPrefix = "api_key:"
+)
+
+// CreateAPIKeyDTO asks for an API key, it never expires when ExpiresAt is not given
+type CreateAPIKeyDTO struct {
+	Name      string     `json:"name" validate:"not_blank"`
+	Scopes    []Scope    `json:"scopes" validate:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate lists all the fields of the DTO that are not valid at now
+func (dto CreateAPIKeyDTO) Validate(now time.Time) error {
+	var fields []crud.FieldError
+	if strings.TrimSpace(dto.Name) == "" {
+		fields = append(fields, crud.FieldError{Field: "name", Rule: "not_blank"})
+	}
+	if len(dto.Scopes) == 0 {
+		fields = append(fields, crud.FieldError{Field: "scopes", Rule: "required"})
+	}
+	seen := make(map[Scope]bool, len(dto.Scopes))
+	for i, scope := range dto.Scopes {
+		field := fmt.Sprintf("scopes[%d]", i)
+		if scope.Validate() != nil {
+			fields = append(fields, crud.FieldError{Field: field, Rule: "api_key_scope"})
+		} else if seen[scope] {
+			fields = append(fields, crud.FieldError{Field: field, Rule: "unique"})
+		}
+		seen[scope] = true
+	}
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(now) {
+		fields = append(fields, crud.FieldError{Field: "expires_at", Rule: "future"})
+	}
+	if len(fields) > 0 {
+		return &crud.ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// APIKey describes a key without its secret, which is only told once by NewAPIKey
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []Scope    `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey is a key just created with its secret, the client has to keep it since only its hash is stored
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyStore keeps the API keys by the hash of their secret, the lists give the last created first
+type APIKeyStore interface {
+	AddAPIKey(key APIKey, hash string) error
+	GetAPIKeys(page crud.Page) ([]APIKey, crud.PageInfo, error)
+	FetchAPIKeyByHash(hash string) (APIKey, error)
+	RevokeAPIKey(id string, at time.Time) error
+	TouchAPIKey(id string, at time.Time) error
+}
+
+// APIKeys creates the API keys of the machine clients, revokes them and authenticates their requests
+type APIKeys struct {
+	store APIKeyStore
+	now   func() time.Time
+}
+
+func NewAPIKeys(store APIKeyStore) *APIKeys {
+	return &APIKeys{store: store, now: time.Now}
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create makes a key of dto on behalf of actor and returns it with its secret
+func (k *APIKeys) Create(actor string, dto CreateAPIKeyDTO) (NewAPIKey, error) {
+	now := k.now().UTC()
+	if err := dto.Validate(now); err != nil {
+		return NewAPIKey{}, err
+	}
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return NewAPIKey{}, fmt.Errorf("could not generate an api key: %v", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	if dto.ExpiresAt != nil {
+		expiresAt := dto.ExpiresAt.UTC()
+		dto.ExpiresAt = &expiresAt
+	}
+	key := APIKey{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(dto.Name),
+		Prefix:    secret[:apiKeyVisible],
+		Scopes:    dto.Scopes,
+		CreatedBy: actor,
+		CreatedAt: now,
+		ExpiresAt: dto.ExpiresAt,
+	}
+	if err := k.store.AddAPIKey(key, hashAPIKey(secret)); err != nil {
+		return NewAPIKey{}, err
+	}
+	return NewAPIKey{APIKey: key, Key: secret}, nil
+}
+
+// List returns a page of the keys, the revoked and expired ones included
+func (k *APIKeys) List(page crud.Page) ([]APIKey, crud.PageInfo, error) {
+	if err := page.NormalizeNumbered("the list of the api keys"); err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	return k.store.GetAPIKeys(page)
+}
+
+// Revoke refuses the requests of the key of id from now on, a key already revoked stays revoked since then
+func (k *APIKeys) Revoke(id string) error {
+	u, err := uuid.Parse(strings.TrimSpace(id))
+	if err != nil {
+		return fmt.Errorf("api key %s: %w", id, logger.ErrNotFound)
+	}
+	return k.store.RevokeAPIKey(u.String(), k.now().UTC())
+}
+
+// Authenticate returns the principal of the key secret, it has the scopes of the key and no role
+func (k *APIKeys) Authenticate(secret string) (Principal, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return Principal{}, fmt.Errorf("api key %w", logger.ErrIsNotAuthenticated)
+	}
+	key, err := k.store.FetchAPIKeyByHash(hashAPIKey(secret))
+	if errors.Is(err, logger.ErrNotFound) {
+		return Principal{}, fmt.Errorf("api key %w", logger.ErrIsNotAuthenticated)
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	now := k.now().UTC()
+	if key.RevokedAt != nil {
+		return Principal{}, fmt.Errorf("api key %s %w: it was revoked", key.Prefix, logger.ErrIsNotAuthenticated)
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return Principal{}, fmt.Errorf("api key %s %w: it expired", key.Prefix, logger.ErrIsNotAuthenticated)
+	}
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := k.store.TouchAPIKey(key.ID, now); err != nil {
+			return Principal{}, err
+		}
+	}
+	return Principal{Subject: apiKeySubjectPrefix + key.ID, KeyID: key.ID, Scopes: key.Scopes}, nil
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// fakeAPIKeyStore keeps the API keys in memory by the hash of their secret
+type fakeAPIKeyStore struct {
+	keys    map[string]*APIKey
+	touches int
+}
+
+func newFakeAPIKeyStore() *fakeAPIKeyStore {
+	return &fakeAPIKeyStore{keys: map[string]*APIKey{}}
+}
+
+func (f *fakeAPIKeyStore) AddAPIKey(key APIKey, hash string) error {
+	f.keys[hash] = &key
+	return nil
+}
+
+func (f *fakeAPIKeyStore) GetAPIKeys(page crud.Page) ([]APIKey, crud.PageInfo, error) {
+	var keys []APIKey
+	for _, key := range f.keys {
+		keys = append(keys, *key)
+	}
+	return keys, crud.PageInfo{Total: int64(len(keys)), Number: page.Number, PerPage: page.PerPage}, nil
+}
+
+func (f *fakeAPIKeyStore) FetchAPIKeyByHash(hash string) (APIKey, error) {
+	key, ok := f.keys[hash]
+	if !ok {
+		return APIKey{}, logger.ErrNotFound
+	}
+	return *key, nil
+}
+
+func (f *fakeAPIKeyStore) byID(id string) *APIKey {
+	for _, key := range f.keys {
+		if key.ID == id {
+			return key
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPIKeyStore) RevokeAPIKey(id string, at time.Time) error {
+	key := f.byID(id)
+	if key == nil {
+		return logger.ErrNotFound
+	}
+	key.RevokedAt = &at
+	return nil
+}
+
+func (f *fakeAPIKeyStore) TouchAPIKey(id string, at time.Time) error {
+	f.touches++
+	f.byID(id).LastUsedAt = &at
+	return nil
+}
+
+func TestCreateAPIKeyDTO_Validate(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name       string
+		dto        CreateAPIKeyDTO
+		wantFields []crud.FieldError
+	}{
+		{
+			name: "When the dto is valid",
+			dto:  CreateAPIKeyDTO{Name: "fake", Scopes: []Scope{CatalogRead, VideosWrite}},
+		},
+		{
+			name: "When every field is not valid",
+			dto:  CreateAPIKeyDTO{Name: " ", Scopes: []Scope{CatalogRead, "fake", CatalogRead}, ExpiresAt: &past},
+			wantFields: []crud.FieldError{
+				{Field: "name", Rule: "not_blank"},
+				{Field: "scopes[1]", Rule: "api_key_scope"},
+				{Field: "scopes[2]", Rule: "unique"},
+				{Field: "expires_at", Rule: "future"},
+			},
+		},
+		{
+			name:       "When the scopes are not given",
+			dto:        CreateAPIKeyDTO{Name: "fake"},
+			wantFields: []crud.FieldError{{Field: "scopes", Rule: "required"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dto.Validate(now)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var vErr *crud.ValidationError
+			if !errors.As(err, &vErr) {
+				t.Fatalf("Validate() error = %v, want a validation error", err)
+			}
+			if !reflect.DeepEqual(vErr.Fields, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", vErr.Fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestAPIKeys_Authenticate(t *testing.T) {
+	now := time.Now().UTC()
+	store := newFakeAPIKeyStore()
+	k := &APIKeys{store: store, now: func() time.Time { return now }}
+	expiresAt := now.Add(time.Hour)
+	key, err := k.Create("fake", CreateAPIKeyDTO{Name: "fake", Scopes: []Scope{VideosWrite}, ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("test: create api key: %v", err)
+	}
+	if !strings.HasPrefix(key.Key, apiKeyPrefix) || key.Prefix != key.Key[:apiKeyVisible] {
+		t.Fatalf("test: key %s, prefix %s", key.Key, key.Prefix)
+	}
+	if _, ok := store.keys[key.Key]; ok {
+		t.Fatal("test: the secret of the key is stored")
+	}
+	want := Principal{Subject: apiKeySubjectPrefix + key.ID, KeyID: key.ID, Scopes: []Scope{VideosWrite}}
+
+	t.Run("When the key is used", func(t *testing.T) {
+		got, err := k.Authenticate(key.Key)
+		if err != nil {
+			t.Fatalf("Authenticate() error = %v, want nil", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Authenticate() got = %v, want %v", got, want)
+		}
+		if !got.Allows(Admin, VideosWrite) || got.Allows(Viewer, CatalogRead) || got.Allows(Viewer, "") {
+			t.Errorf("Allows() of %v is not the one of its scopes", got)
+		}
+	})
+	t.Run("When the key is used again before the resolution of its last use", func(t *testing.T) {
+		touches := store.touches
+		now = now.Add(lastUsedResolution / 2)
+		if _, err := k.Authenticate(key.Key); err != nil {
+			t.Fatalf("Authenticate() error = %v, want nil", err)
+		}
+		if store.touches != touches {
+			t.Errorf("touches: %d, want: %d", store.touches, touches)
+		}
+		now = now.Add(lastUsedResolution)
+		if _, err := k.Authenticate(key.Key); err != nil {
+			t.Fatalf("Authenticate() error = %v, want nil", err)
+		}
+		if store.touches != touches+1 {
+			t.Errorf("touches: %d, want: %d", store.touches, touches+1)
+		}
+	})
+	t.Run("When the key is not known", func(t *testing.T) {
+		for _, secret := range []string{"fake", apiKeyPrefix + "fake"} {
+			if _, err := k.Authenticate(secret); !errors.Is(err, logger.ErrIsNotAuthenticated) {
+				t.Errorf("Authenticate(%s) error = %v, want %v", secret, err, logger.ErrIsNotAuthenticated)
+			}
+		}
+	})
+	t.Run("When the key expired", func(t *testing.T) {
+		defer func(at time.Time) { now = at }(now)
+		now = expiresAt
+		if _, err := k.Authenticate(key.Key); !errors.Is(err, logger.ErrIsNotAuthenticated) {
+			t.Errorf("Authenticate() error = %v, want %v", err, logger.ErrIsNotAuthenticated)
+		}
+	})
+	t.Run("When the key is revoked", func(t *testing.T) {
+		if err := k.Revoke(key.ID); err != nil {
+			t.Fatalf("Revoke() error = %v, want nil", err)
+		}
+		if _, err := k.Authenticate(key.Key); !errors.Is(err, logger.ErrIsNotAuthenticated) {
+			t.Errorf("Authenticate() error = %v, want %v", err, logger.ErrIsNotAuthenticated)
+		}
+	})
+}
+
+func TestAPIKeys_Revoke(t *testing.T) {
+	k := NewAPIKeys(newFakeAPIKeyStore())
+	if err := k.Revoke("fake"); !errors.Is(err, logger.ErrNotFound) {
+		t.Errorf("Revoke() error = %v, want %v", err, logger.ErrNotFound)
+	}
+}
+
+func TestAPIKeys_List(t *testing.T) {
+	k := NewAPIKeys(newFakeAPIKeyStore())
+	if _, _, err := k.List(crud.Page{Cursor: &crud.Cursor{}}); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Errorf("List() error = %v, want %v", err, logger.ErrIsNotValidated)
+	}
+	if _, info, err := k.List(crud.Page{}); err != nil || info.Number != 1 {
+		t.Errorf("List() info = %v, error = %v, want the first page", info, err)
+	}
+}
@@ -0,0 +1,101 @@
+// Package auth authenticates the requests by the JSON Web Tokens or the API keys they bear and tells what their
+// subjects are allowed
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role is what a subject is allowed to do, each role is allowed what the roles below it are
+type Role int
+
+const (
+	// Viewer reads the catalogue
+	Viewer Role = iota + 1
+	// Editor adds the items of the catalogue, updates and restores them
+	Editor
+	// Admin removes the items of the catalogue
+	Admin
+)
+
+var roleNames = map[Role]string{
+	Viewer: "viewer",
+	Editor: "editor",
+	Admin:  "admin",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("Role(%d)", int(r))
+}
+
+// Valid reports whether r is one of the roles
+func (r Role) Valid() bool {
+	_, ok := roleNames[r]
+	return ok
+}
+
+// ParseRole returns the role of name, false when there is none
+func ParseRole(name string) (Role, bool) {
+	for role, roleName := range roleNames {
+		if roleName == name {
+			return role, true
+		}
+	}
+	return 0, false
+}
+
+// Principal is the subject of a verified token with the roles its claims give, or the subject of an API key
+// with the scopes of the key
+type Principal struct {
+	Subject string
+	Roles   []Role
+	// KeyID is the id of the API key the principal was authenticated by, empty for a token
+	KeyID  string
+	Scopes []Scope
+}
+
+// Can reports whether p has role or a role above it
+func (p Principal) Can(role Role) bool {
+	for _, r := range p.Roles {
+		if r >= role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether p was given scope
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether p is allowed what needs role from a token or scope from an API key, an empty scope
+// is never allowed to an API key
+func (p Principal) Allows(role Role, scope Scope) bool {
+	if p.KeyID != "" {
+		return scope != "" && p.HasScope(scope)
+	}
+	return p.Can(role)
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx that carries p
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal ctx carries, false when the request was not authenticated
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+const (
+	// DefaultRolesClaim names the claim of the roles when the config names none
+	DefaultRolesClaim = "roles"
+	// leeway is how far the clocks of the issuer and the server may drift apart
+	leeway = time.Minute
+	// refreshInterval is how often at most a token signed by an unknown key reloads the key set of a URL, the
+	// issuer may have rotated its keys
+	refreshInterval = 5 * time.Minute
+	fetchTimeout    = 10 * time.Second
+	maxKeySetSize   = 1 << 20
+)
+
+// algorithms are the signature algorithms of the tokens, the others are refused
+var algorithms = map[string]bool{
+	string(jose.RS256): true,
+	string(jose.ES256): true,
+}
+
+// Config tells which tokens are verified
+type Config struct {
+	// JWKS is the file or the http(s) URL of the JSON Web Key Set of the public keys that sign the tokens
+	JWKS string
+	// Issuer and Audience, when they are given, have to match the iss and aud claims of the tokens
+	Issuer   string
+	Audience string
+	// RolesClaim names the claim of the roles, a list or a space separated string of their names. Its dots
+	// name the claims of nested objects, like realm_access.roles.
+	RolesClaim string
+	// Disabled serves every request without authenticating it. The server refuses to start without a JWKS
+	// unless it is set.
+	Disabled bool
+}
+
+// Verifier verifies the tokens signed by the keys of a JSON Web Key Set
+type Verifier struct {
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	keys   jose.JSONWebKeySet
+	loaded time.Time
+}
+
+// NewVerifier returns a verifier of the tokens of cfg, its key set is loaded at once so a wrong one is told early
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.JWKS == "" {
+		return nil, fmt.Errorf("jwks %w", logger.ErrIsRequired)
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = DefaultRolesClaim
+	}
+	v := &Verifier{cfg: cfg, client: &http.Client{Timeout: fetchTimeout}, now: time.Now}
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *Verifier) isURL() bool {
+	return strings.HasPrefix(v.cfg.JWKS, "http://") || strings.HasPrefix(v.cfg.JWKS, "https://")
+}
+
+// load reads the key set and keeps its public keys
+func (v *Verifier) load() error {
+	var (
+		b   []byte
+		err error
+	)
+	if v.isURL() {
+		b, err = v.fetch()
+	} else {
+		b, err = ioutil.ReadFile(v.cfg.JWKS)
+	}
+	if err != nil {
+		return fmt.Errorf("load jwks %s: %w", v.cfg.JWKS, err)
+	}
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &set); err != nil {
+		return fmt.Errorf("jwks %s %w: %v", v.cfg.JWKS, logger.ErrIsNotValidated, err)
+	}
+	var keys jose.JSONWebKeySet
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		keys.Keys = append(keys.Keys, key.Public())
+	}
+	if len(keys.Keys) == 0 {
+		return fmt.Errorf("signing keys of jwks %s %w", v.cfg.JWKS, logger.ErrNotFound)
+	}
+	v.keys, v.loaded = keys, v.now()
+	return nil
+}
+
+func (v *Verifier) fetch() ([]byte, error) {
+	resp, err := v.client.Get(v.cfg.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxKeySetSize))
+}
+
+// key returns the key named kid, the key set of a URL is reloaded for the keys the issuer rotated in
+func (v *Verifier) key(kid string) (jose.JSONWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if keys := v.keys.Key(kid); len(keys) > 0 {
+		return keys[0], nil
+	}
+	if v.isURL() && v.now().Sub(v.loaded) >= refreshInterval {
+		if err := v.load(); err != nil {
+			return jose.JSONWebKey{}, err
+		}
+		if keys := v.keys.Key(kid); len(keys) > 0 {
+			return keys[0], nil
+		}
+	}
+	return jose.JSONWebKey{}, fmt.Errorf("key %q %w", kid, logger.ErrNotFound)
+}
+
+// Verify checks the signature and the claims of token and returns its subject with its roles
+func (v *Verifier) Verify(token string) (Principal, error) {
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return Principal{}, fmt.Errorf("token %w: %v", logger.ErrIsNotAuthenticated, err)
+	}
+	if len(tok.Headers) != 1 {
+		return Principal{}, fmt.Errorf("token %w: it has %d signatures", logger.ErrIsNotAuthenticated, len(tok.Headers))
+	}
+	header := tok.Headers[0]
+	if !algorithms[header.Algorithm] {
+		return Principal{}, fmt.Errorf("token %w: algorithm %s", logger.ErrIsNotAuthenticated, header.Algorithm)
+	}
+	key, err := v.key(header.KeyID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("token %w: %v", logger.ErrIsNotAuthenticated, err)
+	}
+	if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+		return Principal{}, fmt.Errorf(
+			"token %w: algorithm %s of key %s", logger.ErrIsNotAuthenticated, key.Algorithm, header.KeyID,
+		)
+	}
+	var (
+		claims jwt.Claims
+		custom map[string]interface{}
+	)
+	if err := tok.Claims(key.Key, &claims, &custom); err != nil {
+		return Principal{}, fmt.Errorf("token %w: %v", logger.ErrIsNotAuthenticated, err)
+	}
+	if claims.Expiry == nil {
+		return Principal{}, fmt.Errorf("token %w: it does not expire", logger.ErrIsNotAuthenticated)
+	}
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("token %w: it has no subject", logger.ErrIsNotAuthenticated)
+	}
+	expected := jwt.Expected{Issuer: v.cfg.Issuer, Time: v.now()}
+	if v.cfg.Audience != "" {
+		expected.Audience = jwt.Audience{v.cfg.Audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, leeway); err != nil {
+		return Principal{}, fmt.Errorf("token %w: %v", logger.ErrIsNotAuthenticated, err)
+	}
+	return Principal{Subject: claims.Subject, Roles: rolesOf(custom, v.cfg.RolesClaim)}, nil
+}
+
+// rolesOf returns the roles named by the claim of claims named by path, the names of no role are ignored
+func rolesOf(claims map[string]interface{}, path string) []Role {
+	var claim interface{} = claims
+	for _, name := range strings.Split(path, ".") {
+		object, ok := claim.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		claim = object[name]
+	}
+	var names []string
+	switch claim := claim.(type) {
+	case string:
+		names = strings.Fields(claim)
+	case []interface{}:
+		for _, name := range claim {
+			if name, ok := name.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	var roles []Role
+	for _, name := range names {
+		if role, ok := ParseRole(name); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
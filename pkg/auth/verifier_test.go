@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+type testKey struct {
+	kid string
+	alg jose.SignatureAlgorithm
+	key interface{}
+}
+
+func newTestKeys(t *testing.T) (rsaKey, ecKey testKey) {
+	t.Helper()
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("test: generate rsa key: %v", err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("test: generate ecdsa key: %v", err)
+	}
+	return testKey{"rsa", jose.RS256, rsaPriv}, testKey{"ec", jose.ES256, ecPriv}
+}
+
+func (k testKey) public() jose.JSONWebKey {
+	key := jose.JSONWebKey{Key: k.key, KeyID: k.kid, Algorithm: string(k.alg), Use: "sig"}
+	return key.Public()
+}
+
+func (k testKey) sign(t *testing.T, claims ...interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: k.alg, Key: jose.JSONWebKey{Key: k.key, KeyID: k.kid}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("test: new signer: %v", err)
+	}
+	builder := jwt.Signed(signer)
+	for _, c := range claims {
+		builder = builder.Claims(c)
+	}
+	token, err := builder.CompactSerialize()
+	if err != nil {
+		t.Fatalf("test: sign token: %v", err)
+	}
+	return token
+}
+
+func writeKeySet(t *testing.T, path string, keys ...jose.JSONWebKey) {
+	t.Helper()
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	if err != nil {
+		t.Fatalf("test: marshal jwks: %v", err)
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		t.Fatalf("test: write jwks: %v", err)
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "auth-verifier")
+	if err != nil {
+		t.Fatalf("test: create temp dir: %v", err)
+	}
+	defer os.RemoveAll(rootDir)
+	rsaKey, ecKey := newTestKeys(t)
+	otherKey, _ := newTestKeys(t)
+	jwks := filepath.Join(rootDir, "jwks.json")
+	writeKeySet(t, jwks, rsaKey.public(), ecKey.public())
+	now := time.Now()
+	fakeClaims := func() jwt.Claims {
+		return jwt.Claims{
+			Subject:  "fake",
+			Issuer:   "https://issuer.test",
+			Audience: jwt.Audience{"code-micro-videos"},
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt: jwt.NewNumericDate(now),
+		}
+	}
+	hsSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("fake-secret")}, nil)
+	if err != nil {
+		t.Fatalf("test: new signer: %v", err)
+	}
+	hsToken, err := jwt.Signed(hsSigner).Claims(fakeClaims()).CompactSerialize()
+	if err != nil {
+		t.Fatalf("test: sign token: %v", err)
+	}
+	tests := []struct {
+		name       string
+		rolesClaim string
+		token      func() string
+		want       Principal
+		wantErr    error
+	}{
+		{
+			name: "When the token is signed by RS256 and has a list of roles",
+			token: func() string {
+				return rsaKey.sign(t, fakeClaims(), map[string]interface{}{"roles": []string{"editor", "fake"}})
+			},
+			want: Principal{Subject: "fake", Roles: []Role{Editor}},
+		},
+		{
+			name:       "When the token is signed by ES256 and has nested roles",
+			rolesClaim: "realm_access.roles",
+			token: func() string {
+				return ecKey.sign(t, fakeClaims(), map[string]interface{}{
+					"realm_access": map[string]interface{}{"roles": []string{"viewer", "admin"}},
+				})
+			},
+			want: Principal{Subject: "fake", Roles: []Role{Viewer, Admin}},
+		},
+		{
+			name:       "When the roles are a space separated string",
+			rolesClaim: "scope",
+			token: func() string {
+				return rsaKey.sign(t, fakeClaims(), map[string]interface{}{"scope": "viewer editor"})
+			},
+			want: Principal{Subject: "fake", Roles: []Role{Viewer, Editor}},
+		},
+		{
+			name:  "When the token has no roles",
+			token: func() string { return rsaKey.sign(t, fakeClaims()) },
+			want:  Principal{Subject: "fake"},
+		},
+		{
+			name: "When the token has expired",
+			token: func() string {
+				claims := fakeClaims()
+				claims.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+				return rsaKey.sign(t, claims)
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token does not expire",
+			token: func() string {
+				claims := fakeClaims()
+				claims.Expiry = nil
+				return rsaKey.sign(t, claims)
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token has no subject",
+			token: func() string {
+				claims := fakeClaims()
+				claims.Subject = ""
+				return rsaKey.sign(t, claims)
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token has another issuer",
+			token: func() string {
+				claims := fakeClaims()
+				claims.Issuer = "https://fake.test"
+				return rsaKey.sign(t, claims)
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token has another audience",
+			token: func() string {
+				claims := fakeClaims()
+				claims.Audience = jwt.Audience{"fake"}
+				return rsaKey.sign(t, claims)
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token is signed by another key with a known id",
+			token: func() string {
+				return otherKey.sign(t, fakeClaims())
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name: "When the token is signed by an unknown key",
+			token: func() string {
+				return testKey{"fake", otherKey.alg, otherKey.key}.sign(t, fakeClaims())
+			},
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name:    "When the token is signed by HS256",
+			token:   func() string { return hsToken },
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+		{
+			name:    "When the token is malformed",
+			token:   func() string { return "fake" },
+			wantErr: logger.ErrIsNotAuthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewVerifier(Config{
+				JWKS:       jwks,
+				Issuer:     "https://issuer.test",
+				Audience:   "code-micro-videos",
+				RolesClaim: tt.rolesClaim,
+			})
+			if err != nil {
+				t.Fatalf("NewVerifier() error: %v", err)
+			}
+			got, err := v.Verify(tt.token())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Verify() got: %+v, want: %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifier_key(t *testing.T) {
+	rsaKey, ecKey := newTestKeys(t)
+	keys := []jose.JSONWebKey{rsaKey.public()}
+	fetches := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if err := json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: keys}); err != nil {
+			t.Errorf("test: encode jwks: %v", err)
+		}
+	}))
+	defer ts.Close()
+	v, err := NewVerifier(Config{JWKS: ts.URL})
+	if err != nil {
+		t.Fatalf("NewVerifier() error: %v", err)
+	}
+	now := time.Now()
+	v.now = func() time.Time { return now }
+	claims := jwt.Claims{Subject: "fake", Expiry: jwt.NewNumericDate(now.Add(time.Hour))}
+	keys = append(keys, ecKey.public())
+	t.Run("When the key is rotated in before the refresh interval", func(t *testing.T) {
+		if _, err := v.Verify(ecKey.sign(t, claims)); !errors.Is(err, logger.ErrIsNotAuthenticated) {
+			t.Errorf("Verify() error: %v, wantErr: %v", err, logger.ErrIsNotAuthenticated)
+		}
+		if fetches != 1 {
+			t.Errorf("fetches: %d, want: 1", fetches)
+		}
+	})
+	t.Run("When the key is rotated in after the refresh interval", func(t *testing.T) {
+		now = now.Add(refreshInterval)
+		if _, err := v.Verify(ecKey.sign(t, claims)); err != nil {
+			t.Errorf("Verify() error: %v", err)
+		}
+		if fetches != 2 {
+			t.Errorf("fetches: %d, want: 2", fetches)
+		}
+	})
+}
+
+func TestNewVerifier(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "auth-verifier")
+	if err != nil {
+		t.Fatalf("test: create temp dir: %v", err)
+	}
+	defer os.RemoveAll(rootDir)
+	empty := filepath.Join(rootDir, "empty.json")
+	writeKeySet(t, empty)
+	tests := []struct {
+		name    string
+		jwks    string
+		wantErr error
+	}{
+		{name: "When no key set is given", wantErr: logger.ErrIsRequired},
+		{name: "When the key set has no key", jwks: empty, wantErr: logger.ErrNotFound},
+		{name: "When the key set does not exist", jwks: filepath.Join(rootDir, "fake.json"), wantErr: os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewVerifier(Config{JWKS: tt.jwks}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewVerifier() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
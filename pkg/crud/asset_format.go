@@ -0,0 +1,197 @@
+package crud
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	mp4ContentType      = "video/mp4"
+	webmContentType     = "video/webm"
+	matroskaContentType = "video/x-matroska"
+	jpegContentType     = "image/jpeg"
+	pngContentType      = "image/png"
+
+	ebmlDocTypeID = 0x4282
+	ebmlSegmentID = 0x18538067
+)
+
+var (
+	ebmlMagic = []byte{0x1A, 0x45, 0xDF, 0xA3}
+	jpegMagic = []byte{0xFF, 0xD8, 0xFF}
+	pngMagic  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// sniffAsset identifies the format of an asset from its first bytes and lists what is wrong with its header.
+// Only the header present in head is parsed, a box or element that goes beyond it is not an error.
+func sniffAsset(head []byte) (string, []string) {
+	switch {
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		return mp4ContentType, checkMP4(head)
+	case bytes.HasPrefix(head, ebmlMagic):
+		return checkEBML(head)
+	case bytes.HasPrefix(head, jpegMagic):
+		return jpegContentType, checkJPEG(head)
+	case bytes.HasPrefix(head, pngMagic):
+		return pngContentType, checkPNG(head)
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream", nil
+	}
+	return contentType, nil
+}
+
+// checkMP4 walks the top level boxes of an ISO base media file, which must start with a well formed ftyp box
+func checkMP4(head []byte) []string {
+	for offset, first := 0, true; offset+8 <= len(head); first = false {
+		size := uint64(binary.BigEndian.Uint32(head[offset:]))
+		boxType := head[offset+4 : offset+8]
+		headerSize := uint64(8)
+		if !isBoxType(boxType) {
+			return []string{fmt.Sprintf("mp4: box type %q at offset %d is not valid", boxType, offset)}
+		}
+		if size == 1 {
+			if offset+16 > len(head) {
+				return nil
+			}
+			size = binary.BigEndian.Uint64(head[offset+8:])
+			headerSize = 16
+		}
+		if first {
+			if size < 16 || (size-headerSize-8)%4 != 0 {
+				return []string{fmt.Sprintf("mp4: ftyp box size %d is not valid", size)}
+			}
+		}
+		if size == 0 {
+			return nil
+		}
+		if size < headerSize {
+			return []string{fmt.Sprintf("mp4: box '%s' size %d is smaller than its header", boxType, size)}
+		}
+		if size > uint64(len(head)-offset) {
+			return nil
+		}
+		offset += int(size)
+	}
+	return nil
+}
+
+func isBoxType(boxType []byte) bool {
+	for _, c := range boxType {
+		if c < 0x20 || c > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEBML parses the EBML header of a Matroska file, its DocType tells WebM from Matroska
+func checkEBML(head []byte) (string, []string) {
+	offset := len(ebmlMagic)
+	size, n, ok := readVint(head[offset:], true)
+	if !ok {
+		return matroskaContentType, []string{"ebml: header size is not valid"}
+	}
+	offset += n
+	end := offset + int(size)
+	if size > uint64(len(head)-offset) {
+		return matroskaContentType, []string{"ebml: header is truncated"}
+	}
+	docType := ""
+	for offset < end {
+		id, n, ok := readVint(head[offset:end], false)
+		if !ok {
+			return matroskaContentType, []string{fmt.Sprintf("ebml: element id at offset %d is not valid", offset)}
+		}
+		offset += n
+		size, n, ok := readVint(head[offset:end], true)
+		if !ok || size > uint64(end-offset-n) {
+			return matroskaContentType, []string{fmt.Sprintf("ebml: element 0x%X size is not valid", id)}
+		}
+		offset += n
+		if id == ebmlDocTypeID {
+			docType = strings.TrimRight(string(head[offset:offset+int(size)]), "\x00")
+		}
+		offset += int(size)
+	}
+	var contentType string
+	var reasons []string
+	switch docType {
+	case "webm":
+		contentType = webmContentType
+	case "matroska":
+		contentType = matroskaContentType
+	case "":
+		return matroskaContentType, []string{"ebml: DocType is missing"}
+	default:
+		return matroskaContentType, []string{fmt.Sprintf("ebml: DocType '%s' is not webm or matroska", docType)}
+	}
+	if id, _, ok := readVint(head[end:], false); ok && id != ebmlSegmentID {
+		reasons = append(reasons, fmt.Sprintf("ebml: element 0x%X follows the header, want a Segment", id))
+	}
+	return contentType, reasons
+}
+
+// readVint reads an EBML variable length integer, sizes drop the length marker while ids keep it
+func readVint(b []byte, isSize bool) (uint64, int, bool) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0, false
+	}
+	length := 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > len(b) {
+		return 0, 0, false
+	}
+	value := uint64(b[0])
+	if isSize {
+		value &= uint64(0xFF >> uint(length))
+	}
+	for _, c := range b[1:length] {
+		value = value<<8 | uint64(c)
+	}
+	return value, length, true
+}
+
+// checkJPEG verifies that the start of image marker is followed by a well formed segment
+func checkJPEG(head []byte) []string {
+	if len(head) < 6 {
+		return []string{"jpeg: header is truncated"}
+	}
+	marker := head[3]
+	if marker == 0x00 || marker == 0xFF || marker == 0xD8 || marker == 0xD9 {
+		return []string{fmt.Sprintf("jpeg: marker 0x%X does not start a segment", marker)}
+	}
+	if length := binary.BigEndian.Uint16(head[4:6]); length < 2 {
+		return []string{fmt.Sprintf("jpeg: segment length %d is not valid", length)}
+	}
+	return nil
+}
+
+// checkPNG verifies that the signature is followed by an IHDR chunk describing a non empty image
+func checkPNG(head []byte) []string {
+	offset := len(pngMagic)
+	if len(head) < offset+8+13 {
+		return []string{"png: header is truncated"}
+	}
+	var reasons []string
+	if length := binary.BigEndian.Uint32(head[offset:]); length != 13 {
+		reasons = append(reasons, fmt.Sprintf("png: IHDR length %d is not 13", length))
+	}
+	if chunkType := string(head[offset+4 : offset+8]); chunkType != "IHDR" {
+		reasons = append(reasons, fmt.Sprintf("png: first chunk is '%s', want IHDR", chunkType))
+		return reasons
+	}
+	width := binary.BigEndian.Uint32(head[offset+8:])
+	height := binary.BigEndian.Uint32(head[offset+12:])
+	if width == 0 || height == 0 {
+		reasons = append(reasons, fmt.Sprintf("png: image size %dx%d is empty", width, height))
+	}
+	return reasons
+}
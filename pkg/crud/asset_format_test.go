@@ -0,0 +1,127 @@
+package crud_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_NewAssetReader_Formats(t *testing.T) {
+	const (
+		fakeWebM     = "\x1A\x45\xDF\xA3\x87\x42\x82\x84webm\x18\x53\x80\x67\xFF"
+		fakeMatroska = "\x1A\x45\xDF\xA3\x8B\x42\x82\x88matroska\x18\x53\x80\x67\xFF"
+		fakeFtyp     = "\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"
+	)
+	fakeImageData := testdata.FakePNG(16)
+	zeroWidthPNG := append([]byte{}, fakeImageData...)
+	copy(zeroWidthPNG[16:20], []byte{0, 0, 0, 0})
+	type args struct {
+		kind crud.AssetKind
+		data string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantContentType string
+		wantReasons     []string
+	}{
+		{
+			name:            "When video is webm",
+			args:            args{crud.VideoAsset, fakeWebM},
+			wantContentType: "video/webm",
+		},
+		{
+			name:            "When video is matroska",
+			args:            args{crud.TrailerAsset, fakeMatroska},
+			wantContentType: "video/x-matroska",
+		},
+		{
+			name:        "When ebml DocType is unknown",
+			args:        args{crud.VideoAsset, "\x1A\x45\xDF\xA3\x87\x42\x82\x84abcd\x18\x53\x80\x67\xFF"},
+			wantReasons: []string{"ebml: DocType 'abcd' is not webm or matroska"},
+		},
+		{
+			name:        "When ebml header is not followed by a segment",
+			args:        args{crud.VideoAsset, "\x1A\x45\xDF\xA3\x87\x42\x82\x84webm\x1F\x43\xB6\x75\xFF"},
+			wantReasons: []string{"ebml: element 0x1F43B675 follows the header, want a Segment"},
+		},
+		{
+			name:        "When ebml header is truncated",
+			args:        args{crud.VideoAsset, "\x1A\x45\xDF\xA3\x9F\x42\x82\x84webm"},
+			wantReasons: []string{"ebml: header is truncated"},
+		},
+		{
+			name:        "When mp4 ftyp box size is not valid",
+			args:        args{crud.VideoAsset, "\x00\x00\x00\x0Aftypmp42\x00\x00"},
+			wantReasons: []string{"mp4: ftyp box size 10 is not valid"},
+		},
+		{
+			name:        "When mp4 box type is not valid",
+			args:        args{crud.VideoAsset, fakeFtyp + "\x00\x00\x00\x10\x01\x02\x03\x04"},
+			wantReasons: []string{`mp4: box type "\x01\x02\x03\x04" at offset 24 is not valid`},
+		},
+		{
+			name:        "When mp4 box is smaller than its header",
+			args:        args{crud.VideoAsset, fakeFtyp + "\x00\x00\x00\x04mdat"},
+			wantReasons: []string{"mp4: box 'mdat' size 4 is smaller than its header"},
+		},
+		{
+			name:            "When image is jpeg",
+			args:            args{crud.ThumbnailAsset, "\xFF\xD8\xFF\xE0\x00\x10JFIF\x00\x01"},
+			wantContentType: "image/jpeg",
+		},
+		{
+			name:        "When jpeg marker does not start a segment",
+			args:        args{crud.ThumbnailAsset, "\xFF\xD8\xFF\xD9\x00\x10"},
+			wantReasons: []string{"jpeg: marker 0xD9 does not start a segment"},
+		},
+		{
+			name:        "When png is truncated",
+			args:        args{crud.BannerAsset, "\x89PNG\r\n\x1a\n\x00\x00"},
+			wantReasons: []string{"png: header is truncated"},
+		},
+		{
+			name:        "When png image is empty",
+			args:        args{crud.BannerAsset, string(zeroWidthPNG)},
+			wantReasons: []string{"png: image size 0x1 is empty"},
+		},
+		{
+			name: "When content type is not allowed and its header is not valid",
+			args: args{crud.BannerAsset, "\x00\x00\x00\x0Aftypmp42\x00\x00"},
+			wantReasons: []string{
+				"content type 'video/mp4' is not allowed, want image/jpeg, image/png",
+				"mp4: ftyp box size 10 is not valid",
+			},
+		},
+		{
+			name:        "When file is plain text",
+			args:        args{crud.VideoAsset, "fake video"},
+			wantReasons: []string{"content type 'text/plain' is not allowed, want video/mp4, video/webm, video/x-matroska"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crud.NewAssetReader(tt.args.kind, bytes.NewReader([]byte(tt.args.data)))
+			if tt.wantReasons == nil {
+				if err != nil {
+					t.Fatalf("NewAssetReader() error: %v", err)
+				}
+				if got.ContentType != tt.wantContentType {
+					t.Errorf("NewAssetReader() ContentType: %v, want: %v", got.ContentType, tt.wantContentType)
+				}
+				return
+			}
+			var assetErr *crud.AssetError
+			if !errors.As(err, &assetErr) {
+				t.Fatalf("NewAssetReader() error: %v, want: *crud.AssetError", err)
+			}
+			if assetErr.Kind != tt.args.kind || !reflect.DeepEqual(assetErr.Reasons, tt.wantReasons) {
+				t.Errorf("NewAssetReader() reasons: %q, want: %q", assetErr.Reasons, tt.wantReasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package crud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// SystemActor is the actor of the changes made by a service that was not given one
+const SystemActor = "system"
+
+// EntityType names the kind of entity an audit event is about
+type EntityType string
+
+const (
+	CategoryEntity   EntityType = "category"
+	GenreEntity      EntityType = "genre"
+	CastMemberEntity EntityType = "cast_member"
+	VideoEntity      EntityType = "video"
+)
+
+func (e EntityType) Validate() error {
+	switch e {
+	case CategoryEntity, GenreEntity, CastMemberEntity, VideoEntity:
+		return nil
+	}
+	return fmt.Errorf("entity type '%s' %w", e, logger.ErrIsNotValidated)
+}
+
+// AuditOperation is the kind of change an audit event records
+type AuditOperation string
+
+const (
+	AddOperation     AuditOperation = "add"
+	UpdateOperation  AuditOperation = "update"
+	RemoveOperation  AuditOperation = "remove"
+	RestoreOperation AuditOperation = "restore"
+	PurgeOperation   AuditOperation = "purge"
+)
+
+// AuditChange is the value of a field before and after a change, null on the side the entity was not there
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEvent is a change of an entity of the catalogue. Diff holds the fields that changed, keyed by their
+// JSON name, it is empty for a change of an entity that was out of the catalogue on both sides, like a purge
+// of the trash.
+type AuditEvent struct {
+	ID         int64                  `json:"id"`
+	Actor      string                 `json:"actor"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	EntityType EntityType             `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Operation  AuditOperation         `json:"operation"`
+	Diff       map[string]AuditChange `json:"diff"`
+}
+
+// AuditLog keeps the changes of the catalogue, the history of an entity lists the last ones first
+type AuditLog interface {
+	AddAuditEvent(event AuditEvent) error
+	GetHistory(entity EntityType, id string, page Page) ([]AuditEvent, PageInfo, error)
+}
+
+// Transactor is an audit log that records the events in the transactions of their changes
+type Transactor interface {
+	// InTx runs fn with a repository and an audit log bound to a transaction, which is committed when fn
+	// succeeds and rolled back otherwise. The entity with id, when it is given, is locked for the transaction.
+	InTx(entity EntityType, id string, fn func(r Repository, log AuditLog) error) error
+}
+
+func (s service) As(actor string) Service {
+	if r, ok := s.r.(auditedRepository); ok {
+		r.actor = actor
+		s.r = r
+	}
+	return &s
+}
+
+func (s service) GetHistory(entity EntityType, id string, page Page) ([]AuditEvent, PageInfo, error) {
+	if err := entity.Validate(); err != nil {
+		return nil, PageInfo{}, err
+	}
+	id, err := normalizeID(id)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if err := page.NormalizeNumbered("a history"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	if s.audit == nil {
+		return nil, PageInfo{}, fmt.Errorf("history of %s: %w", id, logger.ErrNotFound)
+	}
+	return s.audit.GetHistory(entity, id, page)
+}
+
+// auditedRepository records the changes made through its repository in its log. A log that is a Transactor
+// records an event in the transaction of its change, which is undone when the event could not be recorded.
+// Another log records it once its change is done, a change that could not be recorded is then reported only.
+type auditedRepository struct {
+	Repository
+	log   AuditLog
+	actor string
+}
+
+func (a auditedRepository) AddCategory(dto CategoryDTO) (uuid.UUID, error) {
+	return a.add(CategoryEntity, func(r Repository) (uuid.UUID, error) {
+		return r.AddCategory(dto)
+	})
+}
+
+func (a auditedRepository) UpdateCategory(id string, version int64, dto CategoryDTO) error {
+	return a.change(CategoryEntity, id, UpdateOperation, func(r Repository) error {
+		return r.UpdateCategory(id, version, dto)
+	})
+}
+
+func (a auditedRepository) RemoveCategory(id string, version int64) error {
+	return a.change(CategoryEntity, id, RemoveOperation, func(r Repository) error {
+		return r.RemoveCategory(id, version)
+	})
+}
+
+func (a auditedRepository) RestoreCategory(id string) error {
+	return a.change(CategoryEntity, id, RestoreOperation, func(r Repository) error {
+		return r.RestoreCategory(id)
+	})
+}
+
+func (a auditedRepository) PurgeCategory(id string, version int64) error {
+	return a.change(CategoryEntity, id, PurgeOperation, func(r Repository) error {
+		return r.PurgeCategory(id, version)
+	})
+}
+
+func (a auditedRepository) AddGenre(dto GenreDTO) (uuid.UUID, error) {
+	return a.add(GenreEntity, func(r Repository) (uuid.UUID, error) {
+		return r.AddGenre(dto)
+	})
+}
+
+func (a auditedRepository) UpdateGenre(id string, version int64, dto GenreDTO) error {
+	return a.change(GenreEntity, id, UpdateOperation, func(r Repository) error {
+		return r.UpdateGenre(id, version, dto)
+	})
+}
+
+func (a auditedRepository) RemoveGenre(id string, version int64) error {
+	return a.change(GenreEntity, id, RemoveOperation, func(r Repository) error {
+		return r.RemoveGenre(id, version)
+	})
+}
+
+func (a auditedRepository) RestoreGenre(id string) error {
+	return a.change(GenreEntity, id, RestoreOperation, func(r Repository) error {
+		return r.RestoreGenre(id)
+	})
+}
+
+func (a auditedRepository) PurgeGenre(id string, version int64) error {
+	return a.change(GenreEntity, id, PurgeOperation, func(r Repository) error {
+		return r.PurgeGenre(id, version)
+	})
+}
+
+func (a auditedRepository) AddCastMember(dto CastMemberDTO) (uuid.UUID, error) {
+	return a.add(CastMemberEntity, func(r Repository) (uuid.UUID, error) {
+		return r.AddCastMember(dto)
+	})
+}
+
+func (a auditedRepository) UpdateCastMember(id string, version int64, dto CastMemberDTO) error {
+	return a.change(CastMemberEntity, id, UpdateOperation, func(r Repository) error {
+		return r.UpdateCastMember(id, version, dto)
+	})
+}
+
+func (a auditedRepository) RemoveCastMember(id string, version int64) error {
+	return a.change(CastMemberEntity, id, RemoveOperation, func(r Repository) error {
+		return r.RemoveCastMember(id, version)
+	})
+}
+
+func (a auditedRepository) RestoreCastMember(id string) error {
+	return a.change(CastMemberEntity, id, RestoreOperation, func(r Repository) error {
+		return r.RestoreCastMember(id)
+	})
+}
+
+func (a auditedRepository) PurgeCastMember(id string, version int64) error {
+	return a.change(CastMemberEntity, id, PurgeOperation, func(r Repository) error {
+		return r.PurgeCastMember(id, version)
+	})
+}
+
+func (a auditedRepository) AddVideo(dto VideoDTO) (uuid.UUID, error) {
+	files, discard, err := a.stageAssets(dto.Files)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer discard()
+	dto.Files = files
+	return a.add(VideoEntity, func(r Repository) (uuid.UUID, error) {
+		return r.AddVideo(dto)
+	})
+}
+
+func (a auditedRepository) UpdateVideo(id string, version int64, dto VideoDTO) (uuid.UUID, error) {
+	files, discard, err := a.stageAssets(dto.Files)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer discard()
+	dto.Files = files
+	var updatedID uuid.UUID
+	err = a.change(VideoEntity, id, UpdateOperation, func(r Repository) error {
+		var err error
+		updatedID, err = r.UpdateVideo(id, version, dto)
+		return err
+	})
+	return updatedID, err
+}
+
+func (a auditedRepository) AttachVideoAsset(id string, kind AssetKind, file io.Reader) error {
+	if stager, ok := a.Repository.(AssetStager); ok {
+		staged, discard, err := stager.StageAsset(kind, file)
+		if err != nil {
+			return err
+		}
+		defer discard()
+		file = staged
+	}
+	return a.change(VideoEntity, id, UpdateOperation, func(r Repository) error {
+		return r.AttachVideoAsset(id, kind, file)
+	})
+}
+
+func (a auditedRepository) RemoveVideo(id string, version int64) error {
+	return a.change(VideoEntity, id, RemoveOperation, func(r Repository) error {
+		return r.RemoveVideo(id, version)
+	})
+}
+
+func (a auditedRepository) RestoreVideo(id string) error {
+	return a.change(VideoEntity, id, RestoreOperation, func(r Repository) error {
+		return r.RestoreVideo(id)
+	})
+}
+
+func (a auditedRepository) PurgeVideo(id string, version int64) error {
+	return a.change(VideoEntity, id, PurgeOperation, func(r Repository) error {
+		return r.PurgeVideo(id, version)
+	})
+}
+
+// add runs the addition of an entity and records it with the entity taken after it
+func (a auditedRepository) add(entity EntityType, run func(r Repository) (uuid.UUID, error)) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := a.inTx(entity, "", func(a auditedRepository) error {
+		var err error
+		if id, err = run(a.Repository); err != nil {
+			return err
+		}
+		after, err := a.snapshot(entity, id.String())
+		if err != nil {
+			return err
+		}
+		return a.record(entity, id.String(), AddOperation, nil, after)
+	})
+	return id, err
+}
+
+// change runs the change op of an entity and records it with the entity taken before and after it. An entity
+// out of the catalogue, in the trash or purged, is taken as null.
+func (a auditedRepository) change(entity EntityType, id string, op AuditOperation, run func(r Repository) error) error {
+	return a.inTx(entity, id, func(a auditedRepository) error {
+		before, err := a.snapshot(entity, id)
+		if err != nil {
+			return err
+		}
+		if err := run(a.Repository); err != nil {
+			return err
+		}
+		after, err := a.snapshot(entity, id)
+		if err != nil {
+			return err
+		}
+		return a.record(entity, id, op, before, after)
+	})
+}
+
+// stageAssets stages the files of source before the transaction of their change begins, when the repository is
+// an AssetStager. discard removes the ones the change did not store.
+func (a auditedRepository) stageAssets(source AssetSource) (staged AssetSource, discard func(), err error) {
+	stager, ok := a.Repository.(AssetStager)
+	if !ok || source == nil {
+		return source, func() {}, nil
+	}
+	assets := &stagedAssets{}
+	for {
+		kind, file, err := source.NextAsset()
+		if err == io.EOF {
+			return assets, assets.discard, nil
+		}
+		var discard func()
+		if err == nil {
+			file, discard, err = stager.StageAsset(kind, file)
+		}
+		if err != nil {
+			assets.discard()
+			return nil, nil, err
+		}
+		assets.kinds = append(assets.kinds, kind)
+		assets.files = append(assets.files, file)
+		assets.discards = append(assets.discards, discard)
+	}
+}
+
+// inTx runs fn with a bound to the transaction of its log, or as it is when its log is not a Transactor
+func (a auditedRepository) inTx(entity EntityType, id string, fn func(a auditedRepository) error) error {
+	tx, ok := a.log.(Transactor)
+	if !ok {
+		return fn(a)
+	}
+	return tx.InTx(entity, id, func(r Repository, log AuditLog) error {
+		return fn(auditedRepository{Repository: r, log: log, actor: a.actor})
+	})
+}
+
+// snapshot takes the entity as it is shown, with its relations, or nil when it is out of the catalogue
+func (a auditedRepository) snapshot(entity EntityType, id string) (interface{}, error) {
+	var snapshot interface{}
+	var err error
+	switch entity {
+	case CategoryEntity:
+		var category models.Category
+		if category, err = a.FetchCategory(id); err == nil {
+			snapshot, err = MapCategoryToDTO(category)
+		}
+	case GenreEntity:
+		var genre models.Genre
+		if genre, err = a.FetchGenre(id); err == nil {
+			snapshot, err = MapGenreToDTO(genre)
+		}
+	case CastMemberEntity:
+		var castMember models.CastMember
+		if castMember, err = a.FetchCastMember(id); err == nil {
+			snapshot, err = MapCastMemberToDTO(castMember)
+		}
+	case VideoEntity:
+		var video models.Video
+		if video, err = a.FetchVideo(id); err == nil {
+			var dto *VideoDTO
+			if dto, err = MapVideoToDTO(video); err == nil {
+				// the assets are told apart by their content type and size, they have no address here
+				dto.Assets = MapVideoAssetsToDTO(video.R.VideoAssets, func(AssetKind) string { return "" })
+				snapshot = dto
+			}
+		}
+	default:
+		return nil, entity.Validate()
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not take %s %s for the audit log: %v: %w", entity, id, err, logger.ErrInternalApplication)
+	}
+	return snapshot, nil
+}
+
+func (a auditedRepository) record(entity EntityType, id string, op AuditOperation, before, after interface{}) error {
+	diff, err := auditDiff(before, after)
+	if err != nil {
+		return fmt.Errorf("could not record the %s of %s %s: %v: %w", op, entity, id, err, logger.ErrInternalApplication)
+	}
+	event := AuditEvent{
+		Actor:      a.actor,
+		OccurredAt: time.Now(),
+		EntityType: entity,
+		EntityID:   id,
+		Operation:  op,
+		Diff:       diff,
+	}
+	if err := a.log.AddAuditEvent(event); err != nil {
+		return fmt.Errorf("could not record the %s of %s %s: %v: %w", op, entity, id, err, logger.ErrInternalApplication)
+	}
+	return nil
+}
+
+// auditDiff compares the JSON fields of two snapshots, either of which can be nil, the id is left out
+func auditDiff(before, after interface{}) (map[string]AuditChange, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+	diff := make(map[string]AuditChange)
+	for name, value := range beforeFields {
+		if !reflect.DeepEqual(value, afterFields[name]) {
+			diff[name] = AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			diff[name] = AuditChange{After: value}
+		}
+	}
+	delete(diff, "id")
+	return diff, nil
+}
+
+func jsonFields(snapshot interface{}) (map[string]interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,341 @@
+package crud_test
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/crud/mock"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func Test_service_Audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	mockLog := mock.NewMockAuditLog(ctrl)
+	s := crud.NewService(mockR, crud.WithAuditLog(mockLog))
+	fakeID := uuid.New()
+	fakeCastMember := models.CastMember{ID: fakeID.String(), Name: "fake", Type: int16(crud.Actor)}
+	tests := []struct {
+		name      string
+		svc       crud.Service
+		expect    func()
+		call      func(svc crud.Service) error
+		wantEvent *crud.AuditEvent
+		wantErr   error
+	}{
+		{
+			name: "When a cast member is added by nobody",
+			svc:  s,
+			expect: func() {
+				mockR.EXPECT().AddCastMember(gomock.Any()).Return(fakeID, nil)
+				mockR.EXPECT().FetchCastMember(fakeID.String()).Return(fakeCastMember, nil)
+			},
+			call: func(svc crud.Service) error {
+				_, err := svc.AddCastMember(crud.CastMemberDTO{Name: "fake", Type: crud.Actor})
+				return err
+			},
+			wantEvent: &crud.AuditEvent{
+				Actor:     crud.SystemActor,
+				Operation: crud.AddOperation,
+				Diff: map[string]crud.AuditChange{
+					"name": {After: "fake"},
+					"type": {After: "actor"},
+				},
+			},
+		},
+		{
+			name: "When a cast member is updated by an editor",
+			svc:  s.As("editor"),
+			expect: func() {
+				updated := fakeCastMember
+				updated.Type = int16(crud.Director)
+				gomock.InOrder(
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(fakeCastMember, nil),
+					mockR.EXPECT().UpdateCastMember(fakeID.String(), int64(1), gomock.Any()).Return(nil),
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(updated, nil),
+				)
+			},
+			call: func(svc crud.Service) error {
+				return svc.UpdateCastMember(fakeID.String(), 1, crud.CastMemberDTO{Name: "fake", Type: crud.Director})
+			},
+			wantEvent: &crud.AuditEvent{
+				Actor:     "editor",
+				Operation: crud.UpdateOperation,
+				Diff: map[string]crud.AuditChange{
+					"type": {Before: "actor", After: "director"},
+				},
+			},
+		},
+		{
+			name: "When a cast member is removed",
+			svc:  s.As("editor"),
+			expect: func() {
+				gomock.InOrder(
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(fakeCastMember, nil),
+					mockR.EXPECT().RemoveCastMember(fakeID.String(), crud.AnyVersion).Return(nil),
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(models.CastMember{}, sql.ErrNoRows),
+				)
+			},
+			call: func(svc crud.Service) error {
+				return svc.RemoveCastMember(fakeID.String(), crud.AnyVersion)
+			},
+			wantEvent: &crud.AuditEvent{
+				Actor:     "editor",
+				Operation: crud.RemoveOperation,
+				Diff: map[string]crud.AuditChange{
+					"name": {Before: "fake"},
+					"type": {Before: "actor"},
+				},
+			},
+		},
+		{
+			name: "When the cast member is not found",
+			svc:  s.As("editor"),
+			expect: func() {
+				mockR.EXPECT().FetchCastMember(fakeID.String()).Return(models.CastMember{}, sql.ErrNoRows)
+				mockR.EXPECT().RemoveCastMember(fakeID.String(), crud.AnyVersion).Return(sql.ErrNoRows)
+			},
+			call: func(svc crud.Service) error {
+				return svc.RemoveCastMember(fakeID.String(), crud.AnyVersion)
+			},
+			wantErr: logger.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expect()
+			var got *crud.AuditEvent
+			if tt.wantEvent != nil {
+				mockLog.EXPECT().AddAuditEvent(gomock.Any()).DoAndReturn(func(event crud.AuditEvent) error {
+					got = &event
+					return nil
+				})
+			}
+			if err := tt.call(tt.svc); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error: %v, want: %v", err, tt.wantErr)
+			}
+			if tt.wantEvent == nil {
+				return
+			}
+			if got.EntityType != crud.CastMemberEntity || got.EntityID != fakeID.String() || got.OccurredAt.IsZero() {
+				t.Errorf("event of %s %s at %v, want the cast member %s", got.EntityType, got.EntityID, got.OccurredAt, fakeID)
+			}
+			if got.Actor != tt.wantEvent.Actor || got.Operation != tt.wantEvent.Operation {
+				t.Errorf("event %s by %s, want: %s by %s", got.Operation, got.Actor, tt.wantEvent.Operation, tt.wantEvent.Actor)
+			}
+			if !reflect.DeepEqual(got.Diff, tt.wantEvent.Diff) {
+				t.Errorf("diff: %v, want: %v", got.Diff, tt.wantEvent.Diff)
+			}
+		})
+	}
+}
+
+func Test_service_GetHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	mockLog := mock.NewMockAuditLog(ctrl)
+	fakeID := uuid.New().String()
+	tests := []struct {
+		name     string
+		svc      crud.Service
+		entity   crud.EntityType
+		id       string
+		page     crud.Page
+		repoPage crud.Page
+		wantErr  error
+	}{
+		{
+			name:    "When entity type is unknown",
+			svc:     crud.NewService(mockR, crud.WithAuditLog(mockLog)),
+			entity:  "fake",
+			id:      fakeID,
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When id is not an uuid",
+			svc:     crud.NewService(mockR, crud.WithAuditLog(mockLog)),
+			entity:  crud.VideoEntity,
+			id:      "fake",
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name:    "When cursor is given",
+			svc:     crud.NewService(mockR, crud.WithAuditLog(mockLog)),
+			entity:  crud.VideoEntity,
+			id:      fakeID,
+			page:    crud.Page{Cursor: &crud.Cursor{ID: fakeID}},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When the service has no audit log",
+			svc:     crud.NewService(mockR),
+			entity:  crud.VideoEntity,
+			id:      fakeID,
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name:     "When page is not given",
+			svc:      crud.NewService(mockR, crud.WithAuditLog(mockLog)),
+			entity:   crud.GenreEntity,
+			id:       fakeID,
+			repoPage: crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				mockLog.EXPECT().
+					GetHistory(tt.entity, tt.id, tt.repoPage).
+					Return(nil, crud.PageInfo{}, nil)
+			}
+			_, _, err := tt.svc.GetHistory(tt.entity, tt.id, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetHistory() error: %v, want: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// txLog is an audit log that runs the changes in transactions, it keeps the entities they locked
+type txLog struct {
+	*mock.MockAuditLog
+	r          crud.Repository
+	locked     []string
+	rolledBack bool
+}
+
+func (l *txLog) InTx(entity crud.EntityType, id string, fn func(r crud.Repository, log crud.AuditLog) error) error {
+	l.locked = append(l.locked, string(entity)+" "+id)
+	err := fn(l.r, l.MockAuditLog)
+	l.rolledBack = err != nil
+	return err
+}
+
+func Test_service_Audit_InTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeID := uuid.New()
+	fakeCastMember := models.CastMember{ID: fakeID.String(), Name: "fake", Type: int16(crud.Actor)}
+	tests := []struct {
+		name       string
+		expect     func(log *mock.MockAuditLog)
+		call       func(svc crud.Service) error
+		wantLocked []string
+		wantErr    error
+	}{
+		{
+			name: "When a cast member is added",
+			expect: func(log *mock.MockAuditLog) {
+				gomock.InOrder(
+					mockR.EXPECT().AddCastMember(gomock.Any()).Return(fakeID, nil),
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(fakeCastMember, nil),
+					log.EXPECT().AddAuditEvent(gomock.Any()).Return(nil),
+				)
+			},
+			call: func(svc crud.Service) error {
+				_, err := svc.AddCastMember(crud.CastMemberDTO{Name: "fake", Type: crud.Actor})
+				return err
+			},
+			wantLocked: []string{"cast_member "},
+		},
+		{
+			name: "When the event of a removal could not be recorded",
+			expect: func(log *mock.MockAuditLog) {
+				gomock.InOrder(
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(fakeCastMember, nil),
+					mockR.EXPECT().RemoveCastMember(fakeID.String(), crud.AnyVersion).Return(nil),
+					mockR.EXPECT().FetchCastMember(fakeID.String()).Return(models.CastMember{}, sql.ErrNoRows),
+					log.EXPECT().AddAuditEvent(gomock.Any()).Return(errors.New("fake")),
+				)
+			},
+			call: func(svc crud.Service) error {
+				return svc.RemoveCastMember(fakeID.String(), crud.AnyVersion)
+			},
+			wantLocked: []string{"cast_member " + fakeID.String()},
+			wantErr:    logger.ErrInternalApplication,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &txLog{MockAuditLog: mock.NewMockAuditLog(ctrl), r: mockR}
+			tt.expect(log.MockAuditLog)
+			err := tt.call(crud.NewService(mockR, crud.WithAuditLog(log)).As("editor"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error: %v, want: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(log.locked, tt.wantLocked) {
+				t.Errorf("transactions of %v, want: %v", log.locked, tt.wantLocked)
+			}
+			if log.rolledBack != (tt.wantErr != nil) {
+				t.Errorf("rolled back: %v, want: %v", log.rolledBack, tt.wantErr != nil)
+			}
+		})
+	}
+}
+
+// stagingRepository is a repository that stages the files of the assets, it keeps the steps of the changes
+type stagingRepository struct {
+	*mock.MockRepository
+	steps *[]string
+}
+
+func (r stagingRepository) StageAsset(kind crud.AssetKind, file io.Reader) (io.Reader, func(), error) {
+	*r.steps = append(*r.steps, "stage "+string(kind))
+	return file, func() { *r.steps = append(*r.steps, "discard "+string(kind)) }, nil
+}
+
+// stepLog is an audit log that runs the changes in transactions, it keeps the steps of the changes
+type stepLog struct {
+	*mock.MockAuditLog
+	r     crud.Repository
+	steps *[]string
+}
+
+func (l stepLog) InTx(_ crud.EntityType, _ string, fn func(r crud.Repository, log crud.AuditLog) error) error {
+	*l.steps = append(*l.steps, "begin")
+	err := fn(l.r, l.MockAuditLog)
+	*l.steps = append(*l.steps, "end")
+	return err
+}
+
+func Test_service_Audit_StageAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	fakeID := uuid.New().String()
+	var steps []string
+	mockR := mock.NewMockRepository(ctrl)
+	mockLog := mock.NewMockAuditLog(ctrl)
+	gomock.InOrder(
+		mockR.EXPECT().FetchVideo(fakeID).Return(models.Video{}, sql.ErrNoRows),
+		mockR.EXPECT().AttachVideoAsset(fakeID, crud.ThumbnailAsset, gomock.Any()).DoAndReturn(
+			func(string, crud.AssetKind, io.Reader) error {
+				steps = append(steps, "attach")
+				return nil
+			},
+		),
+		mockR.EXPECT().FetchVideo(fakeID).Return(models.Video{}, sql.ErrNoRows),
+		mockLog.EXPECT().AddAuditEvent(gomock.Any()).Return(nil),
+	)
+	r := stagingRepository{MockRepository: mockR, steps: &steps}
+	log := stepLog{MockAuditLog: mockLog, r: mockR, steps: &steps}
+	svc := crud.NewService(r, crud.WithAuditLog(log))
+	if err := svc.AttachVideoAsset(fakeID, crud.ThumbnailAsset, strings.NewReader("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00\x01")); err != nil {
+		t.Fatalf("AttachVideoAsset() error: %v", err)
+	}
+	want := []string{"stage thumbnail", "begin", "attach", "end", "discard thumbnail"}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps: %v, want: %v", steps, want)
+	}
+}
@@ -2,52 +2,111 @@ package crud
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/go-playground/validator/v10/non-standard/validators"
 
+	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
 var castMemberValidate *validator.Validate
 
+// CastMemberType is the role of a cast member, stored as a number and written by its name
 type CastMemberType int16
 
+// the stored numbers of the roles must not change, new roles are appended
 const (
 	Director CastMemberType = iota
 	Actor
+	Writer
+	Producer
+	Composer
+	VoiceActor
 )
 
+var castMemberTypeNames = [...]string{
+	Director:   "director",
+	Actor:      "actor",
+	Writer:     "writer",
+	Producer:   "producer",
+	Composer:   "composer",
+	VoiceActor: "voice_actor",
+}
+
+// ParseCastMemberType reads a role from its name
+func ParseCastMemberType(name string) (CastMemberType, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for c, n := range castMemberTypeNames {
+		if n == name {
+			return CastMemberType(c), nil
+		}
+	}
+	return 0, fmt.Errorf("cast member type '%s' %w", name, logger.ErrIsNotValidated)
+}
+
 func (c CastMemberType) String() string {
-	return [...]string{"Director", "Actor"}[c]
+	if c < 0 || int(c) >= len(castMemberTypeNames) {
+		return fmt.Sprintf("CastMemberType(%d)", int16(c))
+	}
+	return castMemberTypeNames[c]
+}
+
+func (c CastMemberType) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c.String()), nil
+}
+
+func (c *CastMemberType) UnmarshalText(text []byte) error {
+	t, err := ParseCastMemberType(string(text))
+	if err != nil {
+		return err
+	}
+	*c = t
+	return nil
+}
+
+// playsCharacter tells whether a credit in the role names the character that is played
+func (c CastMemberType) playsCharacter() bool {
+	return c == Actor || c == VoiceActor
 }
 
 type CastMemberDTO struct {
+	ID   string         `json:"id,omitempty"`
 	Name string         `json:"name" validate:"not_blank"`
 	Type CastMemberType `json:"type"`
 }
 
+func MapCastMemberToDTO(castMember models.CastMember) (*CastMemberDTO, error) {
+	dto := &CastMemberDTO{
+		ID:   castMember.ID,
+		Name: castMember.Name,
+		Type: CastMemberType(castMember.Type),
+	}
+	if err := dto.Validate(); err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
 func (c CastMemberType) Validate() error {
-	switch c {
-	case Director, Actor:
-		return nil
+	if c < 0 || int(c) >= len(castMemberTypeNames) {
+		return fmt.Errorf("cast member type %d %w", int16(c), logger.ErrIsNotValidated)
 	}
-	return fmt.Errorf("cast member type %w", logger.ErrIsNotValidated)
+	return nil
 }
 
 func (c *CastMemberDTO) Validate() error {
-	err := castMemberValidate.Struct(c)
-	if err != nil {
-		vErrs := err.(validator.ValidationErrors)
-		return fmt.Errorf("%s %w", vErrs[0].StructField(), logger.ErrIsRequired)
-	}
+	var errs fieldErrors
+	errs.addStruct(castMemberValidate, c)
 	if err := c.Type.Validate(); err != nil {
-		return err
+		errs.add("type", "cast_member_type")
 	}
-	return nil
+	return errs.err()
 }
 
 func init() {
-	castMemberValidate = validator.New()
-	castMemberValidate.RegisterValidation("not_blank", validators.NotBlank)
+	castMemberValidate = newValidator()
 }
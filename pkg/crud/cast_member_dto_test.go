@@ -0,0 +1,67 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestCastMemberType_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    crud.CastMemberType
+		wantErr error
+	}{
+		{
+			name: "When the type is a director",
+			json: `"director"`,
+			want: crud.Director,
+		},
+		{
+			name: "When the type is a voice actor",
+			json: `"voice_actor"`,
+			want: crud.VoiceActor,
+		},
+		{
+			name: "When the name is not lowercase",
+			json: `" Composer "`,
+			want: crud.Composer,
+		},
+		{
+			name:    "When the name is unknown",
+			json:    `"stunt"`,
+			wantErr: logger.ErrIsNotValidated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got crud.CastMemberDTO
+			err := json.Unmarshal([]byte(`{"name": "fake", "type": `+tt.json+`}`), &got)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Unmarshal() error: %v, want: %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Type != tt.want {
+				t.Errorf("Unmarshal() type: %v, want: %v", got.Type, tt.want)
+			}
+			data, err := json.Marshal(got.Type)
+			if err != nil {
+				t.Fatalf("Marshal() error: %v", err)
+			}
+			if want := `"` + tt.want.String() + `"`; string(data) != want {
+				t.Errorf("Marshal() got: %s, want: %s", data, want)
+			}
+		})
+	}
+	t.Run("When the type is not one of the roles", func(t *testing.T) {
+		if _, err := json.Marshal(crud.CastMemberType(111)); err == nil {
+			t.Errorf("Marshal() error: nil, want one")
+		}
+	})
+}
@@ -6,62 +6,133 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (s service) RemoveCastMember(name string) error {
-	if len(strings.TrimSpace(name)) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) RemoveCastMember(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if err := s.r.RemoveCastMember(id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) GetCastMembersInTrash(page Page) (models.CastMemberSlice, PageInfo, error) {
+	if err := page.NormalizeNumbered("a trash"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return s.r.GetCastMembersInTrash(page)
+}
+
+func (s service) RestoreCastMember(id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
 	}
-	if err := s.r.RemoveCastMember(name); err != nil {
+	if err := s.r.RestoreCastMember(id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) UpdateCastMember(name string, castMemberDTO CastMemberDTO) error {
-	if len(strings.TrimSpace(name)) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) PurgeCastMember(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if err := s.r.PurgeCastMember(id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) UpdateCastMember(id string, version int64, castMemberDTO CastMemberDTO) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
 	}
 	if err := castMemberDTO.Validate(); err != nil {
 		return err
 	}
-	if err := s.r.UpdateCastMember(name, castMemberDTO); err != nil {
+	if err := s.r.UpdateCastMember(id, version, castMemberDTO); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) AddCastMember(castMemberDTO CastMemberDTO) error {
+func (s service) AddCastMember(castMemberDTO CastMemberDTO) (uuid.UUID, error) {
 	castMemberDTO.Name = strings.TrimSpace(castMemberDTO.Name)
 	if err := castMemberDTO.Validate(); err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
 	return s.r.AddCastMember(castMemberDTO)
 }
 
-func (s service) GetCastMembers(limit int) (models.CastMemberSlice, error) {
-	if limit < 0 {
-		return nil, logger.ErrInvalidedLimit
+func (s service) GetCastMembers(filter Filter, page Page) (models.CastMemberSlice, PageInfo, error) {
+	if err := filter.normalize(castMemberSortFields, &page); err != nil {
+		return nil, PageInfo{}, err
 	}
-	return s.r.GetCastMembers(limit)
+	return s.r.GetCastMembers(filter, page)
 }
 
-func (s service) FetchCastMember(name string) (models.CastMember, error) {
-	name = strings.TrimSpace(name)
-	c, err := s.r.FetchCastMember(name)
+func (s service) FetchCastMember(id string) (models.CastMember, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return models.CastMember{}, err
+	}
+	c, err := s.r.FetchCastMember(id)
 	if err == sql.ErrNoRows {
-		return models.CastMember{}, fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+		return models.CastMember{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 	} else if err != nil {
-		return models.CastMember{}, fmt.Errorf("%s: %w", name, logger.ErrInternalApplication)
+		return models.CastMember{}, fmt.Errorf("%s: %w", id, logger.ErrInternalApplication)
 	}
 
 	return c, nil
 }
+
+// GetCastMemberVideos lists the filmography of a cast member, one credit per role they had in a video,
+// the latest videos first. Its pages are numbered.
+func (s service) GetCastMemberVideos(id string, page Page) (models.CastMemberVideoSlice, PageInfo, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	if err := page.NormalizeNumbered("a filmography"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	credits, info, err := s.r.GetCastMemberVideos(id, page)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return nil, PageInfo{}, err
+	}
+	return credits, info, nil
+}
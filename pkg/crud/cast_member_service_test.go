@@ -8,9 +8,11 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/crud"
@@ -25,6 +27,7 @@ func TestAddCastMember(t *testing.T) {
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
 	fakeName := strings.ToLower(faker.FirstName())
+	fakeID := uuid.New()
 	type fields struct {
 		r sqlboiler.Repository
 	}
@@ -84,14 +87,17 @@ func TestAddCastMember(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
 					AddCastMember(tt.args.dto).
-					Return(tt.want.err)
+					Return(fakeID, tt.want.err)
 			}
 			s := crud.NewService(mockR)
-			err := s.AddCastMember(tt.args.dto)
+			id, err := s.AddCastMember(tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddCastMember() error = '%v', wantErr '%v'", err, tt.wantErr)
 				return
 			}
+			if !tt.wantErr && id != fakeID {
+				t.Errorf("AddCastMember() id = '%v', want '%v'", id, fakeID)
+			}
 			if !errors.Is(err, tt.want.err) {
 				t.Errorf("AddCastMember() got = '%v', want '%v'", err, tt.want.err)
 			}
@@ -103,16 +109,16 @@ func Test_service_RemoveCastMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	indexRandom := rand.Intn(len(testdata.FakeCategories))
-	fakeNames := [2]string{
-		faker.FirstName(),
-		testdata.FakeCategories[indexRandom].Name,
+	indexRandom := rand.Intn(len(testdata.FakeCastMembers))
+	fakeIDs := [2]string{
+		uuid.New().String(),
+		testdata.FakeCastMembers[indexRandom].ID,
 	}
 	type fields struct {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -122,37 +128,43 @@ func Test_service_RemoveCastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is blank",
+			name:    "When id is blank",
 			args:    args{"     "},
-			want:    fmt.Errorf("'name' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
 			wantErr: true,
 		},
 		{
-			name:    "When name is not found",
-			args:    args{fakeNames[0]},
-			want:    fmt.Errorf("%s: %w", fakeNames[0], logger.ErrNotFound),
+			name:    "When id is not an uuid",
+			args:    args{"fake"},
+			want:    fmt.Errorf("%s: %w", "fake", logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeNames[1]},
+			name:    "When id is not found",
+			args:    args{fakeIDs[0]},
+			want:    fmt.Errorf("%s: %w", fakeIDs[0], logger.ErrNotFound),
+			wantErr: true,
+		},
+		{
+			name:    "When id is found",
+			args:    args{fakeIDs[1]},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "When name is not found" {
+			if tt.name == "When id is not found" {
 				mockR.EXPECT().
-					RemoveCastMember(tt.args.name).
+					RemoveCastMember(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
-			} else if tt.name == "When name is found" {
+			} else if tt.name == "When id is found" {
 				mockR.EXPECT().
-					RemoveCastMember(tt.args.name).
+					RemoveCastMember(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.RemoveCastMember(tt.args.name)
+			err := s.RemoveCastMember(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveCastMember() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -168,16 +180,14 @@ func Test_service_UpdateCastMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	const (
-		fakeExistName        = "fakeExistName"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
 	type fields struct {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
-		dto  crud.CastMemberDTO
+		id  string
+		dto crud.CastMemberDTO
 	}
 	tests := []struct {
 		name    string
@@ -187,7 +197,7 @@ func Test_service_UpdateCastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is blank",
+			name: "When id is blank",
 			args: args{
 				"     ",
 				crud.CastMemberDTO{
@@ -200,26 +210,26 @@ func Test_service_UpdateCastMember(t *testing.T) {
 		},
 		{
 			name:    "When CastMemberDTO is not provided",
-			args:    args{fakeExistName, crud.CastMemberDTO{}},
+			args:    args{fakeExistID, crud.CastMemberDTO{}},
 			want:    logger.ErrIsRequired,
 			wantErr: true,
 		},
 		{
-			name: "When name is not found",
+			name: "When id is not found",
 			args: args{
-				fakeDoesNotExistName,
+				fakeDoesNotExistID,
 				crud.CastMemberDTO{
 					Name: faker.FirstName(),
 					Type: crud.Director,
 				},
 			},
-			want:    fmt.Errorf("%s: %w", fakeDoesNotExistName, logger.ErrNotFound),
+			want:    fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name: "When name is found and CastMemberDTO is provided",
+			name: "When id is found and CastMemberDTO is provided",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.CastMemberDTO{
 					Name: faker.FirstName(),
 					Type: crud.Actor,
@@ -231,13 +241,13 @@ func Test_service_UpdateCastMember(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "When name is not found" || tt.name == "When name is found and CastMemberDTO is provided" {
+			if tt.name == "When id is not found" || tt.name == "When id is found and CastMemberDTO is provided" {
 				mockR.EXPECT().
-					UpdateCastMember(tt.args.name, tt.args.dto).
+					UpdateCastMember(tt.args.id, crud.AnyVersion, tt.args.dto).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.UpdateCastMember(tt.args.name, tt.args.dto)
+			err := s.UpdateCastMember(tt.args.id, crud.AnyVersion, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateCastMember() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -264,45 +274,104 @@ func Test_service_GetCastMembers(t *testing.T) {
 			Name: "Maria Alves",
 		},
 	}
-	fakeLimit := len(fakeCastMemberSlice)
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeCastMemberSlice)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{time.Now()}, ID: uuid.New().String()}
+	fakeType := crud.Writer
+	fakeNotValidatedType := crud.CastMemberType(111)
 	type args struct {
-		limit int
+		filter crud.Filter
+		page   crud.Page
 	}
 	type returns struct {
 		cs models.CastMemberSlice
 		e  error
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    returns
-		wantErr bool
+		name       string
+		args       args
+		repoFilter crud.Filter
+		repoPage   crud.Page
+		want       returns
+		wantErr    bool
 	}{
 		{
-			name:    "When limit is less than zero",
-			args:    args{-1},
+			name:    "When per_page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{PerPage: -1}},
 			want:    returns{nil, logger.ErrInvalidedLimit},
 			wantErr: true,
 		},
 		{
-			name:    "When limit is right",
-			args:    args{fakeLimit},
-			want:    returns{fakeCastMemberSlice, nil},
-			wantErr: false,
+			name:    "When page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{Number: -1}},
+			want:    returns{nil, logger.ErrInvalidedLimit},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor and page are both given",
+			args:    args{crud.Filter{}, crud.Page{Cursor: fakeCursor, Number: 2}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When page is not given",
+			args:       args{crud.Filter{}, crud.Page{}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCastMemberSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When per_page is above the maximum",
+			args:       args{crud.Filter{}, crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage + 1}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage},
+			want:       returns{fakeCastMemberSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When name is given",
+			args:       args{crud.Filter{Name: " Maria \t ALVES "}, crud.Page{}},
+			repoFilter: crud.Filter{Name: "maria alves", Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCastMemberSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When a prefix of the name is given",
+			args:       args{crud.Filter{NamePrefix: " Ma "}, crud.Page{}},
+			repoFilter: crud.Filter{NamePrefix: "ma", Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCastMemberSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When type is given",
+			args:       args{crud.Filter{Type: &fakeType}, crud.Page{}},
+			repoFilter: crud.Filter{Type: &fakeType, Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCastMemberSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:    "When type is not validated",
+			args:    args{crud.Filter{Type: &fakeNotValidatedType}, crud.Page{}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
-					GetCastMembers(tt.args.limit).
+					GetCastMembers(tt.repoFilter, tt.repoPage).
 					Return(
 						fakeCastMemberSlice,
+						fakePageInfo,
 						nil,
 					)
 			}
 			s := crud.NewService(mockR)
-			got, err := s.GetCastMembers(tt.args.limit)
+			got, info, err := s.GetCastMembers(tt.args.filter, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCastMembers() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -310,9 +379,100 @@ func Test_service_GetCastMembers(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want.cs) {
 				t.Errorf("GetCastMembers() got = %v, want %v", got, tt.want.cs)
 			}
-			if !reflect.DeepEqual(err, tt.want.e) {
+			if !errors.Is(err, tt.want.e) {
 				t.Errorf("GetCastMembers() got = %v, want %v", err, tt.want.e)
 			}
+			if !tt.wantErr && info != fakePageInfo {
+				t.Errorf("GetCastMembers() info = %+v, want %+v", info, fakePageInfo)
+			}
+		})
+	}
+}
+
+func Test_service_GetCastMemberVideos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeCredits := models.CastMemberVideoSlice{
+		&models.CastMemberVideo{
+			CastMemberID: fakeExistID,
+			VideoID:      testdata.FakeVideos[0].ID,
+			Role:         int16(crud.Actor),
+			BillingOrder: 1,
+		},
+	}
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeCredits)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{time.Now()}, ID: uuid.New().String()}
+	type args struct {
+		id   string
+		page crud.Page
+	}
+	tests := []struct {
+		name     string
+		args     args
+		repoID   string
+		repoPage crud.Page
+		repoErr  error
+		want     models.CastMemberVideoSlice
+		wantErr  error
+	}{
+		{
+			name:    "When id is blank",
+			args:    args{" ", crud.Page{}},
+			wantErr: logger.ErrIsRequired,
+		},
+		{
+			name:    "When id is not an uuid",
+			args:    args{"fakeCastMember", crud.Page{}},
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name:    "When cursor is given",
+			args:    args{fakeExistID, crud.Page{Cursor: fakeCursor}},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When per_page is less than zero",
+			args:    args{fakeExistID, crud.Page{PerPage: -1}},
+			wantErr: logger.ErrInvalidedLimit,
+		},
+		{
+			name:     "When id is not found",
+			args:     args{fakeDoesNotExistID, crud.Page{}},
+			repoID:   fakeDoesNotExistID,
+			repoPage: crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			repoErr:  sql.ErrNoRows,
+			wantErr:  logger.ErrNotFound,
+		},
+		{
+			name:     "When id is found",
+			args:     args{strings.ToUpper(fakeExistID), crud.Page{Number: 2}},
+			repoID:   fakeExistID,
+			repoPage: crud.Page{Number: 2, PerPage: crud.DefaultPerPage},
+			want:     fakeCredits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.repoID != "" {
+				credits := fakeCredits
+				if tt.repoErr != nil {
+					credits = nil
+				}
+				mockR.EXPECT().
+					GetCastMemberVideos(tt.repoID, tt.repoPage).
+					Return(credits, fakePageInfo, tt.repoErr)
+			}
+			s := crud.NewService(mockR)
+			got, _, err := s.GetCastMemberVideos(tt.args.id, tt.args.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetCastMemberVideos() error: %v, want: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCastMemberVideos() got: %v, want: %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -321,11 +481,13 @@ func Test_service_FetchCastMember(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	fakeDoesNotExistName := "fakeDoesNotExistName"
+	fakeAnyID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeExistID := uuid.New().String()
 	fakeExistName := "João Batista"
 	fakeErrorInternalApplication := fmt.Errorf("Service.FetchCastMember(): %w", logger.ErrInternalApplication)
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		c models.CastMember
@@ -340,7 +502,7 @@ func Test_service_FetchCastMember(t *testing.T) {
 	}{
 		{
 			name: "When throw the error internal application",
-			args: args{"anyName"},
+			args: args{fakeAnyID},
 			want: returns{
 				models.CastMember{},
 				fakeErrorInternalApplication,
@@ -348,7 +510,7 @@ func Test_service_FetchCastMember(t *testing.T) {
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchCastMember("anyName").
+					FetchCastMember(fakeAnyID).
 					Return(
 						models.CastMember{},
 						fakeErrorInternalApplication,
@@ -356,16 +518,16 @@ func Test_service_FetchCastMember(t *testing.T) {
 			},
 		},
 		{
-			name: "When name is not found",
-			args: args{fakeDoesNotExistName},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.CastMember{},
-				fmt.Errorf("%s: %w", fakeDoesNotExistName, logger.ErrNotFound),
+				fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			},
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchCastMember(fakeDoesNotExistName).
+					FetchCastMember(fakeDoesNotExistID).
 					Return(
 						models.CastMember{},
 						sql.ErrNoRows,
@@ -373,8 +535,8 @@ func Test_service_FetchCastMember(t *testing.T) {
 			},
 		},
 		{
-			name: "When name is found",
-			args: args{fakeExistName},
+			name: "When id is found",
+			args: args{fakeExistID},
 			want: returns{
 				models.CastMember{
 					Name: fakeExistName,
@@ -384,7 +546,7 @@ func Test_service_FetchCastMember(t *testing.T) {
 			wantErr: false,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchCastMember(fakeExistName).
+					FetchCastMember(fakeExistID).
 					Return(
 						models.CastMember{
 							Name: fakeExistName,
@@ -398,7 +560,7 @@ func Test_service_FetchCastMember(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMockR()
 			s := crud.NewService(mockR)
-			got, err := s.FetchCastMember(tt.args.name)
+			got, err := s.FetchCastMember(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchCastMember() error: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1,33 +1,38 @@
 package crud
 
 import (
-	"fmt"
-
 	"github.com/go-playground/validator/v10"
-	"github.com/go-playground/validator/v10/non-standard/validators"
 
 	"github.com/selmison/code-micro-videos/models"
-	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
 var categoryValidate *validator.Validate
 
 type CategoryDTO struct {
+	// ID is given by the repository, it is left out of the requests
+	ID          string     `json:"id,omitempty" schema:"-"`
 	Name        string     `json:"name" schema:"name" validate:"not_blank"`
 	Description string     `json:"description,omitempty" schema:"description"`
 	Genres      []GenreDTO `json:"genres" schema:"genres"`
 }
 
+// MapCategoryToDTO maps a category with its genres, which are left out when they are not loaded
 func MapCategoryToDTO(category models.Category) (*CategoryDTO, error) {
-	genreDTOs := make([]GenreDTO, len(category.R.Genres))
-	for i, genre := range category.R.Genres {
-		genreDTOs[i] = GenreDTO{
-			Name: genre.Name,
+	var genreDTOs []GenreDTO
+	if category.R != nil {
+		genreDTOs = make([]GenreDTO, len(category.R.Genres))
+		for i, genre := range category.R.Genres {
+			genreDTOs[i] = GenreDTO{
+				ID:   genre.ID,
+				Name: genre.Name,
+			}
 		}
 	}
 	dto := &CategoryDTO{
-		Name:   category.Name,
-		Genres: genreDTOs,
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description.String,
+		Genres:      genreDTOs,
 	}
 	if err := dto.Validate(); err != nil {
 		return nil, err
@@ -36,15 +41,11 @@ func MapCategoryToDTO(category models.Category) (*CategoryDTO, error) {
 }
 
 func (c *CategoryDTO) Validate() error {
-	err := categoryValidate.Struct(c)
-	if err != nil {
-		vErrs := err.(validator.ValidationErrors)
-		return fmt.Errorf("'%s' field %w", vErrs[0].StructField(), logger.ErrIsRequired)
-	}
-	return nil
+	var errs fieldErrors
+	errs.addStruct(categoryValidate, c)
+	return errs.err()
 }
 
 func init() {
-	categoryValidate = validator.New()
-	categoryValidate.RegisterValidation("not_blank", validators.NotBlank)
+	categoryValidate = newValidator()
 }
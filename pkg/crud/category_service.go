@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mock/service.go -package=mock . Repository,Service
+//go:generate mockgen -destination=./mock/service.go -package=mock . Repository,Service,AuditLog
 
 package crud
 
@@ -8,66 +8,115 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (s service) RemoveCategory(name string) error {
-	name = strings.ToLower(strings.TrimSpace(name))
-	if len(name) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) RemoveCategory(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if err := s.r.RemoveCategory(id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) GetCategoriesInTrash(page Page) (models.CategorySlice, PageInfo, error) {
+	if err := page.NormalizeNumbered("a trash"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return s.r.GetCategoriesInTrash(page)
+}
+
+func (s service) RestoreCategory(id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.r.RestoreCategory(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) PurgeCategory(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
 	}
-	if err := s.r.RemoveCategory(name); err != nil {
+	if err := s.r.PurgeCategory(id, version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) UpdateCategory(name string, dto CategoryDTO) error {
-	name = strings.ToLower(strings.TrimSpace(name))
-	if len(name) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) UpdateCategory(id string, version int64, dto CategoryDTO) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
 	}
-	dto.Name = strings.ToLower(strings.TrimSpace(dto.Name))
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	dto.Name = NormalizeName(dto.Name)
 	dto.Description = strings.TrimSpace(dto.Description)
 	if err := dto.Validate(); err != nil {
 		return err
 	}
-	if err := s.r.UpdateCategory(name, dto); err != nil {
+	if err := s.r.UpdateCategory(id, version, dto); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) AddCategory(dto CategoryDTO) error {
-	dto.Name = strings.ToLower(strings.TrimSpace(dto.Name))
+func (s service) AddCategory(dto CategoryDTO) (uuid.UUID, error) {
+	dto.Name = NormalizeName(dto.Name)
 	dto.Description = strings.TrimSpace(dto.Description)
 	for i := range dto.Genres {
-		dto.Genres[i].Name = strings.ToLower(strings.TrimSpace(dto.Genres[i].Name))
+		dto.Genres[i].Name = NormalizeName(dto.Genres[i].Name)
 	}
 	if err := dto.Validate(); err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
 	return s.r.AddCategory(dto)
 }
-func (s service) GetCategories(limit int) (models.CategorySlice, error) {
-	if limit < 0 {
-		return nil, logger.ErrInvalidedLimit
+func (s service) GetCategories(filter Filter, page Page) (models.CategorySlice, PageInfo, error) {
+	if err := filter.normalize(categorySortFields, &page); err != nil {
+		return nil, PageInfo{}, err
 	}
-	return s.r.GetCategories(limit)
+	return s.r.GetCategories(filter, page)
 }
 
-func (s service) FetchCategory(name string) (models.Category, error) {
-	name = strings.ToLower(strings.TrimSpace(name))
-	c, err := s.r.FetchCategory(name)
+func (s service) FetchCategory(id string) (models.Category, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return models.Category{}, err
+	}
+	c, err := s.r.FetchCategory(id)
 	if err == sql.ErrNoRows {
-		return models.Category{}, fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+		return models.Category{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 	}
 	return c, nil
 }
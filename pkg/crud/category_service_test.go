@@ -8,9 +8,11 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/volatiletech/null/v8"
 
 	"github.com/selmison/code-micro-videos/models"
@@ -30,6 +32,7 @@ func TestAddCategory(t *testing.T) {
 	fakeDescription := faker.Sentence()
 	fakeDoesNotExistGenre := crud.GenreDTO{Name: faker.FirstName()}
 	fakeExistGenreDTO := testdata.FakeGenresDTO[fakeGenreIndex]
+	fakeID := uuid.New()
 	type fields struct {
 		r sqlboiler.Repository
 	}
@@ -50,7 +53,7 @@ func TestAddCategory(t *testing.T) {
 		{
 			name:    "When CategoryDTO is not provided",
 			args:    args{crud.CategoryDTO{}},
-			want:    returns{err: fmt.Errorf("'Name' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -59,7 +62,7 @@ func TestAddCategory(t *testing.T) {
 				Name:        "    ",
 				Description: fakeDescription,
 			}},
-			want:    returns{err: fmt.Errorf("'Name' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -111,14 +114,17 @@ func TestAddCategory(t *testing.T) {
 				}
 				mockR.EXPECT().
 					AddCategory(dto).
-					Return(tt.want.err)
+					Return(fakeID, tt.want.err)
 			}
 			s := crud.NewService(mockR)
-			err := s.AddCategory(tt.args.dto)
+			id, err := s.AddCategory(tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddCategory() error = '%v', wantErr '%v'", err, tt.wantErr)
 				return
 			}
+			if !tt.wantErr && id != fakeID {
+				t.Errorf("AddCategory() id = '%v', want '%v'", id, fakeID)
+			}
 			if err != nil && err.Error() != tt.want.err.Error() {
 				t.Errorf("AddCategory() got = '%v', want '%v'", err, tt.want.err)
 			}
@@ -131,15 +137,15 @@ func Test_service_RemoveCategory(t *testing.T) {
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
 	indexRandom := rand.Intn(len(testdata.FakeCategories))
-	fakeNames := [2]string{
-		faker.FirstName(),
-		testdata.FakeCategories[indexRandom].Name,
+	fakeIDs := [2]string{
+		uuid.New().String(),
+		testdata.FakeCategories[indexRandom].ID,
 	}
 	type fields struct {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -149,35 +155,40 @@ func Test_service_RemoveCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is blank",
+			name:    "When id is blank",
 			args:    args{"     "},
-			want:    fmt.Errorf("'name' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
+			wantErr: true,
+		},
+		{
+			name:    "When id is not an uuid",
+			args:    args{"fake"},
+			want:    fmt.Errorf("%s: %w", "fake", logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When name is not found",
-			args:    args{fakeNames[0]},
-			want:    fmt.Errorf("%s: %w", fakeNames[0], logger.ErrNotFound),
+			name:    "When id is not found",
+			args:    args{fakeIDs[0]},
+			want:    fmt.Errorf("%s: %w", fakeIDs[0], logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeNames[1]},
+			name:    "When id is found",
+			args:    args{fakeIDs[1]},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "When name is not found" ||
-				tt.name == "When name is found" {
-				name := strings.ToLower(strings.TrimSpace(tt.args.name))
+			if tt.name == "When id is not found" ||
+				tt.name == "When id is found" {
 				mockR.EXPECT().
-					RemoveCategory(name).
+					RemoveCategory(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.RemoveCategory(tt.args.name)
+			err := s.RemoveCategory(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveCategory() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -193,11 +204,9 @@ func Test_service_UpdateCategory(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	const (
-		fakeExistName        = "fakeExistName"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-		fakeGenreIndex       = 0
-	)
+	const fakeGenreIndex = 0
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
 	fakeName := faker.FirstName()
 	fakeDescription := faker.Sentence()
 	fakeDoesNotExistGenre := crud.GenreDTO{Name: faker.FirstName()}
@@ -206,8 +215,8 @@ func Test_service_UpdateCategory(t *testing.T) {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
-		dto  crud.CategoryDTO
+		id  string
+		dto crud.CategoryDTO
 	}
 	tests := []struct {
 		name    string
@@ -217,7 +226,7 @@ func Test_service_UpdateCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is blank",
+			name: "When id is blank",
 			args: args{
 				"     ",
 				crud.CategoryDTO{
@@ -225,24 +234,24 @@ func Test_service_UpdateCategory(t *testing.T) {
 					Description: faker.Sentence(),
 				},
 			},
-			want:    fmt.Errorf("'name' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
 			wantErr: true,
 		},
 		{
 			name: "When the Name in CategoryDTO is blank",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.CategoryDTO{
 					Name:        "    ",
 					Description: fakeDescription,
 				}},
-			want:    fmt.Errorf("'Name' field %w", logger.ErrIsRequired),
+			want:    &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}},
 			wantErr: true,
 		},
 		{
 			name: "When CategoryDTO is with wrong genres",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.CategoryDTO{
 					Name:        fakeName,
 					Description: fakeDescription,
@@ -254,27 +263,27 @@ func Test_service_UpdateCategory(t *testing.T) {
 		},
 		{
 			name:    "When CategoryDTO is not provided",
-			args:    args{fakeExistName, crud.CategoryDTO{}},
-			want:    fmt.Errorf("'Name' field %w", logger.ErrIsRequired),
+			args:    args{fakeExistID, crud.CategoryDTO{}},
+			want:    &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}},
 			wantErr: true,
 		},
 		{
-			name: "When name is not found",
+			name: "When id is not found",
 			args: args{
-				fakeDoesNotExistName,
+				fakeDoesNotExistID,
 				crud.CategoryDTO{
 					Name:        faker.FirstName(),
 					Description: faker.Sentence(),
 					Genres:      []crud.GenreDTO{fakeExistGenreDTO},
 				},
 			},
-			want:    fmt.Errorf("%s: %w", fakeDoesNotExistName, logger.ErrNotFound),
+			want:    fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
 			name: "When CategoryDTO is right",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.CategoryDTO{
 					Name:        faker.FirstName(),
 					Description: faker.Sentence(),
@@ -289,20 +298,19 @@ func Test_service_UpdateCategory(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.name == "When the Name in CategoryDTO already exists" ||
 				tt.name == "When CategoryDTO is with wrong genres" ||
-				tt.name == "When name is not found" ||
+				tt.name == "When id is not found" ||
 				tt.name == "When CategoryDTO is right" {
-				name := strings.ToLower(strings.TrimSpace(tt.args.name))
 				dto := crud.CategoryDTO{
 					Name:        strings.ToLower(strings.TrimSpace(tt.args.dto.Name)),
 					Description: tt.args.dto.Description,
 					Genres:      tt.args.dto.Genres,
 				}
 				mockR.EXPECT().
-					UpdateCategory(name, dto).
+					UpdateCategory(tt.args.id, crud.AnyVersion, dto).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.UpdateCategory(tt.args.name, tt.args.dto)
+			err := s.UpdateCategory(tt.args.id, crud.AnyVersion, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateCategory() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -332,45 +340,93 @@ func Test_service_GetCategories(t *testing.T) {
 			Description: null.String{String: faker.Sentence(), Valid: true},
 		},
 	}
-	fakeLimit := len(fakeCategorySlice)
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeCategorySlice)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{time.Now()}, ID: uuid.New().String()}
+	fakeSortedFilter := crud.Filter{Sort: []crud.SortKey{{Field: "name", Desc: true}}}
 	type args struct {
-		limit int
+		filter crud.Filter
+		page   crud.Page
 	}
 	type returns struct {
 		cs models.CategorySlice
 		e  error
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    returns
-		wantErr bool
+		name       string
+		args       args
+		repoFilter crud.Filter
+		repoPage   crud.Page
+		want       returns
+		wantErr    bool
 	}{
 		{
-			name:    "When limit is less than zero",
-			args:    args{-1},
+			name:    "When per_page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{PerPage: -1}},
 			want:    returns{nil, logger.ErrInvalidedLimit},
 			wantErr: true,
 		},
 		{
-			name:    "When limit is right",
-			args:    args{fakeLimit},
-			want:    returns{fakeCategorySlice, nil},
-			wantErr: false,
+			name:    "When page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{Number: -1}},
+			want:    returns{nil, logger.ErrInvalidedLimit},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor and page are both given",
+			args:    args{crud.Filter{}, crud.Page{Cursor: fakeCursor, Number: 2}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When page is not given",
+			args:       args{crud.Filter{}, crud.Page{}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCategorySlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When per_page is above the maximum",
+			args:       args{crud.Filter{}, crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage + 1}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage},
+			want:       returns{fakeCategorySlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:    "When sort field is unknown",
+			args:    args{crud.Filter{Sort: []crud.SortKey{{Field: "fake_field"}}}, crud.Page{}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor is of another sort",
+			args:    args{fakeSortedFilter, crud.Page{Cursor: fakeCursor}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When the list is sorted",
+			args:       args{fakeSortedFilter, crud.Page{Number: 2}},
+			repoFilter: fakeSortedFilter,
+			repoPage:   crud.Page{Number: 2, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeCategorySlice, nil},
+			wantErr:    false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
-					GetCategories(tt.args.limit).
+					GetCategories(tt.repoFilter, tt.repoPage).
 					Return(
 						fakeCategorySlice,
+						fakePageInfo,
 						nil,
 					)
 			}
 			s := crud.NewService(mockR)
-			got, err := s.GetCategories(tt.args.limit)
+			got, info, err := s.GetCategories(tt.args.filter, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCategories() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -378,9 +434,12 @@ func Test_service_GetCategories(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want.cs) {
 				t.Errorf("GetCategories() got = %v, want %v", got, tt.want.cs)
 			}
-			if !reflect.DeepEqual(err, tt.want.e) {
+			if !errors.Is(err, tt.want.e) {
 				t.Errorf("GetCategories() got = %v, want %v", err, tt.want.e)
 			}
+			if !tt.wantErr && info != fakePageInfo {
+				t.Errorf("GetCategories() info = %+v, want %+v", info, fakePageInfo)
+			}
 		})
 	}
 }
@@ -390,12 +449,12 @@ func Test_service_FetchCategory(t *testing.T) {
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
 	indexRandom := rand.Intn(len(testdata.FakeCategories))
-	fakeNames := [2]string{
-		faker.FirstName(),
-		testdata.FakeCategories[indexRandom].Name,
+	fakeIDs := [2]string{
+		uuid.New().String(),
+		testdata.FakeCategories[indexRandom].ID,
 	}
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		c models.Category
@@ -408,20 +467,21 @@ func Test_service_FetchCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is not found",
-			args: args{fakeNames[0]},
+			name: "When id is not found",
+			args: args{fakeIDs[0]},
 			want: returns{
 				models.Category{},
-				fmt.Errorf("%s: %w", fakeNames[0], logger.ErrNotFound),
+				fmt.Errorf("%s: %w", fakeIDs[0], logger.ErrNotFound),
 			},
 			wantErr: true,
 		},
 		{
-			name: "When name is found",
-			args: args{fakeNames[1]},
+			name: "When id is found",
+			args: args{fakeIDs[1]},
 			want: returns{
 				models.Category{
-					Name: fakeNames[1],
+					ID:   fakeIDs[1],
+					Name: testdata.FakeCategories[indexRandom].Name,
 				},
 				nil,
 			},
@@ -432,21 +492,21 @@ func Test_service_FetchCategory(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
-					FetchCategory(tt.args.name).
+					FetchCategory(tt.args.id).
 					Return(
 						tt.want.c,
 						nil,
 					)
 			} else {
 				mockR.EXPECT().
-					FetchCategory(strings.ToLower(tt.args.name)).
+					FetchCategory(tt.args.id).
 					Return(
 						models.Category{},
 						sql.ErrNoRows,
 					)
 			}
 			s := crud.NewService(mockR)
-			got, err := s.FetchCategory(tt.args.name)
+			got, err := s.FetchCategory(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchCategory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,127 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// DefaultSort orders the lists by creation when no sort is asked
+var DefaultSort = []SortKey{{Field: "created_at"}}
+
+var (
+	castMemberSortFields = []string{"created_at"}
+	categorySortFields   = []string{"name", "created_at"}
+	genreSortFields      = []string{"name", "created_at"}
+	videoSortFields      = []string{"title", "year_launched", "rating", "duration", "created_at"}
+)
+
+// SortKey orders a list by one of its fields, named after their column
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Range bounds a number, a nil end leaves it open
+type Range struct {
+	Min *int
+	Max *int
+}
+
+// Filter narrows and orders a list, each list only reads the fields that apply to it
+type Filter struct {
+	// Name is the name of the cast members, the categories and the genres, or the title of the videos, whatever
+	// their case and the runs of spaces in them. NamePrefix is a prefix of it.
+	Name       string
+	NamePrefix string
+	// HasVideos keeps the categories and the genres with, or without, videos
+	HasVideos *bool
+	// Type keeps the cast members of a role
+	Type *CastMemberType
+
+	Category     string
+	Genre        string
+	Rating       *VideoRating
+	MaxRating    *VideoRating
+	YearLaunched Range
+	Opened       *bool
+	Duration     Range
+	// HasFile keeps the videos with, or without, a video file
+	HasFile *bool
+
+	// Sort applies its keys in order, ties are broken by id
+	Sort []SortKey
+}
+
+// ParseSort reads sort keys from a comma separated list of fields, prefixed by - for a descending order
+func ParseSort(s string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		key := SortKey{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+		if key.Field == "" {
+			return nil, fmt.Errorf("'sort' %w", logger.ErrIsNotValidated)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortString returns the sort of the filter in the form read by ParseSort
+func (f Filter) SortString() string {
+	fields := make([]string, len(f.Sort))
+	for i, key := range f.Sort {
+		fields[i] = key.Field
+		if key.Desc {
+			fields[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// normalize checks the filter against the fields a list can be sorted by and pairs it with page
+func (f *Filter) normalize(sortFields []string, page *Page) error {
+	if len(f.Sort) == 0 {
+		f.Sort = DefaultSort
+	}
+	seen := make(map[string]bool, len(f.Sort))
+	for _, key := range f.Sort {
+		if seen[key.Field] || !contains(sortFields, key.Field) {
+			return fmt.Errorf("sort field '%s' %w", key.Field, logger.ErrIsNotValidated)
+		}
+		seen[key.Field] = true
+	}
+	for _, rating := range []*VideoRating{f.Rating, f.MaxRating} {
+		if rating != nil {
+			if err := rating.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if f.Type != nil {
+		if err := f.Type.Validate(); err != nil {
+			return err
+		}
+	}
+	f.Name = NormalizeName(f.Name)
+	f.NamePrefix = strings.ToLower(strings.TrimSpace(f.NamePrefix))
+	f.Category = NormalizeName(f.Category)
+	f.Genre = NormalizeName(f.Genre)
+	if err := page.Normalize(); err != nil {
+		return err
+	}
+	if page.Cursor != nil && (page.Cursor.Sort != f.SortString() || len(page.Cursor.Keys) != len(f.Sort)) {
+		return fmt.Errorf("cursor of another sort %w", logger.ErrIsNotValidated)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
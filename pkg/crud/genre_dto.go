@@ -1,30 +1,33 @@
 package crud
 
 import (
-	"fmt"
-
 	"github.com/go-playground/validator/v10"
-	"github.com/go-playground/validator/v10/non-standard/validators"
 
 	"github.com/selmison/code-micro-videos/models"
-	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
 var genreValidate *validator.Validate
 
 type GenreDTO struct {
+	ID         string        `json:"id,omitempty" schema:"-"`
 	Name       string        `json:"name" schema:"name" validate:"not_blank"`
 	Categories []CategoryDTO `json:"categories" schema:"categories"`
 }
 
+// MapGenreToDTO maps a genre with its categories, which are left out when they are not loaded
 func MapGenreToDTO(genre models.Genre) (*GenreDTO, error) {
-	categoryDTOs := make([]CategoryDTO, len(genre.R.Categories))
-	for i, genre := range genre.R.Categories {
-		categoryDTOs[i] = CategoryDTO{
-			Name: genre.Name,
+	var categoryDTOs []CategoryDTO
+	if genre.R != nil {
+		categoryDTOs = make([]CategoryDTO, len(genre.R.Categories))
+		for i, category := range genre.R.Categories {
+			categoryDTOs[i] = CategoryDTO{
+				ID:   category.ID,
+				Name: category.Name,
+			}
 		}
 	}
 	dto := &GenreDTO{
+		ID:         genre.ID,
 		Name:       genre.Name,
 		Categories: categoryDTOs,
 	}
@@ -35,15 +38,11 @@ func MapGenreToDTO(genre models.Genre) (*GenreDTO, error) {
 }
 
 func (c *GenreDTO) Validate() error {
-	err := genreValidate.Struct(c)
-	if err != nil {
-		vErrs := err.(validator.ValidationErrors)
-		return fmt.Errorf("'%s' field %w", vErrs[0].StructField(), logger.ErrIsRequired)
-	}
-	return nil
+	var errs fieldErrors
+	errs.addStruct(genreValidate, c)
+	return errs.err()
 }
 
 func init() {
-	genreValidate = validator.New()
-	genreValidate.RegisterValidation("not_blank", validators.NotBlank)
+	genreValidate = newValidator()
 }
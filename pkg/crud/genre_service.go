@@ -4,65 +4,113 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
+
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (s service) RemoveGenre(name string) error {
-	name = strings.ToLower(strings.TrimSpace(name))
-	if len(name) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) RemoveGenre(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
 	}
-	if err := s.r.RemoveGenre(name); err != nil {
+	if err := s.r.RemoveGenre(id, version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) UpdateGenre(name string, genreDTO GenreDTO) error {
-	name = strings.ToLower(strings.TrimSpace(name))
-	if len(name) == 0 {
-		return fmt.Errorf("'name' %w", logger.ErrIsRequired)
+func (s service) GetGenresInTrash(page Page) (models.GenreSlice, PageInfo, error) {
+	if err := page.NormalizeNumbered("a trash"); err != nil {
+		return nil, PageInfo{}, err
 	}
-	if err := genreDTO.Validate(); err != nil {
+	return s.r.GetGenresInTrash(page)
+}
+
+func (s service) RestoreGenre(id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.r.RestoreGenre(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) PurgeGenre(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
 		return err
 	}
-	genreDTO.Name = strings.ToLower(strings.TrimSpace(genreDTO.Name))
-	if err := s.r.UpdateGenre(name, genreDTO); err != nil {
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if err := s.r.PurgeGenre(id, version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) AddGenre(genreDTO GenreDTO) error {
-	genreDTO.Name = strings.ToLower(strings.TrimSpace(genreDTO.Name))
+func (s service) UpdateGenre(id string, version int64, genreDTO GenreDTO) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
 	if err := genreDTO.Validate(); err != nil {
 		return err
 	}
+	genreDTO.Name = NormalizeName(genreDTO.Name)
+	if err := s.r.UpdateGenre(id, version, genreDTO); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) AddGenre(genreDTO GenreDTO) (uuid.UUID, error) {
+	genreDTO.Name = NormalizeName(genreDTO.Name)
+	if err := genreDTO.Validate(); err != nil {
+		return uuid.UUID{}, err
+	}
 	return s.r.AddGenre(genreDTO)
 }
-func (s service) GetGenres(limit int) (models.GenreSlice, error) {
-	if limit < 0 {
-		return nil, logger.ErrInvalidedLimit
+func (s service) GetGenres(filter Filter, page Page) (models.GenreSlice, PageInfo, error) {
+	if err := filter.normalize(genreSortFields, &page); err != nil {
+		return nil, PageInfo{}, err
 	}
-	return s.r.GetGenres(limit)
+	return s.r.GetGenres(filter, page)
 }
 
-func (s service) FetchGenre(name string) (models.Genre, error) {
-	name = strings.ToLower(strings.TrimSpace(name))
-	c, err := s.r.FetchGenre(name)
+func (s service) FetchGenre(id string) (models.Genre, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return models.Genre{}, err
+	}
+	c, err := s.r.FetchGenre(id)
 	if err == sql.ErrNoRows {
-		return models.Genre{}, fmt.Errorf("%s: %w", name, logger.ErrNotFound)
+		return models.Genre{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 	} else if err != nil {
-		return models.Genre{}, fmt.Errorf("%s: %w", name, logger.ErrInternalApplication)
+		return models.Genre{}, fmt.Errorf("%s: %w", id, logger.ErrInternalApplication)
 	}
 
 	return c, nil
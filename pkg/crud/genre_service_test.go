@@ -8,9 +8,11 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/crud"
@@ -26,6 +28,7 @@ func TestAddGenre(t *testing.T) {
 	mockR := mock.NewMockRepository(ctrl)
 	fakeName := strings.ToLower(faker.FirstName())
 	fakeDoesNotExistCategory := crud.CategoryDTO{Name: faker.FirstName()}
+	fakeID := uuid.New()
 	type fields struct {
 		r sqlboiler.Repository
 	}
@@ -46,7 +49,7 @@ func TestAddGenre(t *testing.T) {
 		{
 			name:    "When GenreDTO is not provided",
 			args:    args{crud.GenreDTO{}},
-			want:    returns{err: fmt.Errorf("'Name' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -54,7 +57,7 @@ func TestAddGenre(t *testing.T) {
 			args: args{crud.GenreDTO{
 				Name: "    ",
 			}},
-			want:    returns{err: fmt.Errorf("'Name' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -100,14 +103,17 @@ func TestAddGenre(t *testing.T) {
 				}
 				mockR.EXPECT().
 					AddGenre(dto).
-					Return(tt.want.err)
+					Return(fakeID, tt.want.err)
 			}
 			s := crud.NewService(mockR)
-			err := s.AddGenre(tt.args.dto)
+			id, err := s.AddGenre(tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddGenre() error = '%v', wantErr '%v'", err, tt.wantErr)
 				return
 			}
+			if !tt.wantErr && id != fakeID {
+				t.Errorf("AddGenre() id = '%v', want '%v'", id, fakeID)
+			}
 			if err != nil && err.Error() != tt.want.err.Error() {
 				t.Errorf("AddGenre() got = '%v', want '%v'", err, tt.want.err)
 			}
@@ -119,16 +125,16 @@ func Test_service_RemoveGenre(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	indexRandom := rand.Intn(len(testdata.FakeCategories))
-	fakeNames := [2]string{
-		faker.FirstName(),
-		testdata.FakeCategories[indexRandom].Name,
+	indexRandom := rand.Intn(len(testdata.FakeGenres))
+	fakeIDs := [2]string{
+		uuid.New().String(),
+		testdata.FakeGenres[indexRandom].ID,
 	}
 	type fields struct {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -138,35 +144,40 @@ func Test_service_RemoveGenre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is blank",
+			name:    "When id is blank",
 			args:    args{"     "},
-			want:    fmt.Errorf("'name' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
+			wantErr: true,
+		},
+		{
+			name:    "When id is not an uuid",
+			args:    args{"fake"},
+			want:    fmt.Errorf("%s: %w", "fake", logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When name is not found",
-			args:    args{fakeNames[0]},
-			want:    fmt.Errorf("%s: %w", fakeNames[0], logger.ErrNotFound),
+			name:    "When id is not found",
+			args:    args{fakeIDs[0]},
+			want:    fmt.Errorf("%s: %w", fakeIDs[0], logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeNames[1]},
+			name:    "When id is found",
+			args:    args{fakeIDs[1]},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "When name is not found" ||
-				tt.name == "When name is found" {
-				name := strings.ToLower(strings.TrimSpace(tt.args.name))
+			if tt.name == "When id is not found" ||
+				tt.name == "When id is found" {
 				mockR.EXPECT().
-					RemoveGenre(name).
+					RemoveGenre(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.RemoveGenre(tt.args.name)
+			err := s.RemoveGenre(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveGenre() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -182,11 +193,9 @@ func Test_service_UpdateGenre(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	const (
-		fakeExistName        = "fakeExistName"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-		fakeCategoryIndex    = 0
-	)
+	const fakeCategoryIndex = 0
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
 	fakeName := faker.FirstName()
 	fakeDoesNotExistCategoryDTO := crud.CategoryDTO{Name: faker.FirstName()}
 	fakeExistCategoryDTO := testdata.FakeCategoriesDTO[fakeCategoryIndex]
@@ -194,8 +203,8 @@ func Test_service_UpdateGenre(t *testing.T) {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		name string
-		dto  crud.GenreDTO
+		id  string
+		dto crud.GenreDTO
 	}
 	tests := []struct {
 		name    string
@@ -205,30 +214,30 @@ func Test_service_UpdateGenre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is blank",
+			name: "When id is blank",
 			args: args{
 				"     ",
 				crud.GenreDTO{
 					Name: faker.FirstName(),
 				},
 			},
-			want:    fmt.Errorf("'name' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
 			wantErr: true,
 		},
 		{
 			name: "When the Name in CategoryDTO is blank",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.GenreDTO{
 					Name: "    ",
 				}},
-			want:    fmt.Errorf("'Name' field %w", logger.ErrIsRequired),
+			want:    &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}},
 			wantErr: true,
 		},
 		{
 			name: "When GenreDTO is with wrong genres",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.GenreDTO{
 					Name:       fakeName,
 					Categories: []crud.CategoryDTO{fakeDoesNotExistCategoryDTO},
@@ -239,26 +248,26 @@ func Test_service_UpdateGenre(t *testing.T) {
 		},
 		{
 			name:    "When GenreDTO is not provided",
-			args:    args{fakeExistName, crud.GenreDTO{}},
-			want:    fmt.Errorf("'Name' field %w", logger.ErrIsRequired),
+			args:    args{fakeExistID, crud.GenreDTO{}},
+			want:    &crud.ValidationError{Fields: []crud.FieldError{{Field: "name", Rule: "not_blank"}}},
 			wantErr: true,
 		},
 		{
-			name: "When name is not found",
+			name: "When id is not found",
 			args: args{
-				fakeDoesNotExistName,
+				fakeDoesNotExistID,
 				crud.GenreDTO{
 					Name:       faker.FirstName(),
 					Categories: []crud.CategoryDTO{fakeExistCategoryDTO},
 				},
 			},
-			want:    fmt.Errorf("%s: %w", fakeDoesNotExistName, logger.ErrNotFound),
+			want:    fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
 			name: "When GenreDTO is right",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.GenreDTO{
 					Name:       faker.FirstName(),
 					Categories: []crud.CategoryDTO{fakeDoesNotExistCategoryDTO},
@@ -272,19 +281,18 @@ func Test_service_UpdateGenre(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.name == "When the Name in GenreDTO already exists" ||
 				tt.name == "When GenreDTO is with wrong genres" ||
-				tt.name == "When name is not found" ||
+				tt.name == "When id is not found" ||
 				tt.name == "When GenreDTO is right" {
-				name := strings.ToLower(strings.TrimSpace(tt.args.name))
 				dto := crud.GenreDTO{
 					Name:       strings.ToLower(strings.TrimSpace(tt.args.dto.Name)),
 					Categories: tt.args.dto.Categories,
 				}
 				mockR.EXPECT().
-					UpdateGenre(name, dto).
+					UpdateGenre(tt.args.id, crud.AnyVersion, dto).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.UpdateGenre(tt.args.name, tt.args.dto)
+			err := s.UpdateGenre(tt.args.id, crud.AnyVersion, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateGenre() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -311,45 +319,93 @@ func Test_service_GetGenres(t *testing.T) {
 			Name: "animation",
 		},
 	}
-	fakeLimit := len(fakeGenreSlice)
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeGenreSlice)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{time.Now()}, ID: uuid.New().String()}
+	fakeSortedFilter := crud.Filter{Sort: []crud.SortKey{{Field: "name", Desc: true}}}
 	type args struct {
-		limit int
+		filter crud.Filter
+		page   crud.Page
 	}
 	type returns struct {
 		cs models.GenreSlice
 		e  error
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    returns
-		wantErr bool
+		name       string
+		args       args
+		repoFilter crud.Filter
+		repoPage   crud.Page
+		want       returns
+		wantErr    bool
 	}{
 		{
-			name:    "When limit is less than zero",
-			args:    args{-1},
+			name:    "When per_page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{PerPage: -1}},
 			want:    returns{nil, logger.ErrInvalidedLimit},
 			wantErr: true,
 		},
 		{
-			name:    "When limit is right",
-			args:    args{fakeLimit},
-			want:    returns{fakeGenreSlice, nil},
-			wantErr: false,
+			name:    "When page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{Number: -1}},
+			want:    returns{nil, logger.ErrInvalidedLimit},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor and page are both given",
+			args:    args{crud.Filter{}, crud.Page{Cursor: fakeCursor, Number: 2}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When page is not given",
+			args:       args{crud.Filter{}, crud.Page{}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeGenreSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When per_page is above the maximum",
+			args:       args{crud.Filter{}, crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage + 1}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage},
+			want:       returns{fakeGenreSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:    "When sort field is unknown",
+			args:    args{crud.Filter{Sort: []crud.SortKey{{Field: "fake_field"}}}, crud.Page{}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor is of another sort",
+			args:    args{fakeSortedFilter, crud.Page{Cursor: fakeCursor}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When the list is sorted",
+			args:       args{fakeSortedFilter, crud.Page{Number: 2}},
+			repoFilter: fakeSortedFilter,
+			repoPage:   crud.Page{Number: 2, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeGenreSlice, nil},
+			wantErr:    false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
-					GetGenres(tt.args.limit).
+					GetGenres(tt.repoFilter, tt.repoPage).
 					Return(
 						fakeGenreSlice,
+						fakePageInfo,
 						nil,
 					)
 			}
 			s := crud.NewService(mockR)
-			got, err := s.GetGenres(tt.args.limit)
+			got, info, err := s.GetGenres(tt.args.filter, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetGenres() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -357,9 +413,12 @@ func Test_service_GetGenres(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want.cs) {
 				t.Errorf("GetGenres() got = %v, want %v", got, tt.want.cs)
 			}
-			if !reflect.DeepEqual(err, tt.want.e) {
+			if !errors.Is(err, tt.want.e) {
 				t.Errorf("GetGenres() got = %v, want %v", err, tt.want.e)
 			}
+			if !tt.wantErr && info != fakePageInfo {
+				t.Errorf("GetGenres() info = %+v, want %+v", info, fakePageInfo)
+			}
 		})
 	}
 }
@@ -368,11 +427,13 @@ func Test_service_FetchGenre(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	fakeDoesNotExistName := "fakeDoesNotExistName"
+	fakeAnyID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeExistID := uuid.New().String()
 	fakeExistName := "action"
 	fakeErrorInternalApplication := fmt.Errorf("Service.FetchGenre(): %w", logger.ErrInternalApplication)
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		c models.Genre
@@ -387,7 +448,7 @@ func Test_service_FetchGenre(t *testing.T) {
 	}{
 		{
 			name: "When throw the error internal application",
-			args: args{"anyName"},
+			args: args{fakeAnyID},
 			want: returns{
 				models.Genre{},
 				fakeErrorInternalApplication,
@@ -395,7 +456,7 @@ func Test_service_FetchGenre(t *testing.T) {
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchGenre("anyname").
+					FetchGenre(fakeAnyID).
 					Return(
 						models.Genre{},
 						fakeErrorInternalApplication,
@@ -403,16 +464,16 @@ func Test_service_FetchGenre(t *testing.T) {
 			},
 		},
 		{
-			name: "When name is not found",
-			args: args{fakeDoesNotExistName},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.Genre{},
-				fmt.Errorf("%s: %w", fakeDoesNotExistName, logger.ErrNotFound),
+				fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			},
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchGenre(strings.ToLower(strings.TrimSpace(fakeDoesNotExistName))).
+					FetchGenre(fakeDoesNotExistID).
 					Return(
 						models.Genre{},
 						sql.ErrNoRows,
@@ -420,8 +481,8 @@ func Test_service_FetchGenre(t *testing.T) {
 			},
 		},
 		{
-			name: "When name is found",
-			args: args{fakeExistName},
+			name: "When id is found",
+			args: args{fakeExistID},
 			want: returns{
 				models.Genre{
 					Name: fakeExistName,
@@ -431,7 +492,7 @@ func Test_service_FetchGenre(t *testing.T) {
 			wantErr: false,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchGenre(fakeExistName).
+					FetchGenre(fakeExistID).
 					Return(
 						models.Genre{
 							Name: fakeExistName,
@@ -445,7 +506,7 @@ func Test_service_FetchGenre(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMockR()
 			s := crud.NewService(mockR)
-			got, err := s.FetchGenre(tt.args.name)
+			got, err := s.FetchGenre(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchGenre() error: %v, wantErr %v", err, tt.wantErr)
 				return
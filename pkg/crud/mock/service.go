@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/selmison/code-micro-videos/pkg/crud (interfaces: Repository,Service)
+// Source: github.com/selmison/code-micro-videos/pkg/crud (interfaces: Repository,Service,AuditLog)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -9,6 +9,7 @@ import (
 	uuid "github.com/google/uuid"
 	models "github.com/selmison/code-micro-videos/models"
 	crud "github.com/selmison/code-micro-videos/pkg/crud"
+	io "io"
 	reflect "reflect"
 )
 
@@ -36,11 +37,12 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // AddCastMember mocks base method
-func (m *MockRepository) AddCastMember(arg0 crud.CastMemberDTO) error {
+func (m *MockRepository) AddCastMember(arg0 crud.CastMemberDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddCastMember", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddCastMember indicates an expected call of AddCastMember
@@ -50,11 +52,12 @@ func (mr *MockRepositoryMockRecorder) AddCastMember(arg0 interface{}) *gomock.Ca
 }
 
 // AddCategory mocks base method
-func (m *MockRepository) AddCategory(arg0 crud.CategoryDTO) error {
+func (m *MockRepository) AddCategory(arg0 crud.CategoryDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddCategory", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddCategory indicates an expected call of AddCategory
@@ -64,11 +67,12 @@ func (mr *MockRepositoryMockRecorder) AddCategory(arg0 interface{}) *gomock.Call
 }
 
 // AddGenre mocks base method
-func (m *MockRepository) AddGenre(arg0 crud.GenreDTO) error {
+func (m *MockRepository) AddGenre(arg0 crud.GenreDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddGenre", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddGenre indicates an expected call of AddGenre
@@ -92,6 +96,20 @@ func (mr *MockRepositoryMockRecorder) AddVideo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVideo", reflect.TypeOf((*MockRepository)(nil).AddVideo), arg0)
 }
 
+// AttachVideoAsset mocks base method
+func (m *MockRepository) AttachVideoAsset(arg0 string, arg1 crud.AssetKind, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachVideoAsset", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachVideoAsset indicates an expected call of AttachVideoAsset
+func (mr *MockRepositoryMockRecorder) AttachVideoAsset(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachVideoAsset", reflect.TypeOf((*MockRepository)(nil).AttachVideoAsset), arg0, arg1, arg2)
+}
+
 // FetchCastMember mocks base method
 func (m *MockRepository) FetchCastMember(arg0 string) (models.CastMember, error) {
 	m.ctrl.T.Helper()
@@ -152,177 +170,404 @@ func (mr *MockRepositoryMockRecorder) FetchVideo(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVideo", reflect.TypeOf((*MockRepository)(nil).FetchVideo), arg0)
 }
 
+// GetCastMemberVideos mocks base method
+func (m *MockRepository) GetCastMemberVideos(arg0 string, arg1 crud.Page) (models.CastMemberVideoSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastMemberVideos", arg0, arg1)
+	ret0, _ := ret[0].(models.CastMemberVideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCastMemberVideos indicates an expected call of GetCastMemberVideos
+func (mr *MockRepositoryMockRecorder) GetCastMemberVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMemberVideos", reflect.TypeOf((*MockRepository)(nil).GetCastMemberVideos), arg0, arg1)
+}
+
 // GetCastMembers mocks base method
-func (m *MockRepository) GetCastMembers(arg0 int) (models.CastMemberSlice, error) {
+func (m *MockRepository) GetCastMembers(arg0 crud.Filter, arg1 crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCastMembers", arg0)
+	ret := m.ctrl.Call(m, "GetCastMembers", arg0, arg1)
 	ret0, _ := ret[0].(models.CastMemberSlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetCastMembers indicates an expected call of GetCastMembers
-func (mr *MockRepositoryMockRecorder) GetCastMembers(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetCastMembers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembers", reflect.TypeOf((*MockRepository)(nil).GetCastMembers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembers", reflect.TypeOf((*MockRepository)(nil).GetCastMembers), arg0, arg1)
+}
+
+// GetCastMembersInTrash mocks base method
+func (m *MockRepository) GetCastMembersInTrash(arg0 crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastMembersInTrash", arg0)
+	ret0, _ := ret[0].(models.CastMemberSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCastMembersInTrash indicates an expected call of GetCastMembersInTrash
+func (mr *MockRepositoryMockRecorder) GetCastMembersInTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembersInTrash", reflect.TypeOf((*MockRepository)(nil).GetCastMembersInTrash), arg0)
 }
 
 // GetCategories mocks base method
-func (m *MockRepository) GetCategories(arg0 int) (models.CategorySlice, error) {
+func (m *MockRepository) GetCategories(arg0 crud.Filter, arg1 crud.Page) (models.CategorySlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategories", arg0)
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
 	ret0, _ := ret[0].(models.CategorySlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetCategories indicates an expected call of GetCategories
-func (mr *MockRepositoryMockRecorder) GetCategories(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetCategories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockRepository)(nil).GetCategories), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockRepository)(nil).GetCategories), arg0, arg1)
+}
+
+// GetCategoriesInTrash mocks base method
+func (m *MockRepository) GetCategoriesInTrash(arg0 crud.Page) (models.CategorySlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesInTrash", arg0)
+	ret0, _ := ret[0].(models.CategorySlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCategoriesInTrash indicates an expected call of GetCategoriesInTrash
+func (mr *MockRepositoryMockRecorder) GetCategoriesInTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesInTrash", reflect.TypeOf((*MockRepository)(nil).GetCategoriesInTrash), arg0)
 }
 
 // GetGenres mocks base method
-func (m *MockRepository) GetGenres(arg0 int) (models.GenreSlice, error) {
+func (m *MockRepository) GetGenres(arg0 crud.Filter, arg1 crud.Page) (models.GenreSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenres", arg0)
+	ret := m.ctrl.Call(m, "GetGenres", arg0, arg1)
 	ret0, _ := ret[0].(models.GenreSlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetGenres indicates an expected call of GetGenres
-func (mr *MockRepositoryMockRecorder) GetGenres(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetGenres(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockRepository)(nil).GetGenres), arg0, arg1)
+}
+
+// GetGenresInTrash mocks base method
+func (m *MockRepository) GetGenresInTrash(arg0 crud.Page) (models.GenreSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenresInTrash", arg0)
+	ret0, _ := ret[0].(models.GenreSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGenresInTrash indicates an expected call of GetGenresInTrash
+func (mr *MockRepositoryMockRecorder) GetGenresInTrash(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockRepository)(nil).GetGenres), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenresInTrash", reflect.TypeOf((*MockRepository)(nil).GetGenresInTrash), arg0)
 }
 
 // GetVideos mocks base method
-func (m *MockRepository) GetVideos(arg0 int) (models.VideoSlice, error) {
+func (m *MockRepository) GetVideos(arg0 crud.Filter, arg1 crud.Page) (models.VideoSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideos", arg0, arg1)
+	ret0, _ := ret[0].(models.VideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVideos indicates an expected call of GetVideos
+func (mr *MockRepositoryMockRecorder) GetVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockRepository)(nil).GetVideos), arg0, arg1)
+}
+
+// GetVideosInTrash mocks base method
+func (m *MockRepository) GetVideosInTrash(arg0 crud.Page) (models.VideoSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVideos", arg0)
+	ret := m.ctrl.Call(m, "GetVideosInTrash", arg0)
 	ret0, _ := ret[0].(models.VideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVideosInTrash indicates an expected call of GetVideosInTrash
+func (mr *MockRepositoryMockRecorder) GetVideosInTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideosInTrash", reflect.TypeOf((*MockRepository)(nil).GetVideosInTrash), arg0)
+}
+
+// OpenVideoAsset mocks base method
+func (m *MockRepository) OpenVideoAsset(arg0 string, arg1 crud.AssetKind) (crud.VideoFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenVideoAsset", arg0, arg1)
+	ret0, _ := ret[0].(crud.VideoFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetVideos indicates an expected call of GetVideos
-func (mr *MockRepositoryMockRecorder) GetVideos(arg0 interface{}) *gomock.Call {
+// OpenVideoAsset indicates an expected call of OpenVideoAsset
+func (mr *MockRepositoryMockRecorder) OpenVideoAsset(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockRepository)(nil).GetVideos), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenVideoAsset", reflect.TypeOf((*MockRepository)(nil).OpenVideoAsset), arg0, arg1)
+}
+
+// PurgeCastMember mocks base method
+func (m *MockRepository) PurgeCastMember(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCastMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCastMember indicates an expected call of PurgeCastMember
+func (mr *MockRepositoryMockRecorder) PurgeCastMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCastMember", reflect.TypeOf((*MockRepository)(nil).PurgeCastMember), arg0, arg1)
+}
+
+// PurgeCategory mocks base method
+func (m *MockRepository) PurgeCategory(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCategory indicates an expected call of PurgeCategory
+func (mr *MockRepositoryMockRecorder) PurgeCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCategory", reflect.TypeOf((*MockRepository)(nil).PurgeCategory), arg0, arg1)
+}
+
+// PurgeGenre mocks base method
+func (m *MockRepository) PurgeGenre(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeGenre", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeGenre indicates an expected call of PurgeGenre
+func (mr *MockRepositoryMockRecorder) PurgeGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeGenre", reflect.TypeOf((*MockRepository)(nil).PurgeGenre), arg0, arg1)
+}
+
+// PurgeVideo mocks base method
+func (m *MockRepository) PurgeVideo(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeVideo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeVideo indicates an expected call of PurgeVideo
+func (mr *MockRepositoryMockRecorder) PurgeVideo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeVideo", reflect.TypeOf((*MockRepository)(nil).PurgeVideo), arg0, arg1)
 }
 
 // RemoveCastMember mocks base method
-func (m *MockRepository) RemoveCastMember(arg0 string) error {
+func (m *MockRepository) RemoveCastMember(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveCastMember", arg0)
+	ret := m.ctrl.Call(m, "RemoveCastMember", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveCastMember indicates an expected call of RemoveCastMember
-func (mr *MockRepositoryMockRecorder) RemoveCastMember(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) RemoveCastMember(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCastMember", reflect.TypeOf((*MockRepository)(nil).RemoveCastMember), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCastMember", reflect.TypeOf((*MockRepository)(nil).RemoveCastMember), arg0, arg1)
 }
 
 // RemoveCategory mocks base method
-func (m *MockRepository) RemoveCategory(arg0 string) error {
+func (m *MockRepository) RemoveCategory(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveCategory", arg0)
+	ret := m.ctrl.Call(m, "RemoveCategory", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveCategory indicates an expected call of RemoveCategory
-func (mr *MockRepositoryMockRecorder) RemoveCategory(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) RemoveCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockRepository)(nil).RemoveCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockRepository)(nil).RemoveCategory), arg0, arg1)
 }
 
 // RemoveGenre mocks base method
-func (m *MockRepository) RemoveGenre(arg0 string) error {
+func (m *MockRepository) RemoveGenre(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveGenre", arg0)
+	ret := m.ctrl.Call(m, "RemoveGenre", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveGenre indicates an expected call of RemoveGenre
-func (mr *MockRepositoryMockRecorder) RemoveGenre(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) RemoveGenre(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenre", reflect.TypeOf((*MockRepository)(nil).RemoveGenre), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenre", reflect.TypeOf((*MockRepository)(nil).RemoveGenre), arg0, arg1)
 }
 
 // RemoveVideo mocks base method
-func (m *MockRepository) RemoveVideo(arg0 string) error {
+func (m *MockRepository) RemoveVideo(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveVideo", arg0)
+	ret := m.ctrl.Call(m, "RemoveVideo", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveVideo indicates an expected call of RemoveVideo
-func (mr *MockRepositoryMockRecorder) RemoveVideo(arg0 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) RemoveVideo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVideo", reflect.TypeOf((*MockRepository)(nil).RemoveVideo), arg0, arg1)
+}
+
+// RestoreCastMember mocks base method
+func (m *MockRepository) RestoreCastMember(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCastMember", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCastMember indicates an expected call of RestoreCastMember
+func (mr *MockRepositoryMockRecorder) RestoreCastMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCastMember", reflect.TypeOf((*MockRepository)(nil).RestoreCastMember), arg0)
+}
+
+// RestoreCategory mocks base method
+func (m *MockRepository) RestoreCategory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory
+func (mr *MockRepositoryMockRecorder) RestoreCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockRepository)(nil).RestoreCategory), arg0)
+}
+
+// RestoreGenre mocks base method
+func (m *MockRepository) RestoreGenre(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreGenre", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreGenre indicates an expected call of RestoreGenre
+func (mr *MockRepositoryMockRecorder) RestoreGenre(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreGenre", reflect.TypeOf((*MockRepository)(nil).RestoreGenre), arg0)
+}
+
+// RestoreVideo mocks base method
+func (m *MockRepository) RestoreVideo(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVideo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreVideo indicates an expected call of RestoreVideo
+func (mr *MockRepositoryMockRecorder) RestoreVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVideo", reflect.TypeOf((*MockRepository)(nil).RestoreVideo), arg0)
+}
+
+// SearchVideos mocks base method
+func (m *MockRepository) SearchVideos(arg0 crud.VideoSearch, arg1 crud.Page) ([]crud.VideoMatch, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchVideos", arg0, arg1)
+	ret0, _ := ret[0].([]crud.VideoMatch)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchVideos indicates an expected call of SearchVideos
+func (mr *MockRepositoryMockRecorder) SearchVideos(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVideo", reflect.TypeOf((*MockRepository)(nil).RemoveVideo), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchVideos", reflect.TypeOf((*MockRepository)(nil).SearchVideos), arg0, arg1)
 }
 
 // UpdateCastMember mocks base method
-func (m *MockRepository) UpdateCastMember(arg0 string, arg1 crud.CastMemberDTO) error {
+func (m *MockRepository) UpdateCastMember(arg0 string, arg1 int64, arg2 crud.CastMemberDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCastMember", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateCastMember", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCastMember indicates an expected call of UpdateCastMember
-func (mr *MockRepositoryMockRecorder) UpdateCastMember(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateCastMember(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCastMember", reflect.TypeOf((*MockRepository)(nil).UpdateCastMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCastMember", reflect.TypeOf((*MockRepository)(nil).UpdateCastMember), arg0, arg1, arg2)
 }
 
 // UpdateCategory mocks base method
-func (m *MockRepository) UpdateCategory(arg0 string, arg1 crud.CategoryDTO) error {
+func (m *MockRepository) UpdateCategory(arg0 string, arg1 int64, arg2 crud.CategoryDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory
-func (mr *MockRepositoryMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateCategory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockRepository)(nil).UpdateCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockRepository)(nil).UpdateCategory), arg0, arg1, arg2)
 }
 
 // UpdateGenre mocks base method
-func (m *MockRepository) UpdateGenre(arg0 string, arg1 crud.GenreDTO) error {
+func (m *MockRepository) UpdateGenre(arg0 string, arg1 int64, arg2 crud.GenreDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateGenre indicates an expected call of UpdateGenre
-func (mr *MockRepositoryMockRecorder) UpdateGenre(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateGenre(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockRepository)(nil).UpdateGenre), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockRepository)(nil).UpdateGenre), arg0, arg1, arg2)
 }
 
 // UpdateVideo mocks base method
-func (m *MockRepository) UpdateVideo(arg0 string, arg1 crud.VideoDTO) (uuid.UUID, error) {
+func (m *MockRepository) UpdateVideo(arg0 string, arg1 int64, arg2 crud.VideoDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateVideo", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateVideo", arg0, arg1, arg2)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateVideo indicates an expected call of UpdateVideo
-func (mr *MockRepositoryMockRecorder) UpdateVideo(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) UpdateVideo(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockRepository)(nil).UpdateVideo), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockRepository)(nil).UpdateVideo), arg0, arg1, arg2)
 }
 
 // MockService is a mock of Service interface
@@ -349,11 +594,12 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // AddCastMember mocks base method
-func (m *MockService) AddCastMember(arg0 crud.CastMemberDTO) error {
+func (m *MockService) AddCastMember(arg0 crud.CastMemberDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddCastMember", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddCastMember indicates an expected call of AddCastMember
@@ -363,11 +609,12 @@ func (mr *MockServiceMockRecorder) AddCastMember(arg0 interface{}) *gomock.Call
 }
 
 // AddCategory mocks base method
-func (m *MockService) AddCategory(arg0 crud.CategoryDTO) error {
+func (m *MockService) AddCategory(arg0 crud.CategoryDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddCategory", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddCategory indicates an expected call of AddCategory
@@ -377,11 +624,12 @@ func (mr *MockServiceMockRecorder) AddCategory(arg0 interface{}) *gomock.Call {
 }
 
 // AddGenre mocks base method
-func (m *MockService) AddGenre(arg0 crud.GenreDTO) error {
+func (m *MockService) AddGenre(arg0 crud.GenreDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddGenre", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddGenre indicates an expected call of AddGenre
@@ -405,6 +653,34 @@ func (mr *MockServiceMockRecorder) AddVideo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVideo", reflect.TypeOf((*MockService)(nil).AddVideo), arg0)
 }
 
+// As mocks base method
+func (m *MockService) As(arg0 string) crud.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "As", arg0)
+	ret0, _ := ret[0].(crud.Service)
+	return ret0
+}
+
+// As indicates an expected call of As
+func (mr *MockServiceMockRecorder) As(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "As", reflect.TypeOf((*MockService)(nil).As), arg0)
+}
+
+// AttachVideoAsset mocks base method
+func (m *MockService) AttachVideoAsset(arg0 string, arg1 crud.AssetKind, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachVideoAsset", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachVideoAsset indicates an expected call of AttachVideoAsset
+func (mr *MockServiceMockRecorder) AttachVideoAsset(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachVideoAsset", reflect.TypeOf((*MockService)(nil).AttachVideoAsset), arg0, arg1, arg2)
+}
+
 // FetchCastMember mocks base method
 func (m *MockService) FetchCastMember(arg0 string) (models.CastMember, error) {
 	m.ctrl.T.Helper()
@@ -465,175 +741,471 @@ func (mr *MockServiceMockRecorder) FetchVideo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVideo", reflect.TypeOf((*MockService)(nil).FetchVideo), arg0)
 }
 
+// GetCastMemberVideos mocks base method
+func (m *MockService) GetCastMemberVideos(arg0 string, arg1 crud.Page) (models.CastMemberVideoSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastMemberVideos", arg0, arg1)
+	ret0, _ := ret[0].(models.CastMemberVideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCastMemberVideos indicates an expected call of GetCastMemberVideos
+func (mr *MockServiceMockRecorder) GetCastMemberVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMemberVideos", reflect.TypeOf((*MockService)(nil).GetCastMemberVideos), arg0, arg1)
+}
+
 // GetCastMembers mocks base method
-func (m *MockService) GetCastMembers(arg0 int) (models.CastMemberSlice, error) {
+func (m *MockService) GetCastMembers(arg0 crud.Filter, arg1 crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCastMembers", arg0)
+	ret := m.ctrl.Call(m, "GetCastMembers", arg0, arg1)
 	ret0, _ := ret[0].(models.CastMemberSlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetCastMembers indicates an expected call of GetCastMembers
-func (mr *MockServiceMockRecorder) GetCastMembers(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetCastMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembers", reflect.TypeOf((*MockService)(nil).GetCastMembers), arg0, arg1)
+}
+
+// GetCastMembersInTrash mocks base method
+func (m *MockService) GetCastMembersInTrash(arg0 crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastMembersInTrash", arg0)
+	ret0, _ := ret[0].(models.CastMemberSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCastMembersInTrash indicates an expected call of GetCastMembersInTrash
+func (mr *MockServiceMockRecorder) GetCastMembersInTrash(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembers", reflect.TypeOf((*MockService)(nil).GetCastMembers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastMembersInTrash", reflect.TypeOf((*MockService)(nil).GetCastMembersInTrash), arg0)
 }
 
 // GetCategories mocks base method
-func (m *MockService) GetCategories(arg0 int) (models.CategorySlice, error) {
+func (m *MockService) GetCategories(arg0 crud.Filter, arg1 crud.Page) (models.CategorySlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategories", arg0)
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
 	ret0, _ := ret[0].(models.CategorySlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetCategories indicates an expected call of GetCategories
-func (mr *MockServiceMockRecorder) GetCategories(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockService)(nil).GetCategories), arg0, arg1)
+}
+
+// GetCategoriesInTrash mocks base method
+func (m *MockService) GetCategoriesInTrash(arg0 crud.Page) (models.CategorySlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesInTrash", arg0)
+	ret0, _ := ret[0].(models.CategorySlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCategoriesInTrash indicates an expected call of GetCategoriesInTrash
+func (mr *MockServiceMockRecorder) GetCategoriesInTrash(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockService)(nil).GetCategories), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesInTrash", reflect.TypeOf((*MockService)(nil).GetCategoriesInTrash), arg0)
 }
 
 // GetGenres mocks base method
-func (m *MockService) GetGenres(arg0 int) (models.GenreSlice, error) {
+func (m *MockService) GetGenres(arg0 crud.Filter, arg1 crud.Page) (models.GenreSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenres", arg0)
+	ret := m.ctrl.Call(m, "GetGenres", arg0, arg1)
 	ret0, _ := ret[0].(models.GenreSlice)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetGenres indicates an expected call of GetGenres
-func (mr *MockServiceMockRecorder) GetGenres(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetGenres(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockService)(nil).GetGenres), arg0, arg1)
+}
+
+// GetGenresInTrash mocks base method
+func (m *MockService) GetGenresInTrash(arg0 crud.Page) (models.GenreSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenresInTrash", arg0)
+	ret0, _ := ret[0].(models.GenreSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGenresInTrash indicates an expected call of GetGenresInTrash
+func (mr *MockServiceMockRecorder) GetGenresInTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenresInTrash", reflect.TypeOf((*MockService)(nil).GetGenresInTrash), arg0)
+}
+
+// GetHistory mocks base method
+func (m *MockService) GetHistory(arg0 crud.EntityType, arg1 string, arg2 crud.Page) ([]crud.AuditEvent, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]crud.AuditEvent)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHistory indicates an expected call of GetHistory
+func (mr *MockServiceMockRecorder) GetHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockService)(nil).GetGenres), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockService)(nil).GetHistory), arg0, arg1, arg2)
 }
 
 // GetVideos mocks base method
-func (m *MockService) GetVideos(arg0 int) (models.VideoSlice, error) {
+func (m *MockService) GetVideos(arg0 crud.Filter, arg1 crud.Page) (models.VideoSlice, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideos", arg0, arg1)
+	ret0, _ := ret[0].(models.VideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVideos indicates an expected call of GetVideos
+func (mr *MockServiceMockRecorder) GetVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockService)(nil).GetVideos), arg0, arg1)
+}
+
+// GetVideosInTrash mocks base method
+func (m *MockService) GetVideosInTrash(arg0 crud.Page) (models.VideoSlice, crud.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVideos", arg0)
+	ret := m.ctrl.Call(m, "GetVideosInTrash", arg0)
 	ret0, _ := ret[0].(models.VideoSlice)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVideosInTrash indicates an expected call of GetVideosInTrash
+func (mr *MockServiceMockRecorder) GetVideosInTrash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideosInTrash", reflect.TypeOf((*MockService)(nil).GetVideosInTrash), arg0)
+}
+
+// OpenVideoAsset mocks base method
+func (m *MockService) OpenVideoAsset(arg0 string, arg1 crud.AssetKind) (crud.VideoFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenVideoAsset", arg0, arg1)
+	ret0, _ := ret[0].(crud.VideoFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetVideos indicates an expected call of GetVideos
-func (mr *MockServiceMockRecorder) GetVideos(arg0 interface{}) *gomock.Call {
+// OpenVideoAsset indicates an expected call of OpenVideoAsset
+func (mr *MockServiceMockRecorder) OpenVideoAsset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenVideoAsset", reflect.TypeOf((*MockService)(nil).OpenVideoAsset), arg0, arg1)
+}
+
+// PurgeCastMember mocks base method
+func (m *MockService) PurgeCastMember(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCastMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCastMember indicates an expected call of PurgeCastMember
+func (mr *MockServiceMockRecorder) PurgeCastMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCastMember", reflect.TypeOf((*MockService)(nil).PurgeCastMember), arg0, arg1)
+}
+
+// PurgeCategory mocks base method
+func (m *MockService) PurgeCategory(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeCategory indicates an expected call of PurgeCategory
+func (mr *MockServiceMockRecorder) PurgeCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockService)(nil).GetVideos), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCategory", reflect.TypeOf((*MockService)(nil).PurgeCategory), arg0, arg1)
+}
+
+// PurgeGenre mocks base method
+func (m *MockService) PurgeGenre(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeGenre", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeGenre indicates an expected call of PurgeGenre
+func (mr *MockServiceMockRecorder) PurgeGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeGenre", reflect.TypeOf((*MockService)(nil).PurgeGenre), arg0, arg1)
+}
+
+// PurgeVideo mocks base method
+func (m *MockService) PurgeVideo(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeVideo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeVideo indicates an expected call of PurgeVideo
+func (mr *MockServiceMockRecorder) PurgeVideo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeVideo", reflect.TypeOf((*MockService)(nil).PurgeVideo), arg0, arg1)
 }
 
 // RemoveCastMember mocks base method
-func (m *MockService) RemoveCastMember(arg0 string) error {
+func (m *MockService) RemoveCastMember(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveCastMember", arg0)
+	ret := m.ctrl.Call(m, "RemoveCastMember", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveCastMember indicates an expected call of RemoveCastMember
-func (mr *MockServiceMockRecorder) RemoveCastMember(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) RemoveCastMember(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCastMember", reflect.TypeOf((*MockService)(nil).RemoveCastMember), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCastMember", reflect.TypeOf((*MockService)(nil).RemoveCastMember), arg0, arg1)
 }
 
 // RemoveCategory mocks base method
-func (m *MockService) RemoveCategory(arg0 string) error {
+func (m *MockService) RemoveCategory(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveCategory", arg0)
+	ret := m.ctrl.Call(m, "RemoveCategory", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveCategory indicates an expected call of RemoveCategory
-func (mr *MockServiceMockRecorder) RemoveCategory(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) RemoveCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockService)(nil).RemoveCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCategory", reflect.TypeOf((*MockService)(nil).RemoveCategory), arg0, arg1)
 }
 
 // RemoveGenre mocks base method
-func (m *MockService) RemoveGenre(arg0 string) error {
+func (m *MockService) RemoveGenre(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveGenre", arg0)
+	ret := m.ctrl.Call(m, "RemoveGenre", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveGenre indicates an expected call of RemoveGenre
-func (mr *MockServiceMockRecorder) RemoveGenre(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) RemoveGenre(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenre", reflect.TypeOf((*MockService)(nil).RemoveGenre), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGenre", reflect.TypeOf((*MockService)(nil).RemoveGenre), arg0, arg1)
 }
 
 // RemoveVideo mocks base method
-func (m *MockService) RemoveVideo(arg0 string) error {
+func (m *MockService) RemoveVideo(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveVideo", arg0)
+	ret := m.ctrl.Call(m, "RemoveVideo", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveVideo indicates an expected call of RemoveVideo
-func (mr *MockServiceMockRecorder) RemoveVideo(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) RemoveVideo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVideo", reflect.TypeOf((*MockService)(nil).RemoveVideo), arg0, arg1)
+}
+
+// RestoreCastMember mocks base method
+func (m *MockService) RestoreCastMember(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCastMember", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCastMember indicates an expected call of RestoreCastMember
+func (mr *MockServiceMockRecorder) RestoreCastMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCastMember", reflect.TypeOf((*MockService)(nil).RestoreCastMember), arg0)
+}
+
+// RestoreCategory mocks base method
+func (m *MockService) RestoreCategory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory
+func (mr *MockServiceMockRecorder) RestoreCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockService)(nil).RestoreCategory), arg0)
+}
+
+// RestoreGenre mocks base method
+func (m *MockService) RestoreGenre(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreGenre", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreGenre indicates an expected call of RestoreGenre
+func (mr *MockServiceMockRecorder) RestoreGenre(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVideo", reflect.TypeOf((*MockService)(nil).RemoveVideo), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreGenre", reflect.TypeOf((*MockService)(nil).RestoreGenre), arg0)
+}
+
+// RestoreVideo mocks base method
+func (m *MockService) RestoreVideo(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVideo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreVideo indicates an expected call of RestoreVideo
+func (mr *MockServiceMockRecorder) RestoreVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVideo", reflect.TypeOf((*MockService)(nil).RestoreVideo), arg0)
+}
+
+// SearchVideos mocks base method
+func (m *MockService) SearchVideos(arg0 crud.VideoSearch, arg1 crud.Page) ([]crud.VideoMatch, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchVideos", arg0, arg1)
+	ret0, _ := ret[0].([]crud.VideoMatch)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchVideos indicates an expected call of SearchVideos
+func (mr *MockServiceMockRecorder) SearchVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchVideos", reflect.TypeOf((*MockService)(nil).SearchVideos), arg0, arg1)
 }
 
 // UpdateCastMember mocks base method
-func (m *MockService) UpdateCastMember(arg0 string, arg1 crud.CastMemberDTO) error {
+func (m *MockService) UpdateCastMember(arg0 string, arg1 int64, arg2 crud.CastMemberDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCastMember", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateCastMember", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCastMember indicates an expected call of UpdateCastMember
-func (mr *MockServiceMockRecorder) UpdateCastMember(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateCastMember(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCastMember", reflect.TypeOf((*MockService)(nil).UpdateCastMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCastMember", reflect.TypeOf((*MockService)(nil).UpdateCastMember), arg0, arg1, arg2)
 }
 
 // UpdateCategory mocks base method
-func (m *MockService) UpdateCategory(arg0 string, arg1 crud.CategoryDTO) error {
+func (m *MockService) UpdateCategory(arg0 string, arg1 int64, arg2 crud.CategoryDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCategory indicates an expected call of UpdateCategory
-func (mr *MockServiceMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateCategory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockService)(nil).UpdateCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockService)(nil).UpdateCategory), arg0, arg1, arg2)
 }
 
 // UpdateGenre mocks base method
-func (m *MockService) UpdateGenre(arg0 string, arg1 crud.GenreDTO) error {
+func (m *MockService) UpdateGenre(arg0 string, arg1 int64, arg2 crud.GenreDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateGenre indicates an expected call of UpdateGenre
-func (mr *MockServiceMockRecorder) UpdateGenre(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateGenre(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockService)(nil).UpdateGenre), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockService)(nil).UpdateGenre), arg0, arg1, arg2)
 }
 
 // UpdateVideo mocks base method
-func (m *MockService) UpdateVideo(arg0 string, arg1 crud.VideoDTO) (uuid.UUID, error) {
+func (m *MockService) UpdateVideo(arg0 string, arg1 int64, arg2 crud.VideoDTO) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateVideo", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateVideo", arg0, arg1, arg2)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateVideo indicates an expected call of UpdateVideo
-func (mr *MockServiceMockRecorder) UpdateVideo(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UpdateVideo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockService)(nil).UpdateVideo), arg0, arg1, arg2)
+}
+
+// MockAuditLog is a mock of AuditLog interface
+type MockAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogMockRecorder
+}
+
+// MockAuditLogMockRecorder is the mock recorder for MockAuditLog
+type MockAuditLogMockRecorder struct {
+	mock *MockAuditLog
+}
+
+// NewMockAuditLog creates a new mock instance
+func NewMockAuditLog(ctrl *gomock.Controller) *MockAuditLog {
+	mock := &MockAuditLog{ctrl: ctrl}
+	mock.recorder = &MockAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuditLog) EXPECT() *MockAuditLogMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEvent mocks base method
+func (m *MockAuditLog) AddAuditEvent(arg0 crud.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEvent indicates an expected call of AddAuditEvent
+func (mr *MockAuditLogMockRecorder) AddAuditEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEvent", reflect.TypeOf((*MockAuditLog)(nil).AddAuditEvent), arg0)
+}
+
+// GetHistory mocks base method
+func (m *MockAuditLog) GetHistory(arg0 crud.EntityType, arg1 string, arg2 crud.Page) ([]crud.AuditEvent, crud.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]crud.AuditEvent)
+	ret1, _ := ret[1].(crud.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHistory indicates an expected call of GetHistory
+func (mr *MockAuditLogMockRecorder) GetHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockService)(nil).UpdateVideo), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockAuditLog)(nil).GetHistory), arg0, arg1, arg2)
 }
@@ -0,0 +1,91 @@
+package crud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// Page asks for a slice of a list ordered by creation, either the rows next to Cursor or the page numbered
+// Number, counted from 1. PerPage is capped to MaxPerPage.
+type Page struct {
+	Cursor  *Cursor
+	Number  int
+	PerPage int
+}
+
+// PageInfo locates a page in its list, Next and Prev are the cursors of the pages around it, empty at the ends.
+// Number is zero when the page was asked by a cursor.
+type PageInfo struct {
+	Total   int64
+	Number  int
+	PerPage int
+	Next    string
+	Prev    string
+}
+
+// Cursor is the position of a row in a list, handed out to the clients in its opaque form. Keys are the values
+// of the row for the sort of the list, which the cursor only follows.
+type Cursor struct {
+	Sort string        `json:"s"`
+	Keys []interface{} `json:"k"`
+	ID   string        `json:"i"`
+	// Before asks for the rows preceding the position instead of the following ones
+	Before bool `json:"b,omitempty"`
+}
+
+// String returns the opaque form of the cursor
+func (c Cursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseCursor reads a cursor from its opaque form
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("cursor %w", logger.ErrIsNotValidated)
+	}
+	c := &Cursor{}
+	if err := json.Unmarshal(b, c); err != nil || c.ID == "" || len(c.Keys) == 0 {
+		return nil, fmt.Errorf("cursor %w", logger.ErrIsNotValidated)
+	}
+	return c, nil
+}
+
+// NormalizeNumbered checks the page asked of list, whose pages are numbered, and fills in its defaults. The
+// lists that are reordered by their changes, like a trash by the removals or a history by the new events, are
+// not paged by a cursor.
+func (p *Page) NormalizeNumbered(list string) error {
+	if p.Cursor != nil {
+		return fmt.Errorf("cursor of %s %w, its pages are numbered", list, logger.ErrIsNotValidated)
+	}
+	return p.Normalize()
+}
+
+// Normalize checks the page and fills in its defaults
+func (p *Page) Normalize() error {
+	if p.PerPage < 0 || p.Number < 0 {
+		return logger.ErrInvalidedLimit
+	}
+	if p.Cursor != nil && p.Number > 0 {
+		return fmt.Errorf("cursor and page number together %w", logger.ErrIsNotValidated)
+	}
+	if p.PerPage == 0 {
+		p.PerPage = DefaultPerPage
+	}
+	if p.PerPage > MaxPerPage {
+		p.PerPage = MaxPerPage
+	}
+	if p.Cursor == nil && p.Number == 0 {
+		p.Number = 1
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package crud_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func Test_ParseCursor(t *testing.T) {
+	fakeCursor := crud.Cursor{
+		Sort:   "-year_launched,title",
+		Keys:   []interface{}{float64(2020), "fake title"},
+		ID:     uuid.New().String(),
+		Before: true,
+	}
+	tests := []struct {
+		name    string
+		arg     string
+		want    crud.Cursor
+		wantErr error
+	}{
+		{
+			name: "When cursor was given out",
+			arg:  fakeCursor.String(),
+			want: fakeCursor,
+		},
+		{
+			name:    "When cursor is not base64",
+			arg:     "fake cursor",
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When cursor has no position",
+			arg:     base64.RawURLEncoding.EncodeToString([]byte(`{"b":true}`)),
+			wantErr: logger.ErrIsNotValidated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crud.ParseCursor(tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCursor() error: %v, want: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("ParseCursor() got: %+v, want: %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPage_NormalizeNumbered(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    crud.Page
+		want    crud.Page
+		wantErr error
+	}{
+		{
+			name:    "When cursor is given",
+			page:    crud.Page{Cursor: &crud.Cursor{ID: uuid.New().String()}},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When page is less than zero",
+			page:    crud.Page{Number: -1},
+			wantErr: logger.ErrInvalidedLimit,
+		},
+		{
+			name: "When page is not given",
+			want: crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.page.NormalizeNumbered("a fake list")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeNumbered() error: %v, want: %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(tt.page, tt.want) {
+				t.Errorf("NormalizeNumbered() page: %+v, want: %+v", tt.page, tt.want)
+			}
+		})
+	}
+}
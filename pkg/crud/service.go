@@ -1,48 +1,130 @@
-//go:generate mockgen -destination=./mock/service.go -package=mock . Repository,Service
+//go:generate mockgen -destination=./mock/service.go -package=mock . Repository,Service,AuditLog
 
 package crud
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
 type Repository interface {
-	Service
+	Catalogue
 }
 
 type service struct {
-	r Repository
+	r              Repository
+	assets         *AssetValidator
+	searchLanguage string
+	audit          AuditLog
 }
 
-type Service interface {
-	GetCategories(limit int) (models.CategorySlice, error)
-	FetchCategory(name string) (models.Category, error)
-	AddCategory(dto CategoryDTO) error
-	RemoveCategory(name string) error
-	UpdateCategory(name string, dto CategoryDTO) error
-
-	GetCastMembers(limit int) (models.CastMemberSlice, error)
-	FetchCastMember(name string) (models.CastMember, error)
-	AddCastMember(dto CastMemberDTO) error
-	RemoveCastMember(name string) error
-	UpdateCastMember(name string, dto CastMemberDTO) error
-
-	GetGenres(limit int) (models.GenreSlice, error)
-	FetchGenre(name string) (models.Genre, error)
-	AddGenre(dto GenreDTO) error
-	RemoveGenre(name string) error
-	UpdateGenre(name string, dto GenreDTO) error
-
-	GetVideos(limit int) (models.VideoSlice, error)
-	FetchVideo(name string) (models.Video, error)
+// ServiceOption customizes a crud service
+type ServiceOption func(*service)
+
+// WithAssetValidator checks the asset files of the videos with v instead of the default specs
+func WithAssetValidator(v *AssetValidator) ServiceOption {
+	return func(s *service) {
+		s.assets = v
+	}
+}
+
+// WithAuditLog records every change of the catalogue in log, as done by the actor of the service
+func WithAuditLog(log AuditLog) ServiceOption {
+	return func(s *service) {
+		s.audit = log
+		s.r = auditedRepository{Repository: s.r, log: log, actor: SystemActor}
+	}
+}
+
+// WithSearchLanguage parses the searches that do not name their language with the text search configuration lang,
+// and indexes the videos added without a language with it
+func WithSearchLanguage(lang string) ServiceOption {
+	return func(s *service) {
+		s.searchLanguage = strings.ToLower(strings.TrimSpace(lang))
+	}
+}
+
+// Catalogue reads and changes the categories, genres, cast members and videos, it is served by the service from
+// its repository
+type Catalogue interface {
+	GetCategories(filter Filter, page Page) (models.CategorySlice, PageInfo, error)
+	FetchCategory(id string) (models.Category, error)
+	AddCategory(dto CategoryDTO) (uuid.UUID, error)
+	RemoveCategory(id string, version int64) error
+	UpdateCategory(id string, version int64, dto CategoryDTO) error
+	GetCategoriesInTrash(page Page) (models.CategorySlice, PageInfo, error)
+	RestoreCategory(id string) error
+	PurgeCategory(id string, version int64) error
+
+	GetCastMembers(filter Filter, page Page) (models.CastMemberSlice, PageInfo, error)
+	FetchCastMember(id string) (models.CastMember, error)
+	AddCastMember(dto CastMemberDTO) (uuid.UUID, error)
+	RemoveCastMember(id string, version int64) error
+	UpdateCastMember(id string, version int64, dto CastMemberDTO) error
+	GetCastMemberVideos(id string, page Page) (models.CastMemberVideoSlice, PageInfo, error)
+	GetCastMembersInTrash(page Page) (models.CastMemberSlice, PageInfo, error)
+	RestoreCastMember(id string) error
+	PurgeCastMember(id string, version int64) error
+
+	GetGenres(filter Filter, page Page) (models.GenreSlice, PageInfo, error)
+	FetchGenre(id string) (models.Genre, error)
+	AddGenre(dto GenreDTO) (uuid.UUID, error)
+	RemoveGenre(id string, version int64) error
+	UpdateGenre(id string, version int64, dto GenreDTO) error
+	GetGenresInTrash(page Page) (models.GenreSlice, PageInfo, error)
+	RestoreGenre(id string) error
+	PurgeGenre(id string, version int64) error
+
+	GetVideos(filter Filter, page Page) (models.VideoSlice, PageInfo, error)
+	FetchVideo(id string) (models.Video, error)
 	AddVideo(dto VideoDTO) (uuid.UUID, error)
-	RemoveVideo(name string) error
-	UpdateVideo(name string, dto VideoDTO) (uuid.UUID, error)
+	RemoveVideo(id string, version int64) error
+	UpdateVideo(id string, version int64, dto VideoDTO) (uuid.UUID, error)
+	SearchVideos(search VideoSearch, page Page) ([]VideoMatch, PageInfo, error)
+	OpenVideoAsset(id string, kind AssetKind) (VideoFile, error)
+	AttachVideoAsset(id string, kind AssetKind, file io.Reader) error
+	GetVideosInTrash(page Page) (models.VideoSlice, PageInfo, error)
+	RestoreVideo(id string) error
+	PurgeVideo(id string, version int64) error
+}
+
+type Service interface {
+	Catalogue
+	GetHistory(entity EntityType, id string, page Page) ([]AuditEvent, PageInfo, error)
+	// As returns the service recording the changes it makes as done by actor
+	As(actor string) Service
 }
 
 // NewService creates a crud service with the necessary dependencies
-func NewService(repoDB Repository) *service {
-	return &service{repoDB}
+func NewService(repoDB Repository, opts ...ServiceOption) *service {
+	s := &service{r: repoDB, assets: defaultAssetValidator, searchLanguage: DefaultSearchLanguage}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// normalizeID checks id is a UUID and writes it the way the ids are stored
+// NormalizeName writes a name the way it is stored and looked up: in lower case, with each run of spaces in it made
+// one space and none around it
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+func normalizeID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if len(id) == 0 {
+		return "", fmt.Errorf("'id' %w", logger.ErrIsRequired)
+	}
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+	}
+	return u.String(), nil
 }
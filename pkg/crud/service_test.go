@@ -0,0 +1,24 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"fake", "fake"},
+		{"  Fake   NAME\t", "fake name"},
+		{"fake\t\nname", "fake name"},
+		{" \t ", ""},
+	}
+	for _, tt := range tests {
+		if got := crud.NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
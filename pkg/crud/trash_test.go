@@ -0,0 +1,164 @@
+package crud_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/crud/mock"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func Test_service_GetCategoriesInTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{"fake"}, ID: uuid.New().String()}
+	tests := []struct {
+		name     string
+		page     crud.Page
+		repoPage crud.Page
+		wantErr  error
+	}{
+		{
+			name:    "When cursor is given",
+			page:    crud.Page{Cursor: fakeCursor},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When per_page is less than zero",
+			page:    crud.Page{PerPage: -1},
+			wantErr: logger.ErrInvalidedLimit,
+		},
+		{
+			name:     "When page is not given",
+			page:     crud.Page{},
+			repoPage: crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+		},
+		{
+			name:     "When page is given",
+			page:     crud.Page{Number: 2, PerPage: 5},
+			repoPage: crud.Page{Number: 2, PerPage: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				mockR.EXPECT().
+					GetCategoriesInTrash(tt.repoPage).
+					Return(nil, crud.PageInfo{}, nil)
+			}
+			s := crud.NewService(mockR)
+			_, _, err := s.GetCategoriesInTrash(tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCategoriesInTrash() error: %v, want: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_RestoreAndPurge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	s := crud.NewService(mockR)
+	fakeID := uuid.New().String()
+	atAnyVersion := func(purge func(id string, version int64) error) func(id string) error {
+		return func(id string) error {
+			return purge(id, crud.AnyVersion)
+		}
+	}
+	tests := []struct {
+		name    string
+		id      string
+		expect  func(id string) *gomock.Call
+		call    func(id string) error
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "When id is blank",
+			id:      " ",
+			call:    s.RestoreVideo,
+			wantErr: logger.ErrIsRequired,
+		},
+		{
+			name:    "When id is not an uuid",
+			id:      "fake",
+			call:    atAnyVersion(s.PurgeVideo),
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name: "When the category is not in the trash",
+			id:   fakeID,
+			expect: func(id string) *gomock.Call {
+				return mockR.EXPECT().RestoreCategory(id)
+			},
+			call:    s.RestoreCategory,
+			repoErr: sql.ErrNoRows,
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name: "When the name of the genre is taken",
+			id:   fakeID,
+			expect: func(id string) *gomock.Call {
+				return mockR.EXPECT().RestoreGenre(id)
+			},
+			call:    s.RestoreGenre,
+			repoErr: logger.ErrAlreadyExists,
+			wantErr: logger.ErrAlreadyExists,
+		},
+		{
+			name: "When the cast member is purged",
+			id:   " " + fakeID + " ",
+			expect: func(id string) *gomock.Call {
+				return mockR.EXPECT().PurgeCastMember(fakeID, crud.AnyVersion)
+			},
+			call: atAnyVersion(s.PurgeCastMember),
+		},
+		{
+			name: "When the genre changed since its version",
+			id:   fakeID,
+			expect: func(id string) *gomock.Call {
+				return mockR.EXPECT().PurgeGenre(id, int64(2))
+			},
+			call: func(id string) error {
+				return s.PurgeGenre(id, 2)
+			},
+			repoErr: logger.ErrIsStale,
+			wantErr: logger.ErrIsStale,
+		},
+		{
+			name: "When version is negative",
+			id:   fakeID,
+			call: func(id string) error {
+				return s.PurgeCategory(id, -1)
+			},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name: "When the video is not found",
+			id:   fakeID,
+			expect: func(id string) *gomock.Call {
+				return mockR.EXPECT().PurgeVideo(id, crud.AnyVersion)
+			},
+			call:    atAnyVersion(s.PurgeVideo),
+			repoErr: sql.ErrNoRows,
+			wantErr: logger.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expect != nil {
+				tt.expect(tt.id).Return(tt.repoErr)
+			}
+			if err := tt.call(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error: %v, want: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
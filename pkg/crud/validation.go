@@ -0,0 +1,92 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// FieldError is a field of a request that breaks a rule, the field is named as in JSON
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	// Detail explains the rule when its name is not enough
+	Detail string `json:"detail,omitempty"`
+}
+
+// requiredRules are the rules that a field breaks by being missing
+var requiredRules = map[string]bool{"required": true, "not_blank": true}
+
+func (f FieldError) err() error {
+	if requiredRules[f.Rule] {
+		return logger.ErrIsRequired
+	}
+	return logger.ErrIsNotValidated
+}
+
+// ValidationError lists all the fields of a DTO that are not valid
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = fmt.Sprintf("'%s' field %s", f.Field, f.err())
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Is matches ErrIsRequired when a field is missing and ErrIsNotValidated when a field has a value it should not
+func (e *ValidationError) Is(target error) bool {
+	for _, f := range e.Fields {
+		if f.err() == target {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldErrors collects the fields of a DTO that are not valid, so that all of them are told at once
+type fieldErrors []FieldError
+
+func (errs *fieldErrors) add(field, rule string) {
+	*errs = append(*errs, FieldError{Field: field, Rule: rule})
+}
+
+// addStruct adds the fields of dto that break the rules of their validate tags
+func (errs *fieldErrors) addStruct(validate *validator.Validate, dto interface{}) {
+	err := validate.Struct(dto)
+	if err == nil {
+		return
+	}
+	for _, fe := range err.(validator.ValidationErrors) {
+		errs.add(fe.Field(), fe.Tag())
+	}
+}
+
+func (errs fieldErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: errs}
+}
+
+// newValidator creates the validator of the tags of a DTO, which names the fields by their JSON names
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterValidation("not_blank", validators.NotBlank)
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	return validate
+}
@@ -0,0 +1,99 @@
+package crud_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestVideoDTO_Validate(t *testing.T) {
+	fakeYearLaunched, fakeDuration := int16(2020), int16(90)
+	fakeRating, fakeBadRating := crud.TenRating, crud.VideoRating(12)
+	fakeCastMemberID := uuid.New().String()
+	fakeVideo := func() crud.VideoDTO {
+		return crud.VideoDTO{
+			Title:        "fake",
+			YearLaunched: &fakeYearLaunched,
+			Rating:       &fakeRating,
+			Duration:     &fakeDuration,
+			Categories:   []crud.CategoryDTO{{Name: "fake"}},
+			Genres:       []crud.GenreDTO{{Name: "fake"}},
+		}
+	}
+	tests := []struct {
+		name       string
+		dto        func() crud.VideoDTO
+		wantFields []crud.FieldError
+		wantErrs   []error
+	}{
+		{
+			name: "When the video is valid",
+			dto:  fakeVideo,
+		},
+		{
+			name: "When fields are missing",
+			dto: func() crud.VideoDTO {
+				dto := fakeVideo()
+				dto.Title, dto.Duration, dto.Genres = " ", nil, nil
+				return dto
+			},
+			wantFields: []crud.FieldError{
+				{Field: "title", Rule: "not_blank"},
+				{Field: "duration", Rule: "required"},
+				{Field: "genres", Rule: "not_blank"},
+			},
+			wantErrs: []error{logger.ErrIsRequired},
+		},
+		{
+			name: "When fields are missing and others are not valid",
+			dto: func() crud.VideoDTO {
+				dto := fakeVideo()
+				dto.Title, dto.Rating = "", &fakeBadRating
+				dto.CastMembers = []crud.VideoCastMemberDTO{
+					{ID: "fake", Role: crud.Actor},
+					{ID: fakeCastMemberID, Role: crud.Director, Character: "fake", BillingOrder: billingOrder(-1)},
+					{ID: fakeCastMemberID, Role: crud.Director},
+				}
+				return dto
+			},
+			wantFields: []crud.FieldError{
+				{Field: "title", Rule: "not_blank"},
+				{Field: "rating", Rule: "video_rating"},
+				{Field: "cast_members[0].id", Rule: "uuid"},
+				{Field: "cast_members[1].character", Rule: "played_by_role"},
+				{Field: "cast_members[1].billing_order", Rule: "min"},
+				{Field: "cast_members[2]", Rule: "unique"},
+			},
+			wantErrs: []error{logger.ErrIsRequired, logger.ErrIsNotValidated},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dto := tt.dto()
+			err := dto.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() error: %v, want: nil", err)
+				}
+				return
+			}
+			var vErr *crud.ValidationError
+			if !errors.As(err, &vErr) {
+				t.Fatalf("Validate() error: %v, want: a validation error", err)
+			}
+			if !reflect.DeepEqual(vErr.Fields, tt.wantFields) {
+				t.Errorf("Validate() fields: %v, want: %v", vErr.Fields, tt.wantFields)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("Validate() error: %v, want: %v", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package crud
+
+import (
+	"fmt"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// AnyVersion matches an entity at whatever version it is, a change made at it is never stale. The versions of
+// an entity start at 1 and move on at every change, a change made at an older version than the current one is
+// refused as stale.
+const AnyVersion int64 = 0
+
+func checkVersion(version int64) error {
+	if version < AnyVersion {
+		return fmt.Errorf("version %d %w", version, logger.ErrIsNotValidated)
+	}
+	return nil
+}
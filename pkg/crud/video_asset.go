@@ -0,0 +1,270 @@
+package crud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+)
+
+// AssetKind is the role a media file plays for a video, it also names the directory of its files
+type AssetKind string
+
+const (
+	ThumbnailAsset AssetKind = "thumbnail"
+	BannerAsset    AssetKind = "banner"
+	TrailerAsset   AssetKind = "trailer"
+	VideoAsset     AssetKind = "video"
+)
+
+const sniffLen = 512
+
+// AssetSpec holds the limits of the files of a kind of asset
+type AssetSpec struct {
+	MaxSize   int64
+	MIMETypes []string
+}
+
+// AssetSpecs are the kinds of asset a video accepts with their default limits
+var AssetSpecs = map[AssetKind]AssetSpec{
+	ThumbnailAsset: {MaxSize: 5 << 20, MIMETypes: []string{jpegContentType, pngContentType}},
+	BannerAsset:    {MaxSize: 10 << 20, MIMETypes: []string{jpegContentType, pngContentType}},
+	TrailerAsset:   {MaxSize: 1 << 30, MIMETypes: []string{mp4ContentType, webmContentType, matroskaContentType}},
+	VideoAsset:     {MaxSize: 50 << 30, MIMETypes: []string{mp4ContentType, webmContentType, matroskaContentType}},
+}
+
+var defaultAssetValidator = &AssetValidator{specs: AssetSpecs}
+
+// AssetKinds lists the kinds of asset in the order they are shown
+func AssetKinds() []AssetKind {
+	return []AssetKind{ThumbnailAsset, BannerAsset, TrailerAsset, VideoAsset}
+}
+
+func (k AssetKind) Validate() error {
+	if _, ok := AssetSpecs[k]; !ok {
+		return fmt.Errorf("asset kind '%s' %w", k, logger.ErrIsNotValidated)
+	}
+	return nil
+}
+
+func (s AssetSpec) allows(contentType string) bool {
+	for _, t := range s.MIMETypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// AssetSource yields the asset files of a request one at a time, each reader must be consumed before
+// asking for the next one and io.EOF reports there are no more assets
+type AssetSource interface {
+	NextAsset() (AssetKind, io.Reader, error)
+}
+
+// AssetStager is a repository that stages the files of the assets ahead of the changes that store them, so that
+// the transaction of a change does not hold its locks while its files are streamed
+type AssetStager interface {
+	// StageAsset streams file to the files of the repository and returns a reader that its changes store without
+	// reading it again, discard removes the staged file when no change stored it
+	StageAsset(kind AssetKind, file io.Reader) (staged io.Reader, discard func(), err error)
+}
+
+// stagedAssets yields the files an AssetStager staged, in the order of their source
+type stagedAssets struct {
+	kinds    []AssetKind
+	files    []io.Reader
+	discards []func()
+}
+
+func (s *stagedAssets) NextAsset() (AssetKind, io.Reader, error) {
+	if len(s.kinds) == 0 {
+		return "", nil, io.EOF
+	}
+	kind, file := s.kinds[0], s.files[0]
+	s.kinds, s.files = s.kinds[1:], s.files[1:]
+	return kind, file, nil
+}
+
+// discard removes the staged files that no change stored
+func (s *stagedAssets) discard() {
+	for _, discard := range s.discards {
+		discard()
+	}
+}
+
+// VideoAssetDTO describes a stored asset of a video, URL is where it is downloaded from
+type VideoAssetDTO struct {
+	Kind        AssetKind `json:"kind"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+}
+
+// VideoFile is the stored file of a video asset opened for streaming, Name is the sha256 of its content
+type VideoFile struct {
+	Name        string
+	ContentType string
+	ModTime     time.Time
+	Content     files.File
+}
+
+// AssetError lists every reason an asset file was refused
+type AssetError struct {
+	Kind     AssetKind
+	Reasons  []string
+	tooLarge bool
+}
+
+func (e *AssetError) Error() string {
+	return fmt.Sprintf("'%s' file %s: %s", e.Kind, logger.ErrIsNotValidated, strings.Join(e.Reasons, "; "))
+}
+
+// Is matches ErrIsNotValidated, and ErrIsTooLarge when the file exceeded the size of its kind
+func (e *AssetError) Is(target error) bool {
+	return target == logger.ErrIsNotValidated || (e.tooLarge && target == logger.ErrIsTooLarge)
+}
+
+// AssetValidator checks asset files against the specs of their kinds
+type AssetValidator struct {
+	specs map[AssetKind]AssetSpec
+}
+
+// NewAssetValidator creates a validator with the default specs, maxSizes overrides the size of some kinds
+func NewAssetValidator(maxSizes map[AssetKind]int64) (*AssetValidator, error) {
+	specs := make(map[AssetKind]AssetSpec, len(AssetSpecs))
+	for kind, spec := range AssetSpecs {
+		specs[kind] = spec
+	}
+	for kind, maxSize := range maxSizes {
+		spec, ok := specs[kind]
+		if !ok {
+			return nil, fmt.Errorf("asset kind '%s' %w", kind, logger.ErrIsNotValidated)
+		}
+		if maxSize <= 0 {
+			return nil, fmt.Errorf("max size of '%s' %w", kind, logger.ErrIsNotValidated)
+		}
+		spec.MaxSize = maxSize
+		specs[kind] = spec
+	}
+	return &AssetValidator{specs: specs}, nil
+}
+
+// MaxSize is the largest file accepted for the kind, zero for unknown kinds
+func (v *AssetValidator) MaxSize(kind AssetKind) int64 {
+	return v.specs[kind].MaxSize
+}
+
+// NewReader sniffs the format of r from its first bytes, parsing the header of the container, and refuses it
+// with an *AssetError when the kind does not accept it
+func (v *AssetValidator) NewReader(kind AssetKind, r io.Reader) (*AssetReader, error) {
+	spec, ok := v.specs[kind]
+	if !ok {
+		return nil, fmt.Errorf("asset kind '%s' %w", kind, logger.ErrIsNotValidated)
+	}
+	if r == nil {
+		return nil, fmt.Errorf("'%s' file %w", kind, logger.ErrIsRequired)
+	}
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(head) == 0 {
+		return nil, fmt.Errorf("'%s' file %w", kind, logger.ErrIsRequired)
+	}
+	contentType, reasons := sniffAsset(head)
+	if !spec.allows(contentType) {
+		reasons = append([]string{fmt.Sprintf(
+			"content type '%s' is not allowed, want %s",
+			contentType,
+			strings.Join(spec.MIMETypes, ", "),
+		)}, reasons...)
+	}
+	if len(reasons) > 0 {
+		return nil, &AssetError{Kind: kind, Reasons: reasons}
+	}
+	return &AssetReader{Kind: kind, ContentType: contentType, spec: spec, r: br}, nil
+}
+
+// AssetReader checks an asset file while it is streamed, the format is checked from its first bytes
+// before anything is stored and reading fails as soon as the file exceeds the size of its kind
+type AssetReader struct {
+	Kind        AssetKind
+	ContentType string
+	spec        AssetSpec
+	r           *bufio.Reader
+	size        int64
+	err         error
+}
+
+// NewAssetReader checks r against the default specs of the kind
+func NewAssetReader(kind AssetKind, r io.Reader) (*AssetReader, error) {
+	return defaultAssetValidator.NewReader(kind, r)
+}
+
+func (a *AssetReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	a.size += int64(n)
+	if a.size > a.spec.MaxSize {
+		a.err = &AssetError{
+			Kind:     a.Kind,
+			Reasons:  []string{fmt.Sprintf("size exceeds %d bytes", a.spec.MaxSize)},
+			tooLarge: true,
+		}
+		return n, a.err
+	}
+	return n, err
+}
+
+// Err is the error that refused the file while it was read, repositories may wrap it without %w
+func (a *AssetReader) Err() error {
+	return a.err
+}
+
+// Size is the number of bytes read so far, the whole file once it is consumed
+func (a *AssetReader) Size() int64 {
+	return a.size
+}
+
+// validatedAssets checks each file of an asset source while the repository reads it
+type validatedAssets struct {
+	source    AssetSource
+	validator *AssetValidator
+}
+
+func (a validatedAssets) NextAsset() (AssetKind, io.Reader, error) {
+	kind, r, err := a.source.NextAsset()
+	if err != nil {
+		return "", nil, err
+	}
+	assetReader, err := a.validator.NewReader(kind, r)
+	if err != nil {
+		return "", nil, err
+	}
+	return kind, assetReader, nil
+}
+
+// MapVideoAssetsToDTO describes the assets of a video in the order of AssetKinds, url builds their download URL
+func MapVideoAssetsToDTO(assets models.VideoAssetSlice, url func(kind AssetKind) string) []VideoAssetDTO {
+	dtos := make([]VideoAssetDTO, 0, len(assets))
+	for _, kind := range AssetKinds() {
+		for _, asset := range assets {
+			if AssetKind(asset.Kind) != kind {
+				continue
+			}
+			dtos = append(dtos, VideoAssetDTO{
+				Kind:        kind,
+				ContentType: asset.ContentType,
+				Size:        asset.Size,
+				URL:         url(kind),
+			})
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,121 @@
+package crud_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_NewAssetReader(t *testing.T) {
+	fakeVideoData := testdata.FakeMP4(64)
+	fakeImageData := testdata.FakePNG(64)
+	type args struct {
+		kind crud.AssetKind
+		data []byte
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name:    "When kind is unknown",
+			args:    args{"fakeKind", fakeVideoData},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When file is empty",
+			args:    args{crud.VideoAsset, []byte{}},
+			wantErr: logger.ErrIsRequired,
+		},
+		{
+			name:    "When content type is not allowed for the kind",
+			args:    args{crud.ThumbnailAsset, fakeVideoData},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:            "When video is right",
+			args:            args{crud.TrailerAsset, fakeVideoData},
+			wantContentType: "video/mp4",
+		},
+		{
+			name:            "When image is right",
+			args:            args{crud.BannerAsset, fakeImageData},
+			wantContentType: "image/png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crud.NewAssetReader(tt.args.kind, bytes.NewReader(tt.args.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewAssetReader() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ContentType != tt.wantContentType {
+				t.Errorf("NewAssetReader() ContentType: %v, want: %v", got.ContentType, tt.wantContentType)
+			}
+			data, err := ioutil.ReadAll(got)
+			if err != nil {
+				t.Fatalf("test: could not read asset: %v", err)
+			}
+			if !bytes.Equal(data, tt.args.data) || got.Size() != int64(len(tt.args.data)) {
+				t.Errorf("NewAssetReader() read %d bytes, want: %d", got.Size(), len(tt.args.data))
+			}
+		})
+	}
+}
+
+func Test_AssetReader_MaxSize(t *testing.T) {
+	fakeData := testdata.FakePNG(int(crud.AssetSpecs[crud.ThumbnailAsset].MaxSize))
+	r, err := crud.NewAssetReader(crud.ThumbnailAsset, bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("NewAssetReader() error: %v", err)
+	}
+	if _, err := ioutil.ReadAll(r); !errors.Is(err, logger.ErrIsTooLarge) {
+		t.Errorf("Read() error: %v, want: %v", err, logger.ErrIsTooLarge)
+	}
+	if !errors.Is(r.Err(), logger.ErrIsTooLarge) {
+		t.Errorf("Err() got: %v, want: %v", r.Err(), logger.ErrIsTooLarge)
+	}
+}
+
+func Test_NewAssetValidator(t *testing.T) {
+	const fakeMaxSize = 32
+	if _, err := crud.NewAssetValidator(map[crud.AssetKind]int64{"fakeKind": fakeMaxSize}); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Errorf("NewAssetValidator() error: %v, want: %v", err, logger.ErrIsNotValidated)
+	}
+	if _, err := crud.NewAssetValidator(map[crud.AssetKind]int64{crud.BannerAsset: 0}); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Errorf("NewAssetValidator() error: %v, want: %v", err, logger.ErrIsNotValidated)
+	}
+	v, err := crud.NewAssetValidator(map[crud.AssetKind]int64{crud.BannerAsset: fakeMaxSize})
+	if err != nil {
+		t.Fatalf("test: failed to create a validator: %v", err)
+	}
+	if got := v.MaxSize(crud.BannerAsset); got != fakeMaxSize {
+		t.Errorf("MaxSize() got: %d, want: %d", got, fakeMaxSize)
+	}
+	if got, want := v.MaxSize(crud.VideoAsset), crud.AssetSpecs[crud.VideoAsset].MaxSize; got != want {
+		t.Errorf("MaxSize() got: %d, want: %d", got, want)
+	}
+	r, err := v.NewReader(crud.BannerAsset, bytes.NewReader(testdata.FakePNG(fakeMaxSize)))
+	if err != nil {
+		t.Fatalf("NewReader() error: %v", err)
+	}
+	_, err = ioutil.ReadAll(r)
+	var assetErr *crud.AssetError
+	if !errors.As(err, &assetErr) || !errors.Is(err, logger.ErrIsTooLarge) {
+		t.Fatalf("Read() error: %v, want: %v", err, logger.ErrIsTooLarge)
+	}
+	if want := fmt.Sprintf("size exceeds %d bytes", fakeMaxSize); len(assetErr.Reasons) != 1 || assetErr.Reasons[0] != want {
+		t.Errorf("Read() reasons: %q, want: %q", assetErr.Reasons, want)
+	}
+}
@@ -2,10 +2,10 @@ package crud
 
 import (
 	"fmt"
-	"mime/multipart"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/go-playground/validator/v10/non-standard/validators"
+	"github.com/google/uuid"
 
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/logger"
@@ -37,21 +37,57 @@ func (v *VideoRating) Validate() error {
 }
 
 type VideoDTO struct {
-	Title            string                `json:"title" schema:"title" validate:"not_blank"`
-	Description      string                `json:"description" schema:"description"`
-	YearLaunched     *int16                `json:"year_launched" schema:"year_launched" validate:"required"`
-	Opened           bool                  `json:"opened" schema:"opened"`
-	Rating           *VideoRating          `json:"rating" schema:"rating" validate:"required"`
-	Duration         *int16                `json:"duration" schema:"duration" validate:"required"`
-	Categories       []CategoryDTO         `json:"categories" schema:"categories" validate:"not_blank"`
-	Genres           []GenreDTO            `json:"genres" schema:"genres" validate:"not_blank"`
-	VideoFileHandler *multipart.FileHeader `json:"-" schema:"-"`
+	// ID is assigned when the video is added, the one of a request is ignored
+	ID           string        `json:"id,omitempty" schema:"-"`
+	Title        string        `json:"title" schema:"title" validate:"not_blank"`
+	Description  string        `json:"description" schema:"description"`
+	YearLaunched *int16        `json:"year_launched" schema:"year_launched" validate:"required"`
+	Opened       bool          `json:"opened" schema:"opened"`
+	Rating       *VideoRating  `json:"rating" schema:"rating" validate:"required"`
+	Duration     *int16        `json:"duration" schema:"duration" validate:"required"`
+	Categories   []CategoryDTO `json:"categories" schema:"categories" validate:"not_blank"`
+	Genres       []GenreDTO    `json:"genres" schema:"genres" validate:"not_blank"`
+	// CastMembers are the credits of the video in their billing order, a video may have none
+	CastMembers []VideoCastMemberDTO `json:"cast_members" schema:"cast_members"`
+	// Language is the text search configuration of the title and the description, the one of the searches of the
+	// service when a video is added without it and left as it is when a video is updated without it
+	Language string          `json:"language,omitempty" schema:"language"`
+	Assets   []VideoAssetDTO `json:"assets,omitempty" schema:"-"`
+	// Files are read once and streamed straight to the files repository, the assets they omit are kept
+	Files AssetSource `json:"-" schema:"-"`
+}
+
+// VideoCastMemberDTO credits the cast member of ID in a video, the name is only filled in the responses
+type VideoCastMemberDTO struct {
+	ID        string         `json:"id" schema:"id"`
+	Name      string         `json:"name,omitempty" schema:"-"`
+	Role      CastMemberType `json:"role" schema:"role"`
+	Character string         `json:"character,omitempty" schema:"character"`
+	// BillingOrder places the credit in the cast of the video, its position in the list when omitted. It is a
+	// pointer so that an order of 0 is kept.
+	BillingOrder *int16 `json:"billing_order" schema:"billing_order"`
+}
+
+// validate adds the fields of the credit that are not valid, named after the field of the credit in its video
+func (c *VideoCastMemberDTO) validate(field string, errs *fieldErrors) {
+	if _, err := uuid.Parse(strings.TrimSpace(c.ID)); err != nil {
+		errs.add(field+".id", "uuid")
+	}
+	if err := c.Role.Validate(); err != nil {
+		errs.add(field+".role", "cast_member_type")
+	} else if !c.Role.playsCharacter() && strings.TrimSpace(c.Character) != "" {
+		errs.add(field+".character", "played_by_role")
+	}
+	if c.BillingOrder != nil && *c.BillingOrder < 0 {
+		errs.add(field+".billing_order", "min")
+	}
 }
 
 func MapVideoToDTO(video models.Video) (*VideoDTO, error) {
 	categoriesDTOs := make([]CategoryDTO, len(video.R.Categories))
 	for i, category := range video.R.Categories {
 		categoriesDTOs[i] = CategoryDTO{
+			ID:          category.ID,
 			Name:        category.Name,
 			Description: category.Description.String,
 		}
@@ -59,11 +95,17 @@ func MapVideoToDTO(video models.Video) (*VideoDTO, error) {
 	genresDTOs := make([]GenreDTO, len(video.R.Genres))
 	for i, genre := range video.R.Genres {
 		genresDTOs[i] = GenreDTO{
+			ID:   genre.ID,
 			Name: genre.Name,
 		}
 	}
+	castDTOs := make([]VideoCastMemberDTO, len(video.R.CastMemberVideos))
+	for i, credit := range video.R.CastMemberVideos {
+		castDTOs[i] = MapCreditToDTO(*credit)
+	}
 	rating := VideoRating(video.Rating)
 	dto := &VideoDTO{
+		ID:           video.ID,
 		Title:        video.Title,
 		Description:  video.Description,
 		YearLaunched: &video.YearLaunched,
@@ -72,6 +114,8 @@ func MapVideoToDTO(video models.Video) (*VideoDTO, error) {
 		Duration:     &video.Duration,
 		Categories:   categoriesDTOs,
 		Genres:       genresDTOs,
+		CastMembers:  castDTOs,
+		Language:     video.Language,
 	}
 	if err := dto.Validate(); err != nil {
 		return nil, err
@@ -79,19 +123,60 @@ func MapVideoToDTO(video models.Video) (*VideoDTO, error) {
 	return dto, nil
 }
 
+// MapCreditToDTO maps a credit of a video, the name is left out when its cast member is not loaded
+func MapCreditToDTO(credit models.CastMemberVideo) VideoCastMemberDTO {
+	billingOrder := credit.BillingOrder
+	dto := VideoCastMemberDTO{
+		ID:           credit.CastMemberID,
+		Role:         CastMemberType(credit.Role),
+		Character:    credit.CharacterName.String,
+		BillingOrder: &billingOrder,
+	}
+	if credit.R != nil && credit.R.CastMember != nil {
+		dto.Name = credit.R.CastMember.Name
+	}
+	return dto
+}
+
 func (v *VideoDTO) Validate() error {
-	err := videoValidate.Struct(v)
-	if err != nil {
-		vErrs := err.(validator.ValidationErrors)
-		return fmt.Errorf("'%s' field %w", vErrs[0].StructField(), logger.ErrIsRequired)
+	var errs fieldErrors
+	errs.addStruct(videoValidate, v)
+	if v.Rating != nil {
+		if err := v.Rating.Validate(); err != nil {
+			errs.add("rating", "video_rating")
+		}
+	}
+	type credit struct {
+		id   string
+		role CastMemberType
+	}
+	credited := make(map[credit]bool, len(v.CastMembers))
+	for i := range v.CastMembers {
+		field := fmt.Sprintf("cast_members[%d]", i)
+		v.CastMembers[i].validate(field, &errs)
+		c := credit{strings.ToLower(strings.TrimSpace(v.CastMembers[i].ID)), v.CastMembers[i].Role}
+		if credited[c] {
+			errs.add(field, "unique")
+		}
+		credited[c] = true
 	}
-	if err := v.Rating.Validate(); err != nil {
-		return err
+	return errs.err()
+}
+
+// normalizeCast writes the ids of the cast the way they are stored and numbers the credits without a billing order
+func (v *VideoDTO) normalizeCast() {
+	for i := range v.CastMembers {
+		if id, err := uuid.Parse(strings.TrimSpace(v.CastMembers[i].ID)); err == nil {
+			v.CastMembers[i].ID = id.String()
+		}
+		v.CastMembers[i].Character = strings.TrimSpace(v.CastMembers[i].Character)
+		if v.CastMembers[i].BillingOrder == nil {
+			billingOrder := int16(i + 1)
+			v.CastMembers[i].BillingOrder = &billingOrder
+		}
 	}
-	return nil
 }
 
 func init() {
-	videoValidate = validator.New()
-	videoValidate.RegisterValidation("not_blank", validators.NotBlank)
+	videoValidate = newValidator()
 }
@@ -0,0 +1,43 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// DefaultSearchLanguage parses the searches that do not name their text search configuration
+const DefaultSearchLanguage = "english"
+
+// VideoSearch asks for the videos matching Query, written like a web search: words, "quoted phrases", or and
+// -excluded words. Language is the text search configuration the query is parsed with.
+type VideoSearch struct {
+	Query    string
+	Language string
+}
+
+// VideoMatch is a video found by a search with its relevance, Title and Description are its headlines with
+// the matching words highlighted
+type VideoMatch struct {
+	Video       *models.Video
+	Rank        float32
+	Title       string
+	Description string
+}
+
+func (s service) SearchVideos(search VideoSearch, page Page) ([]VideoMatch, PageInfo, error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if len(search.Query) == 0 {
+		return nil, PageInfo{}, fmt.Errorf("'q' %w", logger.ErrIsRequired)
+	}
+	search.Language = strings.ToLower(strings.TrimSpace(search.Language))
+	if search.Language == "" {
+		search.Language = s.searchLanguage
+	}
+	if err := page.NormalizeNumbered("a search"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return s.r.SearchVideos(search, page)
+}
@@ -0,0 +1,103 @@
+package crud_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/crud/mock"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func Test_service_SearchVideos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeMatches := []crud.VideoMatch{
+		{
+			Video:       testdata.FakeVideoSlice[0],
+			Rank:        0.6,
+			Title:       "<mark>" + testdata.FakeVideoSlice[0].Title + "</mark>",
+			Description: testdata.FakeVideoSlice[0].Description,
+		},
+	}
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeMatches)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{"fake"}, ID: uuid.New().String()}
+	type args struct {
+		search crud.VideoSearch
+		page   crud.Page
+	}
+	tests := []struct {
+		name       string
+		opts       []crud.ServiceOption
+		args       args
+		repoSearch crud.VideoSearch
+		repoPage   crud.Page
+		want       []crud.VideoMatch
+		wantErr    error
+	}{
+		{
+			name:    "When query is blank",
+			args:    args{crud.VideoSearch{Query: " "}, crud.Page{}},
+			wantErr: logger.ErrIsRequired,
+		},
+		{
+			name:    "When cursor is given",
+			args:    args{crud.VideoSearch{Query: "fake"}, crud.Page{Cursor: fakeCursor}},
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When per_page is less than zero",
+			args:    args{crud.VideoSearch{Query: "fake"}, crud.Page{PerPage: -1}},
+			wantErr: logger.ErrInvalidedLimit,
+		},
+		{
+			name:       "When language is not given",
+			args:       args{crud.VideoSearch{Query: " fake "}, crud.Page{}},
+			repoSearch: crud.VideoSearch{Query: "fake", Language: crud.DefaultSearchLanguage},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       fakeMatches,
+		},
+		{
+			name:       "When the service has its own language",
+			opts:       []crud.ServiceOption{crud.WithSearchLanguage("Portuguese")},
+			args:       args{crud.VideoSearch{Query: "fake"}, crud.Page{Number: 2}},
+			repoSearch: crud.VideoSearch{Query: "fake", Language: "portuguese"},
+			repoPage:   crud.Page{Number: 2, PerPage: crud.DefaultPerPage},
+			want:       fakeMatches,
+		},
+		{
+			name:       "When language is given",
+			opts:       []crud.ServiceOption{crud.WithSearchLanguage("portuguese")},
+			args:       args{crud.VideoSearch{Query: "fake", Language: "Simple"}, crud.Page{}},
+			repoSearch: crud.VideoSearch{Query: "fake", Language: "simple"},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       fakeMatches,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				mockR.EXPECT().
+					SearchVideos(tt.repoSearch, tt.repoPage).
+					Return(fakeMatches, fakePageInfo, nil)
+			}
+			s := crud.NewService(mockR, tt.opts...)
+			got, info, err := s.SearchVideos(tt.args.search, tt.args.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SearchVideos() error: %v, want: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchVideos() got: %v, want: %v", got, tt.want)
+			}
+			if tt.wantErr == nil && info != fakePageInfo {
+				t.Errorf("SearchVideos() info: %+v, want: %+v", info, fakePageInfo)
+			}
+		})
+	}
+}
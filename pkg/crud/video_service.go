@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/google/uuid"
@@ -12,46 +13,103 @@ import (
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (s service) RemoveVideo(title string) error {
-	title = strings.ToLower(strings.TrimSpace(title))
-	if len(title) == 0 {
-		return fmt.Errorf("'title' %w", logger.ErrIsRequired)
+func (s service) RemoveVideo(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if err := s.r.RemoveVideo(id, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) GetVideosInTrash(page Page) (models.VideoSlice, PageInfo, error) {
+	if err := page.NormalizeNumbered("a trash"); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return s.r.GetVideosInTrash(page)
+}
+
+func (s service) RestoreVideo(id string) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.r.RestoreVideo(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s service) PurgeVideo(id string, version int64) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
 	}
-	if err := s.r.RemoveVideo(title); err != nil {
+	if err := s.r.PurgeVideo(id, version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%s: %w", title, logger.ErrNotFound)
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return err
 	}
 	return nil
 }
 
-func (s service) UpdateVideo(title string, videoDTO VideoDTO) (uuid.UUID, error) {
-	title = strings.ToLower(strings.TrimSpace(title))
-	if len(title) == 0 {
-		return uuid.UUID{}, fmt.Errorf("'title' %w", logger.ErrIsRequired)
+func (s service) UpdateVideo(id string, version int64, videoDTO VideoDTO) (uuid.UUID, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if err := checkVersion(version); err != nil {
+		return uuid.UUID{}, err
 	}
 	if err := videoDTO.Validate(); err != nil {
 		return uuid.UUID{}, err
 	}
-	videoDTO.Title = strings.ToLower(strings.TrimSpace(videoDTO.Title))
+	videoDTO.Title = NormalizeName(videoDTO.Title)
 	videoDTO.Description = strings.TrimSpace(videoDTO.Description)
-	id, err := s.r.UpdateVideo(title, videoDTO)
+	videoDTO.Language = strings.ToLower(strings.TrimSpace(videoDTO.Language))
+	videoDTO.normalizeCast()
+	if videoDTO.Files != nil {
+		videoDTO.Files = validatedAssets{videoDTO.Files, s.assets}
+	}
+	updatedID, err := s.r.UpdateVideo(id, version, videoDTO)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return uuid.UUID{}, fmt.Errorf("%s: %w", title, logger.ErrNotFound)
+			return uuid.UUID{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 		}
 		return uuid.UUID{}, err
 	}
-	return id, nil
+	return updatedID, nil
 }
 
 func (s service) AddVideo(videoDTO VideoDTO) (uuid.UUID, error) {
-	videoDTO.Title = strings.ToLower(strings.TrimSpace(videoDTO.Title))
+	videoDTO.Title = NormalizeName(videoDTO.Title)
 	videoDTO.Description = strings.TrimSpace(videoDTO.Description)
+	videoDTO.Language = strings.ToLower(strings.TrimSpace(videoDTO.Language))
+	if videoDTO.Language == "" {
+		videoDTO.Language = s.searchLanguage
+	}
 	if err := videoDTO.Validate(); err != nil {
 		return uuid.UUID{}, err
 	}
+	videoDTO.normalizeCast()
+	if videoDTO.Files != nil {
+		videoDTO.Files = validatedAssets{videoDTO.Files, s.assets}
+	}
 	id, err := s.r.AddVideo(videoDTO)
 	if err != nil {
 		return uuid.UUID{}, err
@@ -59,21 +117,66 @@ func (s service) AddVideo(videoDTO VideoDTO) (uuid.UUID, error) {
 	return id, nil
 }
 
-func (s service) GetVideos(limit int) (models.VideoSlice, error) {
-	if limit < 0 {
-		return nil, logger.ErrInvalidedLimit
+func (s service) GetVideos(filter Filter, page Page) (models.VideoSlice, PageInfo, error) {
+	if err := filter.normalize(videoSortFields, &page); err != nil {
+		return nil, PageInfo{}, err
 	}
-	return s.r.GetVideos(limit)
+	return s.r.GetVideos(filter, page)
 }
 
-func (s service) FetchVideo(title string) (models.Video, error) {
-	title = strings.ToLower(strings.TrimSpace(title))
-	c, err := s.r.FetchVideo(title)
+func (s service) FetchVideo(id string) (models.Video, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return models.Video{}, err
+	}
+	c, err := s.r.FetchVideo(id)
 	if err == sql.ErrNoRows {
-		return models.Video{}, fmt.Errorf("%s: %w", title, logger.ErrNotFound)
+		return models.Video{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
 	} else if err != nil {
-		return models.Video{}, fmt.Errorf("%s: %w", title, logger.ErrInternalApplication)
+		return models.Video{}, fmt.Errorf("%s: %w", id, logger.ErrInternalApplication)
 	}
 
 	return c, nil
 }
+
+func (s service) OpenVideoAsset(id string, kind AssetKind) (VideoFile, error) {
+	id, err := normalizeID(id)
+	if err != nil {
+		return VideoFile{}, err
+	}
+	if err := kind.Validate(); err != nil {
+		return VideoFile{}, err
+	}
+	f, err := s.r.OpenVideoAsset(id, kind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, logger.ErrNotFound) {
+			return VideoFile{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return VideoFile{}, fmt.Errorf("%s: %w", id, logger.ErrInternalApplication)
+	}
+	return f, nil
+}
+
+func (s service) AttachVideoAsset(id string, kind AssetKind, file io.Reader) error {
+	id, err := normalizeID(id)
+	if err != nil {
+		return err
+	}
+	if err := kind.Validate(); err != nil {
+		return err
+	}
+	if file == nil {
+		return fmt.Errorf("'file' %w", logger.ErrIsRequired)
+	}
+	assetReader, err := s.assets.NewReader(kind, file)
+	if err != nil {
+		return err
+	}
+	if err := s.r.AttachVideoAsset(id, kind, assetReader); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
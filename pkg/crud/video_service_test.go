@@ -1,11 +1,15 @@
 package crud_test
 
 import (
+	"bytes"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bxcodec/faker/v3"
 	"github.com/golang/mock/gomock"
@@ -28,6 +32,10 @@ var (
 	fakeNotValidatedRating = new(crud.VideoRating)
 )
 
+func billingOrder(order int16) *int16 {
+	return &order
+}
+
 func TestAddVideo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -67,7 +75,7 @@ func TestAddVideo(t *testing.T) {
 		{
 			name:    "When VideoDTO is not provided",
 			args:    args{crud.VideoDTO{}},
-			want:    returns{err: fmt.Errorf("'Title' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "title", Rule: "not_blank"}, {Field: "year_launched", Rule: "required"}, {Field: "rating", Rule: "required"}, {Field: "duration", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -79,7 +87,7 @@ func TestAddVideo(t *testing.T) {
 				Rating:       fakeRating,
 				Duration:     fakeDuration,
 			}},
-			want:    returns{err: fmt.Errorf("'Title' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "title", Rule: "not_blank"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -91,7 +99,7 @@ func TestAddVideo(t *testing.T) {
 				Rating:       fakeRating,
 				Duration:     fakeDuration,
 			}},
-			want:    returns{err: fmt.Errorf("'YearLaunched' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "year_launched", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -103,7 +111,7 @@ func TestAddVideo(t *testing.T) {
 				Rating:       nil,
 				Duration:     fakeDuration,
 			}},
-			want:    returns{err: fmt.Errorf("'Rating' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "rating", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -115,7 +123,7 @@ func TestAddVideo(t *testing.T) {
 				Rating:       fakeRating,
 				Duration:     nil,
 			}},
-			want:    returns{err: fmt.Errorf("'Duration' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "duration", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -147,7 +155,7 @@ func TestAddVideo(t *testing.T) {
 					Duration:     fakeDuration,
 				},
 			},
-			want:    returns{err: fmt.Errorf("'Categories' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
@@ -178,8 +186,10 @@ func TestAddVideo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr || tt.name == "When VideoDTO is with wrong categories and genres" {
 				tt.args.dto.Title = strings.ToLower(strings.TrimSpace(tt.args.dto.Title))
+				repoDTO := tt.args.dto
+				repoDTO.Language = crud.DefaultSearchLanguage
 				mockR.EXPECT().
-					AddVideo(tt.args.dto).
+					AddVideo(repoDTO).
 					Return(tt.want.id, tt.want.err)
 			}
 			s := crud.NewService(mockR)
@@ -195,14 +205,152 @@ func TestAddVideo(t *testing.T) {
 	}
 }
 
+func Test_service_AddVideo_Cast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeDirectorID := uuid.New().String()
+	fakeActorID := uuid.New().String()
+	fakeVideoDTO := func(cast ...crud.VideoCastMemberDTO) crud.VideoDTO {
+		return crud.VideoDTO{
+			Title:        "fake title",
+			YearLaunched: fakeYearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Genres:       []crud.GenreDTO{testdata.FakeGenresDTO[0]},
+			Categories:   []crud.CategoryDTO{testdata.FakeCategoriesDTO[0]},
+			CastMembers:  cast,
+		}
+	}
+	tests := []struct {
+		name     string
+		dto      crud.VideoDTO
+		repoCast []crud.VideoCastMemberDTO
+		wantErr  error
+	}{
+		{
+			name:    "When a cast member id is not an uuid",
+			dto:     fakeVideoDTO(crud.VideoCastMemberDTO{ID: "fakeCastMember", Role: crud.Actor}),
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When a role is unknown",
+			dto:     fakeVideoDTO(crud.VideoCastMemberDTO{ID: fakeActorID, Role: 111}),
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name:    "When a director has a character",
+			dto:     fakeVideoDTO(crud.VideoCastMemberDTO{ID: fakeDirectorID, Role: crud.Director, Character: "fake"}),
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name: "When a cast member is credited twice in the same role",
+			dto: fakeVideoDTO(
+				crud.VideoCastMemberDTO{ID: fakeActorID, Role: crud.Actor},
+				crud.VideoCastMemberDTO{ID: strings.ToUpper(fakeActorID), Role: crud.Actor},
+			),
+			wantErr: logger.ErrIsNotValidated,
+		},
+		{
+			name: "When the billing order is omitted",
+			dto: fakeVideoDTO(
+				crud.VideoCastMemberDTO{ID: fakeDirectorID, Role: crud.Director},
+				crud.VideoCastMemberDTO{ID: " " + strings.ToUpper(fakeActorID), Role: crud.Actor, Character: " fake "},
+				crud.VideoCastMemberDTO{ID: fakeDirectorID, Role: crud.Actor, BillingOrder: billingOrder(7)},
+			),
+			repoCast: []crud.VideoCastMemberDTO{
+				{ID: fakeDirectorID, Role: crud.Director, BillingOrder: billingOrder(1)},
+				{ID: fakeActorID, Role: crud.Actor, Character: "fake", BillingOrder: billingOrder(2)},
+				{ID: fakeDirectorID, Role: crud.Actor, BillingOrder: billingOrder(7)},
+			},
+		},
+		{
+			name: "When the billing order is 0",
+			dto: fakeVideoDTO(
+				crud.VideoCastMemberDTO{ID: fakeDirectorID, Role: crud.Director, BillingOrder: billingOrder(0)},
+				crud.VideoCastMemberDTO{ID: fakeActorID, Role: crud.Actor},
+			),
+			repoCast: []crud.VideoCastMemberDTO{
+				{ID: fakeDirectorID, Role: crud.Director, BillingOrder: billingOrder(0)},
+				{ID: fakeActorID, Role: crud.Actor, BillingOrder: billingOrder(2)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				repoDTO := fakeVideoDTO(tt.repoCast...)
+				repoDTO.Language = crud.DefaultSearchLanguage
+				mockR.EXPECT().
+					AddVideo(repoDTO).
+					Return(uuid.New(), nil)
+			}
+			s := crud.NewService(mockR)
+			_, err := s.AddVideo(tt.dto)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddVideo() error: %v, want: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_AddVideo_Language(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeVideoDTO := func(language string) crud.VideoDTO {
+		return crud.VideoDTO{
+			Title:        "fake title",
+			YearLaunched: fakeYearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Genres:       []crud.GenreDTO{testdata.FakeGenresDTO[0]},
+			Categories:   []crud.CategoryDTO{testdata.FakeCategoriesDTO[0]},
+			Language:     language,
+		}
+	}
+	tests := []struct {
+		name         string
+		language     string
+		repoLanguage string
+	}{
+		{
+			name:         "When the video is added without language",
+			repoLanguage: "portuguese",
+		},
+		{
+			name:         "When the video is added with its own language",
+			language:     " Simple ",
+			repoLanguage: "simple",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockR.EXPECT().
+				AddVideo(fakeVideoDTO(tt.repoLanguage)).
+				Return(uuid.New(), nil)
+			s := crud.NewService(mockR, crud.WithSearchLanguage("Portuguese"))
+			if _, err := s.AddVideo(fakeVideoDTO(tt.language)); err != nil {
+				t.Errorf("AddVideo() error: %v, want: nil", err)
+			}
+		})
+	}
+}
+
 func Test_service_RemoveVideo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	fakeExistTitle := testdata.FakeVideos[0].Title
-	const fakeDoesNotExistTitle = "fakeDoesNotExistTitle"
+	fakeExistID := testdata.FakeVideos[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	type args struct {
-		title string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -211,38 +359,37 @@ func Test_service_RemoveVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When title is blank",
+			name:    "When id is blank",
 			args:    args{"     "},
-			want:    fmt.Errorf("'title' %w", logger.ErrIsRequired),
+			want:    fmt.Errorf("'id' %w", logger.ErrIsRequired),
 			wantErr: true,
 		},
 		{
-			name:    "When title is not found",
-			args:    args{fakeDoesNotExistTitle},
-			want:    fmt.Errorf("%s: %w", fakeDoesNotExistTitle, logger.ErrNotFound),
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID},
+			want:    fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			wantErr: true,
 		},
 		{
-			name:    "When title is found",
-			args:    args{fakeExistTitle},
+			name:    "When id is found",
+			args:    args{fakeExistID},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "When title is not found" {
-				tt.args.title = strings.ToLower(strings.ToLower(tt.args.title))
+			if tt.name == "When id is not found" {
 				mockR.EXPECT().
-					RemoveVideo(tt.args.title).
+					RemoveVideo(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
-			} else if tt.name == "When title is found" {
+			} else if tt.name == "When id is found" {
 				mockR.EXPECT().
-					RemoveVideo(tt.args.title).
+					RemoveVideo(tt.args.id, crud.AnyVersion).
 					Return(tt.want)
 			}
 			s := crud.NewService(mockR)
-			err := s.RemoveVideo(tt.args.title)
+			err := s.RemoveVideo(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveVideo() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -259,12 +406,12 @@ func Test_service_UpdateVideo(t *testing.T) {
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
 	const (
-		fakeExistTitle        = "fakeExistTitle"
-		fakeDoesNotExistTitle = "fakeDoesNotExistTitle"
-		fakeOpened            = false
-		fakeCategoryIndex     = 0
-		fakeGenreIndex        = 0
+		fakeOpened        = false
+		fakeCategoryIndex = 0
+		fakeGenreIndex    = 0
 	)
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
 	fakeTitle := faker.Name()
 	fakeDescription := faker.Sentence()
 	*fakeYearLaunched = 2020
@@ -280,8 +427,8 @@ func Test_service_UpdateVideo(t *testing.T) {
 		r sqlboiler.Repository
 	}
 	type args struct {
-		title string
-		dto   crud.VideoDTO
+		id  string
+		dto crud.VideoDTO
 	}
 	type returns struct {
 		id  uuid.UUID
@@ -295,26 +442,26 @@ func Test_service_UpdateVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title is blank",
+			name: "When id is blank",
 			args: args{
 				"     ",
 				crud.VideoDTO{
 					Title: faker.Name(),
 				},
 			},
-			want:    returns{err: fmt.Errorf("'title' %w", logger.ErrIsRequired)},
+			want:    returns{err: fmt.Errorf("'id' %w", logger.ErrIsRequired)},
 			wantErr: true,
 		},
 		{
 			name:    "When VideoDTO is not provided",
-			args:    args{fakeExistTitle, crud.VideoDTO{}},
-			want:    returns{err: fmt.Errorf("'Title' field %w", logger.ErrIsRequired)},
+			args:    args{fakeExistID, crud.VideoDTO{}},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "title", Rule: "not_blank"}, {Field: "year_launched", Rule: "required"}, {Field: "rating", Rule: "required"}, {Field: "duration", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
 			name: "When the Title in VideoDTO is blank",
 			args: args{
-				title: fakeExistTitle,
+				id: fakeExistID,
 				dto: crud.VideoDTO{
 					Title:        "    ",
 					YearLaunched: fakeYearLaunched,
@@ -322,13 +469,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Rating:       fakeRating,
 					Duration:     fakeDuration,
 				}},
-			want:    returns{err: fmt.Errorf("'Title' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "title", Rule: "not_blank"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
 			name: "When the YearLaunched in VideoDTO is blank",
 			args: args{
-				title: fakeExistTitle,
+				id: fakeExistID,
 				dto: crud.VideoDTO{
 					Title:        fakeTitle,
 					YearLaunched: nil,
@@ -336,13 +483,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Rating:       fakeRating,
 					Duration:     fakeDuration,
 				}},
-			want:    returns{err: fmt.Errorf("'YearLaunched' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "year_launched", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
 			name: "When the Rating in VideoDTO is blank",
 			args: args{
-				title: fakeTitle,
+				id: fakeExistID,
 				dto: crud.VideoDTO{
 					Title:        fakeTitle,
 					YearLaunched: fakeYearLaunched,
@@ -350,13 +497,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Rating:       nil,
 					Duration:     fakeDuration,
 				}},
-			want:    returns{err: fmt.Errorf("'Rating' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "rating", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
 			name: "When the Duration in VideoDTO is blank",
 			args: args{
-				title: fakeTitle,
+				id: fakeExistID,
 				dto: crud.VideoDTO{
 					Title:        fakeTitle,
 					YearLaunched: fakeYearLaunched,
@@ -365,13 +512,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Duration:     nil,
 				},
 			},
-			want:    returns{err: fmt.Errorf("'Duration' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "duration", Rule: "required"}, {Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
 			name: "When VideoDTO is with wrong categories and genres",
 			args: args{
-				fakeExistTitle,
+				fakeExistID,
 				crud.VideoDTO{
 					Title:        fakeTitle,
 					Description:  fakeDescription,
@@ -389,7 +536,7 @@ func Test_service_UpdateVideo(t *testing.T) {
 		{
 			name: "When CategoryDTO is without categories and genres",
 			args: args{
-				fakeExistTitle,
+				fakeExistID,
 				crud.VideoDTO{
 					Title:        fakeTitle,
 					Description:  fakeDescription,
@@ -399,13 +546,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Duration:     fakeDuration,
 				},
 			},
-			want:    returns{err: fmt.Errorf("'Categories' field %w", logger.ErrIsRequired)},
+			want:    returns{err: &crud.ValidationError{Fields: []crud.FieldError{{Field: "categories", Rule: "not_blank"}, {Field: "genres", Rule: "not_blank"}}}},
 			wantErr: true,
 		},
 		{
-			name: "When title is not found",
+			name: "When id is not found",
 			args: args{
-				fakeDoesNotExistTitle,
+				fakeDoesNotExistID,
 				crud.VideoDTO{
 					Title:        fakeTitle,
 					YearLaunched: fakeYearLaunched,
@@ -416,13 +563,13 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Categories:   []crud.CategoryDTO{fakeExistCategoryDTO},
 				},
 			},
-			want:    returns{err: fmt.Errorf("%s: %w", fakeDoesNotExistTitle, logger.ErrNotFound)},
+			want:    returns{err: fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound)},
 			wantErr: true,
 		},
 		{
-			name: "When title is found and VideoDTO is right",
+			name: "When id is found and VideoDTO is right",
 			args: args{
-				fakeExistTitle,
+				fakeExistID,
 				crud.VideoDTO{
 					Title:        fakeTitle,
 					YearLaunched: fakeYearLaunched,
@@ -441,8 +588,8 @@ func Test_service_UpdateVideo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.name == "When the Title in CategoryDTO already exists" ||
 				tt.name == "When VideoDTO is with wrong categories and genres" ||
-				tt.name == "When title is not found" ||
-				tt.name == "When title is found and VideoDTO is right" {
+				tt.name == "When id is not found" ||
+				tt.name == "When id is found and VideoDTO is right" {
 				dto := crud.VideoDTO{
 					Title:        strings.ToLower(strings.TrimSpace(tt.args.dto.Title)),
 					Description:  tt.args.dto.Description,
@@ -453,13 +600,12 @@ func Test_service_UpdateVideo(t *testing.T) {
 					Categories:   tt.args.dto.Categories,
 					Genres:       tt.args.dto.Genres,
 				}
-				title := strings.ToLower(strings.TrimSpace(tt.args.title))
 				mockR.EXPECT().
-					UpdateVideo(title, dto).
+					UpdateVideo(tt.args.id, crud.AnyVersion, dto).
 					Return(tt.want.id, tt.want.err)
 			}
 			s := crud.NewService(mockR)
-			_, err := s.UpdateVideo(tt.args.title, tt.args.dto)
+			_, err := s.UpdateVideo(tt.args.id, crud.AnyVersion, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateVideo() error: %v, wantErr: %v", err, tt.wantErr)
 				return
@@ -476,45 +622,93 @@ func Test_service_GetVideos(t *testing.T) {
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
 	fakeVideoSlice := testdata.FakeVideoSlice
-	const fakeLimit = testdata.FakeVideosLength
+	fakePageInfo := crud.PageInfo{Total: int64(len(fakeVideoSlice)), Number: 1, PerPage: crud.DefaultPerPage}
+	fakeCursor := &crud.Cursor{Sort: "created_at", Keys: []interface{}{time.Now()}, ID: uuid.New().String()}
+	fakeSortedFilter := crud.Filter{Sort: []crud.SortKey{{Field: "title", Desc: true}}}
 	type args struct {
-		limit int
+		filter crud.Filter
+		page   crud.Page
 	}
 	type returns struct {
 		videos models.VideoSlice
 		err    error
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    returns
-		wantErr bool
+		name       string
+		args       args
+		repoFilter crud.Filter
+		repoPage   crud.Page
+		want       returns
+		wantErr    bool
 	}{
 		{
-			name:    "When limit is less than zero",
-			args:    args{-1},
+			name:    "When per_page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{PerPage: -1}},
 			want:    returns{nil, logger.ErrInvalidedLimit},
 			wantErr: true,
 		},
 		{
-			name:    "When limit is right",
-			args:    args{fakeLimit},
-			want:    returns{fakeVideoSlice, nil},
-			wantErr: false,
+			name:    "When page is less than zero",
+			args:    args{crud.Filter{}, crud.Page{Number: -1}},
+			want:    returns{nil, logger.ErrInvalidedLimit},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor and page are both given",
+			args:    args{crud.Filter{}, crud.Page{Cursor: fakeCursor, Number: 2}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When page is not given",
+			args:       args{crud.Filter{}, crud.Page{}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Number: 1, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeVideoSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:       "When per_page is above the maximum",
+			args:       args{crud.Filter{}, crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage + 1}},
+			repoFilter: crud.Filter{Sort: crud.DefaultSort},
+			repoPage:   crud.Page{Cursor: fakeCursor, PerPage: crud.MaxPerPage},
+			want:       returns{fakeVideoSlice, nil},
+			wantErr:    false,
+		},
+		{
+			name:    "When sort field is unknown",
+			args:    args{crud.Filter{Sort: []crud.SortKey{{Field: "fake_field"}}}, crud.Page{}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:    "When cursor is of another sort",
+			args:    args{fakeSortedFilter, crud.Page{Cursor: fakeCursor}},
+			want:    returns{nil, logger.ErrIsNotValidated},
+			wantErr: true,
+		},
+		{
+			name:       "When the list is sorted",
+			args:       args{fakeSortedFilter, crud.Page{Number: 2}},
+			repoFilter: fakeSortedFilter,
+			repoPage:   crud.Page{Number: 2, PerPage: crud.DefaultPerPage},
+			want:       returns{fakeVideoSlice, nil},
+			wantErr:    false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
 				mockR.EXPECT().
-					GetVideos(tt.args.limit).
+					GetVideos(tt.repoFilter, tt.repoPage).
 					Return(
 						fakeVideoSlice,
+						fakePageInfo,
 						nil,
 					)
 			}
 			s := crud.NewService(mockR)
-			got, err := s.GetVideos(tt.args.limit)
+			got, info, err := s.GetVideos(tt.args.filter, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetVideos() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -522,9 +716,12 @@ func Test_service_GetVideos(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want.videos) {
 				t.Errorf("GetVideos() got = %v, want %v", got, tt.want.videos)
 			}
-			if !reflect.DeepEqual(err, tt.want.err) {
+			if !errors.Is(err, tt.want.err) {
 				t.Errorf("GetVideos() got = %v, want %v", err, tt.want.err)
 			}
+			if !tt.wantErr && info != fakePageInfo {
+				t.Errorf("GetVideos() info = %+v, want %+v", info, fakePageInfo)
+			}
 		})
 	}
 }
@@ -533,14 +730,13 @@ func Test_service_FetchVideo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockR := mock.NewMockRepository(ctrl)
-	const (
-		fakeDoesNotExistTitle = "fakeDoesNotExistTitle"
-		fakeExistTitle        = "fakeExistTitle"
-	)
-	fakeErrorInternalApplication := fmt.Errorf("anyname: %w", logger.ErrInternalApplication)
+	fakeAnyID := uuid.New().String()
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeErrorInternalApplication := fmt.Errorf("%s: %w", fakeAnyID, logger.ErrInternalApplication)
 	fakeVideo := models.Video{
-		ID:           uuid.New().String(),
-		Title:        fakeExistTitle,
+		ID:           fakeExistID,
+		Title:        strings.ToLower(faker.Name()),
 		Description:  faker.Sentence(),
 		YearLaunched: 2020,
 		Opened:       null.Bool{Bool: true, Valid: true},
@@ -548,7 +744,7 @@ func Test_service_FetchVideo(t *testing.T) {
 		Duration:     150,
 	}
 	type args struct {
-		title string
+		id string
 	}
 	type returns struct {
 		video models.Video
@@ -563,7 +759,7 @@ func Test_service_FetchVideo(t *testing.T) {
 	}{
 		{
 			name: "When throw the error internal application",
-			args: args{"anyName"},
+			args: args{fakeAnyID},
 			want: returns{
 				models.Video{},
 				fakeErrorInternalApplication,
@@ -571,7 +767,7 @@ func Test_service_FetchVideo(t *testing.T) {
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchVideo("anyname").
+					FetchVideo(fakeAnyID).
 					Return(
 						models.Video{},
 						fakeErrorInternalApplication,
@@ -579,16 +775,16 @@ func Test_service_FetchVideo(t *testing.T) {
 			},
 		},
 		{
-			name: "When title is not found",
-			args: args{fakeDoesNotExistTitle},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.Video{},
-				fmt.Errorf("%s: %w", strings.ToLower(strings.ToLower(fakeDoesNotExistTitle)), logger.ErrNotFound),
+				fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
 			},
 			wantErr: true,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchVideo(strings.ToLower(strings.ToLower(fakeDoesNotExistTitle))).
+					FetchVideo(fakeDoesNotExistID).
 					Return(
 						models.Video{},
 						sql.ErrNoRows,
@@ -596,8 +792,8 @@ func Test_service_FetchVideo(t *testing.T) {
 			},
 		},
 		{
-			name: "When title is found",
-			args: args{fakeExistTitle},
+			name: "When id is found",
+			args: args{fakeExistID},
 			want: returns{
 				fakeVideo,
 				nil,
@@ -605,7 +801,7 @@ func Test_service_FetchVideo(t *testing.T) {
 			wantErr: false,
 			setupMockR: func() {
 				mockR.EXPECT().
-					FetchVideo(strings.ToLower(strings.TrimSpace(fakeExistTitle))).
+					FetchVideo(fakeExistID).
 					Return(
 						fakeVideo,
 						nil,
@@ -617,7 +813,7 @@ func Test_service_FetchVideo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMockR()
 			s := crud.NewService(mockR)
-			got, err := s.FetchVideo(tt.args.title)
+			got, err := s.FetchVideo(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchVideo() error: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -629,3 +825,205 @@ func Test_service_FetchVideo(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_OpenVideoAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeVideoFile := crud.VideoFile{
+		Name: fmt.Sprintf("%x", faker.Sentence()),
+	}
+	type args struct {
+		id   string
+		kind crud.AssetKind
+	}
+	type returns struct {
+		videoFile crud.VideoFile
+		err       error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       returns
+		wantErr    bool
+		setupMockR func()
+	}{
+		{
+			name: "When id is blank",
+			args: args{"     ", crud.VideoAsset},
+			want: returns{
+				crud.VideoFile{},
+				fmt.Errorf("'id' %w", logger.ErrIsRequired),
+			},
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name: "When kind is unknown",
+			args: args{fakeExistID, "fakeKind"},
+			want: returns{
+				crud.VideoFile{},
+				fmt.Errorf("asset kind '%s' %w", "fakeKind", logger.ErrIsNotValidated),
+			},
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID, crud.VideoAsset},
+			want: returns{
+				crud.VideoFile{},
+				fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
+			},
+			wantErr: true,
+			setupMockR: func() {
+				mockR.EXPECT().
+					OpenVideoAsset(fakeDoesNotExistID, crud.VideoAsset).
+					Return(crud.VideoFile{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "When video has no file",
+			args: args{fakeExistID, crud.TrailerAsset},
+			want: returns{
+				crud.VideoFile{},
+				fmt.Errorf("%s: %w", fakeExistID, logger.ErrNotFound),
+			},
+			wantErr: true,
+			setupMockR: func() {
+				mockR.EXPECT().
+					OpenVideoAsset(fakeExistID, crud.TrailerAsset).
+					Return(crud.VideoFile{}, fmt.Errorf("file of video %w", logger.ErrNotFound))
+			},
+		},
+		{
+			name: "When video file is found",
+			args: args{fakeExistID, crud.VideoAsset},
+			want: returns{
+				fakeVideoFile,
+				nil,
+			},
+			wantErr: false,
+			setupMockR: func() {
+				mockR.EXPECT().
+					OpenVideoAsset(fakeExistID, crud.VideoAsset).
+					Return(fakeVideoFile, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMockR()
+			s := crud.NewService(mockR)
+			got, err := s.OpenVideoAsset(tt.args.id, tt.args.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OpenVideoAsset() error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want.videoFile, got, "they should be equal")
+			if err != nil && err.Error() != tt.want.err.Error() {
+				t.Errorf("OpenVideoAsset() got: %v, want: %v", err, tt.want.err)
+			}
+		})
+	}
+}
+
+func Test_service_AttachVideoAsset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockR := mock.NewMockRepository(ctrl)
+	fakeExistID := uuid.New().String()
+	fakeDoesNotExistID := uuid.New().String()
+	fakeVideo := testdata.FakeMP4(16)
+	fakeImage := testdata.FakePNG(16)
+	type args struct {
+		id   string
+		kind crud.AssetKind
+		file io.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       error
+		wantErr    bool
+		setupMockR func()
+	}{
+		{
+			name:       "When id is blank",
+			args:       args{"     ", crud.VideoAsset, bytes.NewReader(fakeVideo)},
+			want:       fmt.Errorf("'id' %w", logger.ErrIsRequired),
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name:       "When kind is unknown",
+			args:       args{fakeExistID, "fakeKind", bytes.NewReader(fakeVideo)},
+			want:       fmt.Errorf("asset kind '%s' %w", "fakeKind", logger.ErrIsNotValidated),
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name:       "When file is not provided",
+			args:       args{fakeExistID, crud.VideoAsset, nil},
+			want:       fmt.Errorf("'file' %w", logger.ErrIsRequired),
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name: "When content type is not allowed for the kind",
+			args: args{fakeExistID, crud.ThumbnailAsset, bytes.NewReader(fakeVideo)},
+			want: &crud.AssetError{
+				Kind:    crud.ThumbnailAsset,
+				Reasons: []string{"content type 'video/mp4' is not allowed, want image/jpeg, image/png"},
+			},
+			wantErr:    true,
+			setupMockR: func() {},
+		},
+		{
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID, crud.VideoAsset, bytes.NewReader(fakeVideo)},
+			want:    fmt.Errorf("%s: %w", fakeDoesNotExistID, logger.ErrNotFound),
+			wantErr: true,
+			setupMockR: func() {
+				mockR.EXPECT().
+					AttachVideoAsset(
+						fakeDoesNotExistID,
+						crud.VideoAsset,
+						gomock.AssignableToTypeOf(&crud.AssetReader{}),
+					).
+					Return(sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "When id is found",
+			args:    args{fakeExistID, crud.ThumbnailAsset, bytes.NewReader(fakeImage)},
+			want:    nil,
+			wantErr: false,
+			setupMockR: func() {
+				mockR.EXPECT().
+					AttachVideoAsset(
+						fakeExistID,
+						crud.ThumbnailAsset,
+						gomock.AssignableToTypeOf(&crud.AssetReader{}),
+					).
+					Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMockR()
+			s := crud.NewService(mockR)
+			err := s.AttachVideoAsset(tt.args.id, tt.args.kind, tt.args.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachVideoAsset() error: %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.want.Error() {
+				t.Errorf("AttachVideoAsset() got: %v, want: %v", err, tt.want)
+			}
+		})
+	}
+}
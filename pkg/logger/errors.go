@@ -11,4 +11,9 @@ var (
 	ErrIsNotValidated      = errors.New("is not validated")
 	ErrIsRequired          = errors.New("is required")
 	ErrAlreadyExists       = errors.New("already exists")
+	ErrIsTooLarge          = errors.New("is too large")
+	ErrIsStale             = errors.New("is stale")
+	ErrIsNotAuthenticated  = errors.New("is not authenticated")
+	ErrIsForbidden         = errors.New("is forbidden")
+	ErrIsRateLimited       = errors.New("is rate limited")
 )
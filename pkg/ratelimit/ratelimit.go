@@ -0,0 +1,148 @@
+// Package ratelimit limits the requests of each client by token buckets, which a store keeps
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// Class tells which limit a request counts against, the writes are limited apart from the reads. Every request
+// of an address counts against the limit of the address before its client is known.
+type Class string
+
+const (
+	Read    Class = "read"
+	Write   Class = "write"
+	Address Class = "address"
+)
+
+// Limit lets a client make Requests in a Period, all at once or spread over it. A zero limit does not limit.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled reports whether l limits the requests
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return "0"
+	}
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// ParseLimit reads a limit written like 120/1m, 0 does not limit
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "0" {
+		return Limit{}, nil
+	}
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("limit '%s' %w, it should be like 120/1m", s, logger.ErrIsNotValidated)
+	}
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("requests of the limit '%s' %w, they should be positive", s, logger.ErrIsNotValidated)
+	}
+	period, err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("period of the limit '%s' %w, it should be a positive duration", s, logger.ErrIsNotValidated)
+	}
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+// rate is the number of tokens given back to a bucket of l in a nanosecond
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / float64(l.Period)
+}
+
+// Config tells the limits of the reads and the writes of each client, and the one of the requests of each address
+type Config struct {
+	Read    Limit
+	Write   Limit
+	Address Limit
+}
+
+func (c Config) limit(class Class) Limit {
+	switch class {
+	case Write:
+		return c.Write
+	case Address:
+		return c.Address
+	}
+	return c.Read
+}
+
+// Result tells whether a request was allowed and what is left to its client
+type Result struct {
+	Allowed bool
+	Limit   Limit
+	// Remaining is the number of requests the client may still make at once
+	Remaining int
+	// Reset is how long the bucket takes to be full again, RetryAfter how long a refused client has to wait
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets of the clients by their key. A store shared by the instances of the server limits the
+// clients across them, it has to take the tokens atomically.
+type Store interface {
+	// Take takes a token from the bucket of key, which holds limit, at now
+	Take(key string, limit Limit, now time.Time) (Result, error)
+}
+
+// bucket holds the tokens of a client, they are given back at the rate of its limit up to its requests
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take gives back to b the tokens of the time elapsed since its update, then takes one of them when it can
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	capacity := float64(limit.Requests)
+	if b.updated.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)*limit.rate())
+	}
+	b.updated = now
+	result := Result{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - b.tokens) / limit.rate()))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration(math.Ceil((capacity - b.tokens) / limit.rate()))
+	return result
+}
+
+// Limiter takes a token from the bucket of the class of each request of a client
+type Limiter struct {
+	cfg   Config
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(cfg Config, store Store) *Limiter {
+	return &Limiter{cfg: cfg, store: store, now: time.Now}
+}
+
+// Allow takes a token for a request of client of class, every request is allowed when the class is not limited
+func (l *Limiter) Allow(client string, class Class) (Result, error) {
+	limit := l.cfg.limit(class)
+	if !limit.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+	return l.store.Take(string(class)+":"+client, limit, l.now())
+}
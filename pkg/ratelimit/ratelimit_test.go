@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Limit
+		wantErr error
+	}{
+		{name: "When the limit is valid", s: "120/1m", want: Limit{Requests: 120, Period: time.Minute}},
+		{name: "When the limit is zero", s: "0", want: Limit{}},
+		{name: "When the period is missing", s: "120", wantErr: logger.ErrIsNotValidated},
+		{name: "When the requests are not positive", s: "-1/1m", wantErr: logger.ErrIsNotValidated},
+		{name: "When the period is not a duration", s: "120/minute", wantErr: logger.ErrIsNotValidated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimit(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimit() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := Limit{Requests: 2, Period: 2 * time.Second}
+	now := time.Now()
+	store := NewMemoryStore()
+	take := func(t *testing.T, key string) Result {
+		t.Helper()
+		result, err := store.Take(key, limit, now)
+		if err != nil {
+			t.Fatalf("Take() error = %v", err)
+		}
+		return result
+	}
+	t.Run("When the bucket holds tokens", func(t *testing.T) {
+		for _, want := range []int{1, 0} {
+			result := take(t, "fake")
+			if !result.Allowed || result.Remaining != want {
+				t.Errorf("Take() got = %+v, want allowed with %d remaining", result, want)
+			}
+		}
+	})
+	t.Run("When the bucket is empty", func(t *testing.T) {
+		result := take(t, "fake")
+		if result.Allowed || result.RetryAfter != time.Second || result.Reset != 2*time.Second {
+			t.Errorf("Take() got = %+v, want refused for a second", result)
+		}
+		if result := take(t, "other"); !result.Allowed {
+			t.Errorf("Take() of another key got = %+v, want allowed", result)
+		}
+	})
+	t.Run("When the tokens are given back", func(t *testing.T) {
+		now = now.Add(time.Second)
+		if result := take(t, "fake"); !result.Allowed || result.Remaining != 0 {
+			t.Errorf("Take() got = %+v, want allowed with 0 remaining", result)
+		}
+		now = now.Add(time.Hour)
+		if result := take(t, "fake"); !result.Allowed || result.Remaining != 1 {
+			t.Errorf("Take() got = %+v, want allowed with 1 remaining", result)
+		}
+	})
+	t.Run("When the full buckets are swept", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		take(t, "fake")
+		if _, ok := store.buckets["other"]; ok {
+			t.Error("the full bucket of other was not swept")
+		}
+		if _, ok := store.buckets["fake"]; !ok {
+			t.Error("the bucket of fake was swept")
+		}
+	})
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	l := NewLimiter(Config{Write: Limit{Requests: 1, Period: time.Minute}}, NewMemoryStore())
+	for i := 0; i < 2; i++ {
+		if result, err := l.Allow("fake", Read); err != nil || !result.Allowed || result.Limit.Enabled() {
+			t.Errorf("Allow() of a read got = %+v, error = %v, want allowed without limit", result, err)
+		}
+	}
+	if result, err := l.Allow("fake", Write); err != nil || !result.Allowed {
+		t.Errorf("Allow() of a write got = %+v, error = %v, want allowed", result, err)
+	}
+	if result, err := l.Allow("fake", Write); err != nil || result.Allowed {
+		t.Errorf("Allow() of a write over the limit got = %+v, error = %v, want refused", result, err)
+	}
+	if result, err := l.Allow("fake", Address); err != nil || !result.Allowed || result.Limit.Enabled() {
+		t.Errorf("Allow() of an address got = %+v, error = %v, want allowed without limit", result, err)
+	}
+}
@@ -0,0 +1,358 @@
+package disk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+)
+
+const tempFilePrefix = ".tmp-"
+
+type repository struct {
+	Afs *afero.Afero
+}
+
+// NewRepository creates a files repository that keeps the video files under root on the local disk
+func NewRepository(root string) (*repository, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve root directory: %v", err)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("could not make root directory: %v", err)
+	}
+	r := &repository{
+		Afs: &afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), root)},
+	}
+	return r, nil
+}
+
+func (r *repository) Exists(videoID uuid.UUID, fileName string) (bool, error) {
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		return false, err
+	}
+	exists, err := r.Afs.Exists(filePath)
+	if err != nil {
+		return false, fmt.Errorf("could not verify if file exists: %v", err)
+	}
+	return exists, nil
+}
+
+func (r *repository) GetFileFromVideo(videoID uuid.UUID, fileName string) ([]byte, error) {
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return r.Afs.ReadFile(filePath)
+}
+
+func (r *repository) OpenFileFromVideo(videoID uuid.UUID, fileName string) (files.File, error) {
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	f, err := r.Afs.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", filePath, logger.ErrNotFound)
+		}
+		return nil, fmt.Errorf("could not open file: %v", err)
+	}
+	return f, nil
+}
+
+func (r *repository) SaveFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) error {
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		return err
+	}
+	if fileData == nil {
+		return nil
+	}
+	videoDir := filepath.Dir(filePath)
+	if err := r.Afs.MkdirAll(videoDir, 0755); err != nil {
+		return fmt.Errorf("could not make video directory: %v", err)
+	}
+	tmpFile, err := r.Afs.TempFile(videoDir, tempFilePrefix)
+	if err != nil {
+		return fmt.Errorf("could not create temp file: %v", err)
+	}
+	tmpPath := filepath.Join(videoDir, filepath.Base(tmpFile.Name()))
+	if err := writeAndSync(tmpFile, fileData); err != nil {
+		_ = r.Afs.Remove(tmpPath)
+		return err
+	}
+	if err := r.Afs.Rename(tmpPath, filePath); err != nil {
+		_ = r.Afs.Remove(tmpPath)
+		return fmt.Errorf("could not rename temp file: %v", err)
+	}
+	return r.syncDir(videoDir)
+}
+
+func (r *repository) StoreFileToVideo(videoID uuid.UUID, dir string, fileData io.Reader) (string, error) {
+	if videoID == (uuid.UUID{}) {
+		return "", fmt.Errorf("'videoID' %w", logger.ErrIsRequired)
+	}
+	if fileData == nil {
+		return "", fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	if dir != "" {
+		if err := files.ValidateDir(dir); err != nil {
+			return "", err
+		}
+	}
+	videoDir := filepath.Join(videoID.String(), filepath.FromSlash(dir))
+	if err := r.Afs.MkdirAll(videoDir, 0755); err != nil {
+		return "", fmt.Errorf("could not make video directory: %v", err)
+	}
+	tmpFile, err := r.Afs.TempFile(videoDir, tempFilePrefix)
+	if err != nil {
+		return "", fmt.Errorf("could not create temp file: %v", err)
+	}
+	tmpPath := filepath.Join(videoDir, filepath.Base(tmpFile.Name()))
+	hash := files.NewHash()
+	if err := writeAndSync(tmpFile, io.TeeReader(fileData, hash)); err != nil {
+		_ = r.Afs.Remove(tmpPath)
+		return "", err
+	}
+	fileName := path.Join(dir, files.HashName(hash))
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		_ = r.Afs.Remove(tmpPath)
+		return "", err
+	}
+	if err := r.Afs.Rename(tmpPath, filePath); err != nil {
+		_ = r.Afs.Remove(tmpPath)
+		return "", fmt.Errorf("could not rename temp file: %v", err)
+	}
+	if err := r.syncDir(videoDir); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+func (r *repository) UpdateFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) (bool, error) {
+	exists, err := r.Exists(videoID, fileName)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	if err := r.SaveFileToVideo(videoID, fileName, fileData); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) DeleteFileFromVideo(videoID uuid.UUID, fileName string) error {
+	filePath, err := filePath(videoID, fileName)
+	if err != nil {
+		return err
+	}
+	if err := r.Afs.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove file: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) ListFiles() ([]files.StoredFile, error) {
+	var stored []files.StoredFile
+	err := r.Afs.Walk(string(filepath.Separator), func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, ok := files.ParseKey(strings.TrimPrefix(filepath.ToSlash(p), "/"))
+		if !ok {
+			return nil
+		}
+		f.Size = info.Size()
+		f.ModTime = info.ModTime()
+		stored = append(stored, f)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list files: %v", err)
+	}
+	return stored, nil
+}
+
+func (r *repository) StageBlob(fileData io.Reader) (files.StagedBlob, error) {
+	if fileData == nil {
+		return files.StagedBlob{}, fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	if err := r.Afs.MkdirAll(files.BlobsDir, 0755); err != nil {
+		return files.StagedBlob{}, fmt.Errorf("could not make blobs directory: %v", err)
+	}
+	tmpFile, err := r.Afs.TempFile(files.BlobsDir, tempFilePrefix)
+	if err != nil {
+		return files.StagedBlob{}, fmt.Errorf("could not create temp file: %v", err)
+	}
+	tmpName := path.Join(files.BlobsDir, filepath.Base(tmpFile.Name()))
+	digest := files.NewDigest()
+	if err := writeAndSync(tmpFile, io.TeeReader(fileData, digest)); err != nil {
+		_ = r.Afs.Remove(filepath.FromSlash(tmpName))
+		return files.StagedBlob{}, err
+	}
+	return files.StagedBlob{Blob: digest.Blob(), TempName: tmpName}, nil
+}
+
+func (r *repository) CommitBlob(staged files.StagedBlob) (bool, error) {
+	tmpPath, err := stagedPath(staged)
+	if err != nil {
+		return false, err
+	}
+	blobPath, err := blobPath(staged.Hash)
+	if err != nil {
+		return false, err
+	}
+	exists, err := r.Afs.Exists(blobPath)
+	if err != nil {
+		return false, fmt.Errorf("could not verify if blob exists: %v", err)
+	}
+	if exists {
+		if err := r.Afs.Remove(tmpPath); err != nil {
+			return false, fmt.Errorf("could not remove temp file: %v", err)
+		}
+		return false, nil
+	}
+	blobDir := filepath.Dir(blobPath)
+	if err := r.Afs.MkdirAll(blobDir, 0755); err != nil {
+		return false, fmt.Errorf("could not make blob directory: %v", err)
+	}
+	if err := r.Afs.Rename(tmpPath, blobPath); err != nil {
+		return false, fmt.Errorf("could not rename temp file: %v", err)
+	}
+	if err := r.syncDir(blobDir); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) DiscardBlob(staged files.StagedBlob) error {
+	tmpPath, err := stagedPath(staged)
+	if err != nil {
+		return err
+	}
+	if err := r.Afs.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove temp file: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) OpenBlob(hash string) (files.File, error) {
+	blobPath, err := blobPath(hash)
+	if err != nil {
+		return nil, err
+	}
+	f, err := r.Afs.Open(blobPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("blob %s: %w", hash, logger.ErrNotFound)
+		}
+		return nil, fmt.Errorf("could not open blob: %v", err)
+	}
+	return f, nil
+}
+
+func (r *repository) DeleteBlob(hash string) error {
+	blobPath, err := blobPath(hash)
+	if err != nil {
+		return err
+	}
+	if err := r.Afs.Remove(blobPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove blob: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) ListBlobs() ([]files.Blob, error) {
+	exists, err := r.Afs.DirExists(files.BlobsDir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var blobs []files.Blob
+	err = r.Afs.Walk(files.BlobsDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		hash, ok := files.ParseBlobKey(filepath.ToSlash(p))
+		if !ok {
+			return nil
+		}
+		blobs = append(blobs, files.Blob{Hash: hash, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list blobs: %v", err)
+	}
+	return blobs, nil
+}
+
+func (r *repository) syncDir(dir string) error {
+	d, err := r.Afs.Open(dir)
+	if err != nil {
+		return fmt.Errorf("could not open video directory: %v", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("could not sync video directory: %v", err)
+	}
+	return nil
+}
+
+func writeAndSync(f afero.File, fileData io.Reader) error {
+	if _, err := io.Copy(f, fileData); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("could not write file: %v", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("could not sync file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not close file: %v", err)
+	}
+	return nil
+}
+
+func filePath(videoID uuid.UUID, fileName string) (string, error) {
+	key, err := files.Key(videoID, fileName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(key), nil
+}
+
+func blobPath(hash string) (string, error) {
+	key, err := files.BlobKey(hash)
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(key), nil
+}
+
+// stagedPath refuses the temp names that this repository does not give to the staged blobs
+func stagedPath(staged files.StagedBlob) (string, error) {
+	dir, name := path.Split(staged.TempName)
+	if dir != files.BlobsDir+"/" || !strings.HasPrefix(name, tempFilePrefix) || strings.ContainsRune(name, '\\') {
+		return "", fmt.Errorf("staged blob '%s' %w", staged.TempName, logger.ErrIsNotValidated)
+	}
+	return filepath.FromSlash(staged.TempName), nil
+}
@@ -0,0 +1,584 @@
+package disk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+type fileSeed struct {
+	Repo                     *repository
+	RootDir                  string
+	FakeVideoIDExists        uuid.UUID
+	FakeVideoIDDoesNotExist  uuid.UUID
+	FakeVideoFileNameExists  string
+	FakeVideoFileData        []byte
+	FakeFileNameDoesNotExist string
+}
+
+func setupFileTestCase() (*fileSeed, func(t *testing.T), error) {
+	rootDir, err := ioutil.TempDir("", "disk-repository")
+	if err != nil {
+		return nil, nil, fmt.Errorf("test: failed to make root directory: %v\n", err)
+	}
+	repo, err := NewRepository(rootDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("test: failed to create repository: %v\n", err)
+	}
+	fakeVideoIDExists := uuid.New()
+	if err := repo.Afs.Mkdir(fakeVideoIDExists.String(), 0755); err != nil {
+		return nil, nil, fmt.Errorf("test: failed to make video directory: %v\n", err)
+	}
+	fakeData := make([]byte, 20)
+	if _, err := rand.Read(fakeData); err != nil {
+		return nil, nil, fmt.Errorf("test: failed to generate a random Data: %v\n", err)
+	}
+	fakeFileNameExists := fmt.Sprintf("%x", fakeData)
+	filePath := filepath.Join(fakeVideoIDExists.String(), fakeFileNameExists)
+	if err := repo.Afs.WriteFile(filePath, fakeData, 0644); err != nil {
+		return nil, nil, fmt.Errorf("test: failed to write a new file: %v\n", err)
+	}
+	s := &fileSeed{
+		Repo:                     repo,
+		RootDir:                  rootDir,
+		FakeVideoIDExists:        fakeVideoIDExists,
+		FakeVideoIDDoesNotExist:  uuid.New(),
+		FakeVideoFileNameExists:  fakeFileNameExists,
+		FakeVideoFileData:        fakeData,
+		FakeFileNameDoesNotExist: "FakeFileNameDoesNotExist",
+	}
+	return s, func(t *testing.T) {
+		if err := os.RemoveAll(rootDir); err != nil {
+			t.Errorf("test: failed to remove root directory: %v", err)
+		}
+	}, nil
+}
+
+func Test_repository_Exists(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "when file name escapes the video directory",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: "../" + seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "when video id is empty",
+			args: args{
+				videoID:  uuid.UUID{},
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.Exists(tt.args.videoID, tt.args.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exists() error = %v, wantErr %v\n", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Exists() got: %v, want: %v\n", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_GetFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    seed.FakeVideoFileData,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.GetFileFromVideo(tt.args.videoID, tt.args.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFileFromVideo() error: %v, wantErr: %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFileFromVideo() got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_SaveFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID    uuid.UUID
+		fileName   string
+		fileReader io.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		fileExists bool
+		wantErr    bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			fileExists: false,
+			wantErr:    false,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			fileExists: false,
+			wantErr:    false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			fileExists: true,
+			wantErr:    false,
+		},
+		{
+			name: "when video does not exist and data is provided",
+			args: args{
+				videoID:    seed.FakeVideoIDDoesNotExist,
+				fileName:   seed.FakeVideoFileNameExists,
+				fileReader: bytes.NewReader(seed.FakeVideoFileData),
+			},
+			fileExists: true,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := seed.Repo.SaveFileToVideo(tt.args.videoID, tt.args.fileName, tt.args.fileReader); (err != nil) != tt.wantErr {
+				t.Fatalf("SaveFileToVideo() error: %v\n, wantErr: %v", err, tt.wantErr)
+			}
+			filePath := fmt.Sprintf("%s%c%s", tt.args.videoID, os.PathSeparator, tt.args.fileName)
+			exists, err := afero.Exists(seed.Repo.Afs.Fs, filePath)
+			if err != nil {
+				t.Fatalf("test: could not verify if file exist: %v\n", err)
+			}
+			if exists != tt.fileExists {
+				t.Fatalf("got: '%t' want: %t\n", exists, tt.fileExists)
+			}
+		})
+	}
+}
+
+func Test_repository_SaveFileToVideo_Atomic(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	if err := seed.Repo.SaveFileToVideo(seed.FakeVideoIDDoesNotExist, seed.FakeFileNameDoesNotExist, bytes.NewReader(fakeData)); err != nil {
+		t.Fatalf("SaveFileToVideo() error: %v\n", err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(seed.RootDir, seed.FakeVideoIDDoesNotExist.String(), seed.FakeFileNameDoesNotExist))
+	if err != nil {
+		t.Fatalf("test: could not read file: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("got: %v want: %v\n", got, fakeData)
+	}
+	infos, err := ioutil.ReadDir(filepath.Join(seed.RootDir, seed.FakeVideoIDDoesNotExist.String()))
+	if err != nil {
+		t.Fatalf("test: could not read video directory: %v\n", err)
+	}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), tempFilePrefix) {
+			t.Fatalf("temp file '%s' was left behind\n", info.Name())
+		}
+	}
+	if err := seed.Repo.SaveFileToVideo(seed.FakeVideoIDExists, "../../escaped", bytes.NewReader(fakeData)); err == nil {
+		t.Fatalf("SaveFileToVideo() accepted a file name outside the video directory\n")
+	}
+}
+
+func Test_repository_UpdateFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID    uuid.UUID
+		fileName   string
+		fileReader io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.UpdateFileToVideo(tt.args.videoID, tt.args.fileName, tt.args.fileReader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateFileToVideo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UpdateFileToVideo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_OpenFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	const fakeOffset = 5
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    nil,
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    seed.FakeVideoFileData[fakeOffset:],
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := seed.Repo.OpenFileFromVideo(tt.args.videoID, tt.args.fileName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenFileFromVideo() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			if _, err := f.Seek(fakeOffset, io.SeekStart); err != nil {
+				t.Fatalf("Seek() error: %v", err)
+			}
+			got, err := ioutil.ReadAll(f)
+			if err != nil {
+				t.Fatalf("test: could not read file: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("OpenFileFromVideo() got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_StoreFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	const fakeDir = "thumbnail"
+	fakeData := []byte("fake video data")
+	fakeHash := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	wantFileName := fakeDir + "/" + fakeHash
+	gotFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, fakeDir, bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("StoreFileToVideo() error: %v\n", err)
+	}
+	if gotFileName != wantFileName {
+		t.Fatalf("StoreFileToVideo() got: %s, want: %s\n", gotFileName, wantFileName)
+	}
+	infos, err := ioutil.ReadDir(filepath.Join(seed.RootDir, seed.FakeVideoIDDoesNotExist.String(), fakeDir))
+	if err != nil {
+		t.Fatalf("test: could not read video directory: %v\n", err)
+	}
+	if len(infos) != 1 || infos[0].Name() != fakeHash {
+		t.Fatalf("StoreFileToVideo() left %v in the video directory, want only %s\n", infos, fakeHash)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(seed.RootDir, seed.FakeVideoIDDoesNotExist.String(), fakeDir, fakeHash))
+	if err != nil {
+		t.Fatalf("test: could not read file: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("got: %v want: %v\n", got, fakeData)
+	}
+	if _, err := seed.Repo.StoreFileToVideo(uuid.UUID{}, fakeDir, bytes.NewReader(fakeData)); !errors.Is(err, logger.ErrIsRequired) {
+		t.Fatalf("StoreFileToVideo() error: %v, wantErr: %v\n", err, logger.ErrIsRequired)
+	}
+	if _, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDExists, "../escaped", bytes.NewReader(fakeData)); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("StoreFileToVideo() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
+
+func Test_repository_DeleteFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v\n", err)
+	}
+	exists, err := seed.Repo.Exists(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists)
+	if err != nil {
+		t.Fatalf("test: could not verify if file exists: %v\n", err)
+	}
+	if exists {
+		t.Fatalf("DeleteFileFromVideo() left %s behind\n", seed.FakeVideoFileNameExists)
+	}
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v, want: nil when the file does not exist\n", err)
+	}
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, "../escaped"); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("DeleteFileFromVideo() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
+
+func Test_repository_ListFiles(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, "thumbnail", bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("test: failed to store a file: %v\n", err)
+	}
+	fakeTmpPath := filepath.Join(seed.FakeVideoIDExists.String(), tempFilePrefix+"fake")
+	if err := seed.Repo.Afs.WriteFile(fakeTmpPath, fakeData, 0644); err != nil {
+		t.Fatalf("test: failed to write a temp file: %v\n", err)
+	}
+	if err := seed.Repo.Afs.Mkdir("uploads", 0755); err != nil {
+		t.Fatalf("test: failed to make a directory out of the videos: %v\n", err)
+	}
+	if err := seed.Repo.Afs.WriteFile(filepath.Join("uploads", "fake"), fakeData, 0644); err != nil {
+		t.Fatalf("test: failed to write a file out of the videos: %v\n", err)
+	}
+	got, err := seed.Repo.ListFiles()
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v\n", err)
+	}
+	want := map[string]int64{
+		seed.FakeVideoIDExists.String() + "/" + seed.FakeVideoFileNameExists: int64(len(seed.FakeVideoFileData)),
+		seed.FakeVideoIDDoesNotExist.String() + "/" + fakeFileName:           int64(len(fakeData)),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListFiles() got: %v, want: %v\n", got, want)
+	}
+	for _, f := range got {
+		if size, ok := want[f.VideoID.String()+"/"+f.Name]; !ok || size != f.Size || f.ModTime.IsZero() {
+			t.Errorf("ListFiles() got: %v, want one of: %v\n", f, want)
+		}
+	}
+}
+
+func Test_repository_Blobs(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeHash := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	for i, wantCreated := range []bool{true, false} {
+		staged, err := seed.Repo.StageBlob(bytes.NewReader(fakeData))
+		if err != nil {
+			t.Fatalf("StageBlob() error: %v\n", err)
+		}
+		if staged.Hash != fakeHash || staged.Size != int64(len(fakeData)) {
+			t.Fatalf("StageBlob() got: %s of %d bytes, want: %s of %d bytes\n", staged.Hash, staged.Size, fakeHash, len(fakeData))
+		}
+		created, err := seed.Repo.CommitBlob(staged)
+		if err != nil {
+			t.Fatalf("CommitBlob() error: %v\n", err)
+		}
+		if created != wantCreated {
+			t.Fatalf("CommitBlob() #%d created: %v, want: %v\n", i, created, wantCreated)
+		}
+	}
+	blobs, err := seed.Repo.ListBlobs()
+	if err != nil {
+		t.Fatalf("ListBlobs() error: %v\n", err)
+	}
+	if len(blobs) != 1 || blobs[0].Hash != fakeHash || blobs[0].Size != int64(len(fakeData)) {
+		t.Fatalf("ListBlobs() got: %v, want only %s\n", blobs, fakeHash)
+	}
+	f, err := seed.Repo.OpenBlob(fakeHash)
+	if err != nil {
+		t.Fatalf("OpenBlob() error: %v\n", err)
+	}
+	got, err := ioutil.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		t.Fatalf("test: could not read blob: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("OpenBlob() got: %v, want: %v\n", got, fakeData)
+	}
+	staged, err := seed.Repo.StageBlob(bytes.NewReader([]byte("fake discarded data")))
+	if err != nil {
+		t.Fatalf("StageBlob() error: %v\n", err)
+	}
+	if err := seed.Repo.DiscardBlob(staged); err != nil {
+		t.Fatalf("DiscardBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.CommitBlob(staged); err == nil {
+		t.Fatalf("CommitBlob() committed a discarded blob\n")
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob(fakeHash); !errors.Is(err, logger.ErrNotFound) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrNotFound)
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v, want: nil when the blob does not exist\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob("../" + fakeHash); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
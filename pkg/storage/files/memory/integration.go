@@ -12,6 +12,9 @@ import (
 	"github.com/selmison/code-micro-videos/pkg/storage/files"
 )
 
+// fakeMP4Header makes the temp file pass as a video/mp4 upload, its mdat box extends to the end of the file
+const fakeMP4Header = "\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom\x00\x00\x00\x00mdat"
+
 type FileSeed struct {
 	Repo                     files.Repository
 	FakeAfero                *afero.Afero
@@ -69,7 +72,8 @@ func SetupFileTestCase() (*FileSeed, func(t *testing.T), error) {
 	}
 	fakeTmpFileName := fakeTmpFileStatExists.Name()
 	fakeTmpFilePath := fakeTmpFile.Name()
-	if err := fakeAfs.WriteFile(fakeTmpFile.Name(), fakeData, 0644); err != nil {
+	fakeTmpData := append([]byte(fakeMP4Header), fakeData...)
+	if err := fakeAfs.WriteFile(fakeTmpFile.Name(), fakeTmpData, 0644); err != nil {
 		return nil, nil, fmt.Errorf("test: failed to write a new file: %v\n", err)
 	}
 	s := &FileSeed{
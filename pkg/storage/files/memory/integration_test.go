@@ -1,14 +1,20 @@
 package memory
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
 func Test_repository_Exists(t *testing.T) {
@@ -251,3 +257,209 @@ func Test_repository_UpdateFileToVideo(t *testing.T) {
 		})
 	}
 }
+
+func Test_repository_OpenFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := SetupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	sb, err := afero.ReadFile(seed.FakeAfero.Fs, seed.FakeVideoFileExists.Name())
+	if err != nil {
+		t.Fatalf("test: could not read file: %v", err)
+	}
+	defer teardownTestCase(t)
+	const fakeOffset = 5
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    nil,
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    sb[fakeOffset:],
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := seed.Repo.OpenFileFromVideo(tt.args.videoID, tt.args.fileName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenFileFromVideo() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			if _, err := f.Seek(fakeOffset, io.SeekStart); err != nil {
+				t.Fatalf("Seek() error: %v", err)
+			}
+			got, err := ioutil.ReadAll(f)
+			if err != nil {
+				t.Fatalf("test: could not read file: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OpenFileFromVideo() got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_StoreFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := SetupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	const fakeDir = "thumbnail"
+	fakeData := []byte("fake video data")
+	wantFileName := fmt.Sprintf("%s/%x", fakeDir, sha256.Sum256(fakeData))
+	gotFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, fakeDir, bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("StoreFileToVideo() error: %v\n", err)
+	}
+	if gotFileName != wantFileName {
+		t.Fatalf("StoreFileToVideo() got: %s, want: %s\n", gotFileName, wantFileName)
+	}
+	got, err := seed.Repo.GetFileFromVideo(seed.FakeVideoIDDoesNotExist, gotFileName)
+	if err != nil {
+		t.Fatalf("test: could not read file: %v\n", err)
+	}
+	if !reflect.DeepEqual(got, fakeData) {
+		t.Errorf("StoreFileToVideo() got: %v, want: %v", got, fakeData)
+	}
+}
+
+func Test_repository_DeleteFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := SetupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v\n", err)
+	}
+	exists, err := seed.Repo.Exists(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists)
+	if err != nil {
+		t.Fatalf("test: could not verify if file exists: %v\n", err)
+	}
+	if exists {
+		t.Fatalf("DeleteFileFromVideo() left %s behind\n", seed.FakeVideoFileNameExists)
+	}
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v, want: nil when the file does not exist\n", err)
+	}
+}
+
+func Test_repository_ListFiles(t *testing.T) {
+	seed, teardownTestCase, err := SetupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, "thumbnail", bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("test: failed to store a file: %v\n", err)
+	}
+	got, err := seed.Repo.ListFiles()
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v\n", err)
+	}
+	want := map[string]bool{
+		seed.FakeVideoIDExists.String() + "/" + seed.FakeVideoFileNameExists: true,
+		seed.FakeVideoIDDoesNotExist.String() + "/" + fakeFileName:           true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListFiles() got: %v, want: %v\n", got, want)
+	}
+	for _, f := range got {
+		if !want[f.VideoID.String()+"/"+f.Name] {
+			t.Errorf("ListFiles() got: %v, want one of: %v\n", f, want)
+		}
+	}
+}
+
+func Test_repository_Blobs(t *testing.T) {
+	seed, teardownTestCase, err := SetupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeHash := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	for i, wantCreated := range []bool{true, false} {
+		staged, err := seed.Repo.StageBlob(bytes.NewReader(fakeData))
+		if err != nil {
+			t.Fatalf("StageBlob() error: %v\n", err)
+		}
+		if staged.Hash != fakeHash || staged.Size != int64(len(fakeData)) {
+			t.Fatalf("StageBlob() got: %s of %d bytes, want: %s of %d bytes\n", staged.Hash, staged.Size, fakeHash, len(fakeData))
+		}
+		created, err := seed.Repo.CommitBlob(staged)
+		if err != nil {
+			t.Fatalf("CommitBlob() error: %v\n", err)
+		}
+		if created != wantCreated {
+			t.Fatalf("CommitBlob() #%d created: %v, want: %v\n", i, created, wantCreated)
+		}
+	}
+	blobs, err := seed.Repo.ListBlobs()
+	if err != nil {
+		t.Fatalf("ListBlobs() error: %v\n", err)
+	}
+	if len(blobs) != 1 || blobs[0].Hash != fakeHash || blobs[0].Size != int64(len(fakeData)) {
+		t.Fatalf("ListBlobs() got: %v, want only %s\n", blobs, fakeHash)
+	}
+	f, err := seed.Repo.OpenBlob(fakeHash)
+	if err != nil {
+		t.Fatalf("OpenBlob() error: %v\n", err)
+	}
+	got, err := ioutil.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		t.Fatalf("test: could not read blob: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("OpenBlob() got: %v, want: %v\n", got, fakeData)
+	}
+	staged, err := seed.Repo.StageBlob(bytes.NewReader([]byte("fake discarded data")))
+	if err != nil {
+		t.Fatalf("StageBlob() error: %v\n", err)
+	}
+	if err := seed.Repo.DiscardBlob(staged); err != nil {
+		t.Fatalf("DiscardBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.CommitBlob(staged); err == nil {
+		t.Fatalf("CommitBlob() committed a discarded blob\n")
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob(fakeHash); !errors.Is(err, logger.ErrNotFound) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrNotFound)
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v, want: nil when the blob does not exist\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob("../" + fakeHash); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
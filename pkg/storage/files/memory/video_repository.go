@@ -6,9 +6,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
 )
 
 type repository struct {
@@ -42,6 +48,18 @@ func (r *repository) GetFileFromVideo(videoID uuid.UUID, fileName string) ([]byt
 	return r.Afs.ReadFile(filePath)
 }
 
+func (r *repository) OpenFileFromVideo(videoID uuid.UUID, fileName string) (files.File, error) {
+	filePath := fmt.Sprintf("%s%c%s", videoID, os.PathSeparator, fileName)
+	f, err := r.Afs.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", filePath, logger.ErrNotFound)
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
 func (r *repository) SaveFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) error {
 	filePath := fmt.Sprintf("%s%c%s", videoID, os.PathSeparator, fileName)
 	if fileData != nil {
@@ -52,6 +70,35 @@ func (r *repository) SaveFileToVideo(videoID uuid.UUID, fileName string, fileDat
 	return nil
 }
 
+func (r *repository) StoreFileToVideo(videoID uuid.UUID, dir string, fileData io.Reader) (string, error) {
+	if fileData == nil {
+		return "", fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	videoDir := path.Join(videoID.String(), dir)
+	if err := r.Afs.MkdirAll(videoDir, 0755); err != nil {
+		return "", err
+	}
+	tmpFile, err := r.Afs.TempFile(videoDir, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	hash := files.NewHash()
+	if _, err := io.Copy(tmpFile, io.TeeReader(fileData, hash)); err != nil {
+		_ = tmpFile.Close()
+		_ = r.Afs.Remove(tmpFile.Name())
+		return "", err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", err
+	}
+	fileName := path.Join(dir, files.HashName(hash))
+	filePath := fmt.Sprintf("%s%c%s", videoID, os.PathSeparator, fileName)
+	if err := r.Afs.Rename(tmpFile.Name(), filePath); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
 func (r *repository) UpdateFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) (bool, error) {
 	exists, err := r.Exists(videoID, fileName)
 	if err != nil {
@@ -65,3 +112,138 @@ func (r *repository) UpdateFileToVideo(videoID uuid.UUID, fileName string, fileD
 	}
 	return true, err
 }
+
+func (r *repository) DeleteFileFromVideo(videoID uuid.UUID, fileName string) error {
+	filePath := fmt.Sprintf("%s%c%s", videoID, os.PathSeparator, fileName)
+	if err := r.Afs.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) ListFiles() ([]files.StoredFile, error) {
+	var stored []files.StoredFile
+	err := r.Afs.Walk(".", func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, ok := files.ParseKey(strings.TrimPrefix(filepath.ToSlash(p), "/"))
+		if !ok {
+			return nil
+		}
+		f.Size = info.Size()
+		f.ModTime = info.ModTime()
+		stored = append(stored, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (r *repository) StageBlob(fileData io.Reader) (files.StagedBlob, error) {
+	if fileData == nil {
+		return files.StagedBlob{}, fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	if err := r.Afs.MkdirAll(files.BlobsDir, 0755); err != nil {
+		return files.StagedBlob{}, err
+	}
+	tmpFile, err := r.Afs.TempFile(files.BlobsDir, ".tmp-")
+	if err != nil {
+		return files.StagedBlob{}, err
+	}
+	digest := files.NewDigest()
+	if _, err := io.Copy(tmpFile, io.TeeReader(fileData, digest)); err != nil {
+		_ = tmpFile.Close()
+		_ = r.Afs.Remove(tmpFile.Name())
+		return files.StagedBlob{}, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return files.StagedBlob{}, err
+	}
+	return files.StagedBlob{Blob: digest.Blob(), TempName: tmpFile.Name()}, nil
+}
+
+func (r *repository) CommitBlob(staged files.StagedBlob) (bool, error) {
+	key, err := files.BlobKey(staged.Hash)
+	if err != nil {
+		return false, err
+	}
+	exists, err := r.Afs.Exists(key)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, r.Afs.Remove(staged.TempName)
+	}
+	if err := r.Afs.MkdirAll(path.Dir(key), 0755); err != nil {
+		return false, err
+	}
+	if err := r.Afs.Rename(staged.TempName, key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) DiscardBlob(staged files.StagedBlob) error {
+	if err := r.Afs.Remove(staged.TempName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) OpenBlob(hash string) (files.File, error) {
+	key, err := files.BlobKey(hash)
+	if err != nil {
+		return nil, err
+	}
+	f, err := r.Afs.Open(key)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("blob %s: %w", hash, logger.ErrNotFound)
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *repository) DeleteBlob(hash string) error {
+	key, err := files.BlobKey(hash)
+	if err != nil {
+		return err
+	}
+	if err := r.Afs.Remove(key); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) ListBlobs() ([]files.Blob, error) {
+	exists, err := r.Afs.DirExists(files.BlobsDir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	var blobs []files.Blob
+	err = r.Afs.Walk(files.BlobsDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		hash, ok := files.ParseBlobKey(filepath.ToSlash(p))
+		if !ok {
+			return nil
+		}
+		blobs = append(blobs, files.Blob{Hash: hash, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blobs, nil
+}
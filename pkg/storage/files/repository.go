@@ -1,14 +1,179 @@
 package files
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
 	"io"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
+// File is a stored video file that can be read from any offset without loading it into memory
+type File interface {
+	io.ReadSeeker
+	io.Closer
+}
+
 type Repository interface {
 	Exists(videoID uuid.UUID, fileName string) (bool, error)
 	GetFileFromVideo(videoID uuid.UUID, fileName string) ([]byte, error)
+	OpenFileFromVideo(videoID uuid.UUID, fileName string) (File, error)
 	SaveFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) error
 	UpdateFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) (bool, error)
+	// StoreFileToVideo streams fileData to a temporary name in the dir of the video while hashing it and
+	// then renames it to its content address, returning the file name "dir/sha256" relative to the video
+	StoreFileToVideo(videoID uuid.UUID, dir string, fileData io.Reader) (string, error)
+	// DeleteFileFromVideo removes a file of the video, a file that does not exist is not an error
+	DeleteFileFromVideo(videoID uuid.UUID, fileName string) error
+	// ListFiles lists every file kept for the videos, leaving out the temporary files of stores in progress
+	ListFiles() ([]StoredFile, error)
+
+	// StageBlob streams fileData to a temporary name while hashing it, the blob is not addressable until committed
+	StageBlob(fileData io.Reader) (StagedBlob, error)
+	// CommitBlob moves a staged blob to its content address and reports whether the blob was created, when
+	// the address is already stored the staged file is discarded instead of being written again
+	CommitBlob(staged StagedBlob) (bool, error)
+	// DiscardBlob removes a staged blob that is not going to be committed
+	DiscardBlob(staged StagedBlob) error
+	OpenBlob(hash string) (File, error)
+	// DeleteBlob removes a committed blob, a blob that does not exist is not an error
+	DeleteBlob(hash string) error
+	// ListBlobs lists the committed blobs, leaving out the staged ones
+	ListBlobs() ([]Blob, error)
+}
+
+// BlobsDir is the directory of the blobs, shared by every video
+const BlobsDir = "blobs"
+
+// Blob is a content addressed file, Hash is the sha256 of its content
+type Blob struct {
+	Hash    string
+	Size    int64
+	ModTime time.Time
+}
+
+// StagedBlob is a blob streamed to the temporary TempName of a repository until it is committed
+type StagedBlob struct {
+	Blob
+	TempName string
+}
+
+// StoredFile describes a file kept for a video, Name is relative to the video like the names of Key
+type StoredFile struct {
+	VideoID uuid.UUID
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// ParseKey splits the slash separated key of a video file, it reports false for keys that Key does not
+// build, such as the temporary files of a store in progress
+func ParseKey(key string) (StoredFile, bool) {
+	i := strings.IndexByte(key, '/')
+	if i < 0 {
+		return StoredFile{}, false
+	}
+	videoID, err := uuid.Parse(key[:i])
+	if err != nil || videoID == (uuid.UUID{}) || !isPlainPath(key[i+1:]) {
+		return StoredFile{}, false
+	}
+	return StoredFile{VideoID: videoID, Name: key[i+1:]}, true
+}
+
+// Key builds the slash separated key of a video file, refusing names that could escape the video directory.
+// The file name may be nested in directories of the video, such as the directory of each kind of asset.
+func Key(videoID uuid.UUID, fileName string) (string, error) {
+	if videoID == (uuid.UUID{}) {
+		return "", fmt.Errorf("'videoID' %w", logger.ErrIsRequired)
+	}
+	if strings.TrimSpace(fileName) == "" {
+		return "", fmt.Errorf("'fileName' %w", logger.ErrIsRequired)
+	}
+	if !isPlainPath(fileName) {
+		return "", fmt.Errorf("file name '%s' %w", fileName, logger.ErrIsNotValidated)
+	}
+	return path.Join(videoID.String(), fileName), nil
+}
+
+// ValidateDir verifies that each slash separated element of dir is a plain name inside the video directory
+func ValidateDir(dir string) error {
+	if !isPlainPath(dir) {
+		return fmt.Errorf("directory '%s' %w", dir, logger.ErrIsNotValidated)
+	}
+	return nil
+}
+
+func isPlainPath(p string) bool {
+	for _, name := range strings.Split(p, "/") {
+		if name == "" || strings.ContainsRune(name, '\\') || strings.HasPrefix(name, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// BlobKey builds the slash separated key of a blob, the blobs are spread in directories by the first
+// two characters of their hash
+func BlobKey(hash string) (string, error) {
+	if !isHash(hash) {
+		return "", fmt.Errorf("blob hash '%s' %w", hash, logger.ErrIsNotValidated)
+	}
+	return path.Join(BlobsDir, hash[:2], hash), nil
+}
+
+// ParseBlobKey returns the hash of a key built by BlobKey, it reports false for any other key
+func ParseBlobKey(key string) (string, bool) {
+	hash := path.Base(key)
+	if !isHash(hash) || key != path.Join(BlobsDir, hash[:2], hash) {
+		return "", false
+	}
+	return hash, true
+}
+
+func isHash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Digest hashes and counts the bytes written to it, it describes a blob while it is streamed
+type Digest struct {
+	hash hash.Hash
+	size int64
+}
+
+func NewDigest() *Digest {
+	return &Digest{hash: NewHash()}
+}
+
+func (d *Digest) Write(p []byte) (int, error) {
+	d.size += int64(len(p))
+	return d.hash.Write(p)
+}
+
+// Blob describes the content written so far
+func (d *Digest) Blob() Blob {
+	return Blob{Hash: HashName(d.hash), Size: d.size}
+}
+
+// NewHash returns the hash used to address the content of the video files
+func NewHash() hash.Hash {
+	return sha256.New()
+}
+
+// HashName formats the sum of a content hash as a file name
+func HashName(h hash.Hash) string {
+	return fmt.Sprintf("%x", h.Sum(nil))
 }
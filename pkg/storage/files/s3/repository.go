@@ -0,0 +1,486 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+)
+
+const (
+	defaultRegion   = "us-east-1"
+	defaultPartSize = s3manager.DefaultUploadPartSize
+	tempKeyPrefix   = ".tmp-"
+	// maxCopySize is the largest object a single CopyObject request accepts, larger ones are copied by parts
+	maxCopySize  = 5 << 30
+	copyPartSize = 1 << 30
+)
+
+// Config holds the settings of an S3-compatible bucket
+type Config struct {
+	Bucket          string
+	Prefix          string
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	ForcePathStyle  bool
+	// PartSize is the size of each part of a multipart upload, files smaller than it are sent in a single request
+	PartSize int64
+}
+
+type repository struct {
+	client   *awss3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+// NewRepository creates a files repository that keeps the video files as objects in an S3-compatible bucket
+func NewRepository(cfg Config) (*repository, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("'Bucket' %w", logger.ErrIsRequired)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = defaultRegion
+	}
+	awsCfg := aws.NewConfig().
+		WithRegion(region).
+		WithS3ForcePathStyle(cfg.ForcePathStyle)
+	if cfg.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(cfg.Endpoint)
+	}
+	if cfg.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create s3 session: %v", err)
+	}
+	partSize := cfg.PartSize
+	if partSize <= 0 {
+		partSize = defaultPartSize
+	}
+	client := awss3.New(sess)
+	uploader := s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+		u.PartSize = partSize
+	})
+	r := &repository{
+		client:   client,
+		uploader: uploader,
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+	}
+	return r, nil
+}
+
+func (r *repository) Exists(videoID uuid.UUID, fileName string) (bool, error) {
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return false, err
+	}
+	_, err = r.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not verify if file exists: %v", err)
+	}
+	return true, nil
+}
+
+func (r *repository) GetFileFromVideo(videoID uuid.UUID, fileName string) ([]byte, error) {
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.client.GetObject(&awss3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%s: %w", key, logger.ErrNotFound)
+		}
+		return nil, fmt.Errorf("could not get file: %v", err)
+	}
+	defer out.Body.Close()
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, out.Body); err != nil {
+		return nil, fmt.Errorf("could not read file: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *repository) OpenFileFromVideo(videoID uuid.UUID, fileName string) (files.File, error) {
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return r.openObject(key)
+}
+
+func (r *repository) SaveFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) error {
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return err
+	}
+	if fileData == nil {
+		return nil
+	}
+	_, err = r.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+		Body:   fileData,
+	})
+	if err != nil {
+		return fmt.Errorf("could not upload file: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) StoreFileToVideo(videoID uuid.UUID, dir string, fileData io.Reader) (string, error) {
+	if videoID == (uuid.UUID{}) {
+		return "", fmt.Errorf("'videoID' %w", logger.ErrIsRequired)
+	}
+	if fileData == nil {
+		return "", fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	if dir != "" {
+		if err := files.ValidateDir(dir); err != nil {
+			return "", err
+		}
+	}
+	tmpKey := path.Join(r.prefix, videoID.String(), dir, tempKeyPrefix+uuid.New().String())
+	hash := files.NewHash()
+	_, err := r.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(tmpKey),
+		Body:   io.TeeReader(fileData, hash),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not upload file: %v", err)
+	}
+	defer r.deleteObject(tmpKey)
+	fileName := path.Join(dir, files.HashName(hash))
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return "", err
+	}
+	if err := r.copyObject(tmpKey, key); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+func (r *repository) UpdateFileToVideo(videoID uuid.UUID, fileName string, fileData io.Reader) (bool, error) {
+	exists, err := r.Exists(videoID, fileName)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	if err := r.SaveFileToVideo(videoID, fileName, fileData); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) DeleteFileFromVideo(videoID uuid.UUID, fileName string) error {
+	key, err := r.objectKey(videoID, fileName)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("could not delete file: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) ListFiles() ([]files.StoredFile, error) {
+	prefix := ""
+	if r.prefix != "" {
+		prefix = strings.TrimSuffix(r.prefix, "/") + "/"
+	}
+	var stored []files.StoredFile
+	err := r.client.ListObjectsV2Pages(&awss3.ListObjectsV2Input{
+		Bucket: aws.String(r.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *awss3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			f, ok := files.ParseKey(strings.TrimPrefix(aws.StringValue(obj.Key), prefix))
+			if !ok {
+				continue
+			}
+			f.Size = aws.Int64Value(obj.Size)
+			f.ModTime = aws.TimeValue(obj.LastModified)
+			stored = append(stored, f)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list files: %v", err)
+	}
+	return stored, nil
+}
+
+func (r *repository) StageBlob(fileData io.Reader) (files.StagedBlob, error) {
+	if fileData == nil {
+		return files.StagedBlob{}, fmt.Errorf("'fileData' %w", logger.ErrIsRequired)
+	}
+	tmpName := path.Join(files.BlobsDir, tempKeyPrefix+uuid.New().String())
+	digest := files.NewDigest()
+	_, err := r.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(path.Join(r.prefix, tmpName)),
+		Body:   io.TeeReader(fileData, digest),
+	})
+	if err != nil {
+		return files.StagedBlob{}, fmt.Errorf("could not upload file: %v", err)
+	}
+	return files.StagedBlob{Blob: digest.Blob(), TempName: tmpName}, nil
+}
+
+func (r *repository) CommitBlob(staged files.StagedBlob) (bool, error) {
+	tmpKey, err := r.stagedKey(staged)
+	if err != nil {
+		return false, err
+	}
+	key, err := r.blobKey(staged.Hash)
+	if err != nil {
+		return false, err
+	}
+	_, err = r.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err == nil {
+		r.deleteObject(tmpKey)
+		return false, nil
+	}
+	if !isNotFound(err) {
+		return false, fmt.Errorf("could not verify if blob exists: %v", err)
+	}
+	if err := r.copyObject(tmpKey, key); err != nil {
+		return false, err
+	}
+	r.deleteObject(tmpKey)
+	return true, nil
+}
+
+func (r *repository) DiscardBlob(staged files.StagedBlob) error {
+	tmpKey, err := r.stagedKey(staged)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(tmpKey),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("could not delete temp file: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) OpenBlob(hash string) (files.File, error) {
+	key, err := r.blobKey(hash)
+	if err != nil {
+		return nil, err
+	}
+	return r.openObject(key)
+}
+
+func (r *repository) DeleteBlob(hash string) error {
+	key, err := r.blobKey(hash)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("could not delete blob: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) ListBlobs() ([]files.Blob, error) {
+	prefix := path.Join(r.prefix, files.BlobsDir) + "/"
+	var blobs []files.Blob
+	err := r.client.ListObjectsV2Pages(&awss3.ListObjectsV2Input{
+		Bucket: aws.String(r.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *awss3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			hash, ok := files.ParseBlobKey(files.BlobsDir + "/" + strings.TrimPrefix(aws.StringValue(obj.Key), prefix))
+			if !ok {
+				continue
+			}
+			blobs = append(blobs, files.Blob{
+				Hash:    hash,
+				Size:    aws.Int64Value(obj.Size),
+				ModTime: aws.TimeValue(obj.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list blobs: %v", err)
+	}
+	return blobs, nil
+}
+
+func (r *repository) openObject(key string) (files.File, error) {
+	out, err := r.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%s: %w", key, logger.ErrNotFound)
+		}
+		return nil, fmt.Errorf("could not get file info: %v", err)
+	}
+	return &objectReader{
+		client: r.client,
+		bucket: r.bucket,
+		key:    key,
+		size:   aws.Int64Value(out.ContentLength),
+	}, nil
+}
+
+func (r *repository) copyObject(srcKey, dstKey string) error {
+	head, err := r.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(srcKey),
+	})
+	if err != nil {
+		return fmt.Errorf("could not get file info: %v", err)
+	}
+	source := path.Join(r.bucket, srcKey)
+	size := aws.Int64Value(head.ContentLength)
+	if size <= maxCopySize {
+		_, err := r.client.CopyObject(&awss3.CopyObjectInput{
+			Bucket:     aws.String(r.bucket),
+			Key:        aws.String(dstKey),
+			CopySource: aws.String(source),
+		})
+		if err != nil {
+			return fmt.Errorf("could not copy file: %v", err)
+		}
+		return nil
+	}
+	upload, err := r.client.CreateMultipartUpload(&awss3.CreateMultipartUploadInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(dstKey),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create multipart copy: %v", err)
+	}
+	var parts []*awss3.CompletedPart
+	for offset, number := int64(0), int64(1); offset < size; offset, number = offset+copyPartSize, number+1 {
+		last := offset + copyPartSize - 1
+		if last >= size {
+			last = size - 1
+		}
+		out, err := r.client.UploadPartCopy(&awss3.UploadPartCopyInput{
+			Bucket:          aws.String(r.bucket),
+			Key:             aws.String(dstKey),
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int64(number),
+			CopySource:      aws.String(source),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", offset, last)),
+		})
+		if err != nil {
+			_, _ = r.client.AbortMultipartUpload(&awss3.AbortMultipartUploadInput{
+				Bucket:   aws.String(r.bucket),
+				Key:      aws.String(dstKey),
+				UploadId: upload.UploadId,
+			})
+			return fmt.Errorf("could not copy file part: %v", err)
+		}
+		parts = append(parts, &awss3.CompletedPart{
+			ETag:       out.CopyPartResult.ETag,
+			PartNumber: aws.Int64(number),
+		})
+	}
+	_, err = r.client.CompleteMultipartUpload(&awss3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(r.bucket),
+		Key:             aws.String(dstKey),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &awss3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return fmt.Errorf("could not complete multipart copy: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) deleteObject(key string) {
+	_, _ = r.client.DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+}
+
+func (r *repository) objectKey(videoID uuid.UUID, fileName string) (string, error) {
+	key, err := files.Key(videoID, fileName)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(r.prefix, key), nil
+}
+
+func (r *repository) blobKey(hash string) (string, error) {
+	key, err := files.BlobKey(hash)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(r.prefix, key), nil
+}
+
+// stagedKey refuses the temp names that this repository does not give to the staged blobs
+func (r *repository) stagedKey(staged files.StagedBlob) (string, error) {
+	dir, name := path.Split(staged.TempName)
+	if dir != files.BlobsDir+"/" || !strings.HasPrefix(name, tempKeyPrefix) {
+		return "", fmt.Errorf("staged blob '%s' %w", staged.TempName, logger.ErrIsNotValidated)
+	}
+	return path.Join(r.prefix, staged.TempName), nil
+}
+
+func isNotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var aErr awserr.Error
+	if errors.As(err, &aErr) && aErr.Code() == awss3.ErrCodeNoSuchKey {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,592 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/google/uuid"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+const (
+	fakeBucket   = "code-micro-videos"
+	fakePrefix   = "videos"
+	fakePartSize = 5 << 20
+)
+
+type fileSeed struct {
+	Repo                     *repository
+	FakeVideoIDExists        uuid.UUID
+	FakeVideoIDDoesNotExist  uuid.UUID
+	FakeVideoFileNameExists  string
+	FakeVideoFileData        []byte
+	FakeFileNameDoesNotExist string
+	MultipartRequests        *int32
+}
+
+func setupFileTestCase() (*fileSeed, func(t *testing.T), error) {
+	faker := gofakes3.New(s3mem.New())
+	var multipartRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("uploadId") != "" {
+			atomic.AddInt32(&multipartRequests, 1)
+		}
+		faker.Server().ServeHTTP(w, r)
+	}))
+	repo, err := NewRepository(Config{
+		Bucket:          fakeBucket,
+		Prefix:          fakePrefix,
+		Endpoint:        ts.URL,
+		AccessKeyID:     "fake-key",
+		SecretAccessKey: "fake-secret",
+		ForcePathStyle:  true,
+		PartSize:        fakePartSize,
+	})
+	if err != nil {
+		ts.Close()
+		return nil, nil, fmt.Errorf("test: failed to create repository: %v\n", err)
+	}
+	if _, err := repo.client.CreateBucket(&awss3.CreateBucketInput{Bucket: aws.String(fakeBucket)}); err != nil {
+		ts.Close()
+		return nil, nil, fmt.Errorf("test: failed to create bucket: %v\n", err)
+	}
+	fakeVideoIDExists := uuid.New()
+	fakeData := make([]byte, 20)
+	if _, err := rand.Read(fakeData); err != nil {
+		ts.Close()
+		return nil, nil, fmt.Errorf("test: failed to generate a random Data: %v\n", err)
+	}
+	fakeFileNameExists := fmt.Sprintf("%x", fakeData)
+	if err := repo.SaveFileToVideo(fakeVideoIDExists, fakeFileNameExists, bytes.NewReader(fakeData)); err != nil {
+		ts.Close()
+		return nil, nil, fmt.Errorf("test: failed to write a new file: %v\n", err)
+	}
+	s := &fileSeed{
+		Repo:                     repo,
+		FakeVideoIDExists:        fakeVideoIDExists,
+		FakeVideoIDDoesNotExist:  uuid.New(),
+		FakeVideoFileNameExists:  fakeFileNameExists,
+		FakeVideoFileData:        fakeData,
+		FakeFileNameDoesNotExist: "FakeFileNameDoesNotExist",
+		MultipartRequests:        &multipartRequests,
+	}
+	return s, func(t *testing.T) {
+		ts.Close()
+	}, nil
+}
+
+func Test_repository_Exists(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "when file name escapes the video directory",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: "../" + seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.Exists(tt.args.videoID, tt.args.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exists() error = %v, wantErr %v\n", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Exists() got: %v, want: %v\n", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_GetFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    seed.FakeVideoFileData,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.GetFileFromVideo(tt.args.videoID, tt.args.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFileFromVideo() error: %v, wantErr: %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFileFromVideo() got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_SaveFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID    uuid.UUID
+		fileName   string
+		fileReader io.Reader
+	}
+	tests := []struct {
+		name       string
+		args       args
+		fileExists bool
+		wantErr    bool
+	}{
+		{
+			name: "when video does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDDoesNotExist,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			fileExists: false,
+			wantErr:    false,
+		},
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			fileExists: false,
+			wantErr:    false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			fileExists: true,
+			wantErr:    false,
+		},
+		{
+			name: "when video does not exist and data is provided",
+			args: args{
+				videoID:    seed.FakeVideoIDDoesNotExist,
+				fileName:   seed.FakeVideoFileNameExists,
+				fileReader: bytes.NewReader(seed.FakeVideoFileData),
+			},
+			fileExists: true,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := seed.Repo.SaveFileToVideo(tt.args.videoID, tt.args.fileName, tt.args.fileReader); (err != nil) != tt.wantErr {
+				t.Fatalf("SaveFileToVideo() error: %v\n, wantErr: %v", err, tt.wantErr)
+			}
+			exists, err := seed.Repo.Exists(tt.args.videoID, tt.args.fileName)
+			if err != nil {
+				t.Fatalf("test: could not verify if file exist: %v\n", err)
+			}
+			if exists != tt.fileExists {
+				t.Fatalf("got: '%t' want: %t\n", exists, tt.fileExists)
+			}
+		})
+	}
+}
+
+func Test_repository_SaveFileToVideo_Multipart(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := make([]byte, 2*fakePartSize+1)
+	if _, err := rand.Read(fakeData); err != nil {
+		t.Fatalf("test: failed to generate a random Data: %v\n", err)
+	}
+	if err := seed.Repo.SaveFileToVideo(seed.FakeVideoIDDoesNotExist, seed.FakeFileNameDoesNotExist, bytes.NewReader(fakeData)); err != nil {
+		t.Fatalf("SaveFileToVideo() error: %v\n", err)
+	}
+	if got := atomic.LoadInt32(seed.MultipartRequests); got == 0 {
+		t.Fatalf("SaveFileToVideo() did not use a multipart upload\n")
+	}
+	got, err := seed.Repo.GetFileFromVideo(seed.FakeVideoIDDoesNotExist, seed.FakeFileNameDoesNotExist)
+	if err != nil {
+		t.Fatalf("GetFileFromVideo() error: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("got %d bytes, want %d bytes\n", len(got), len(fakeData))
+	}
+}
+
+func Test_repository_UpdateFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	type args struct {
+		videoID    uuid.UUID
+		fileName   string
+		fileReader io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := seed.Repo.UpdateFileToVideo(tt.args.videoID, tt.args.fileName, tt.args.fileReader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateFileToVideo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UpdateFileToVideo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_OpenFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	const fakeOffset = 5
+	type args struct {
+		videoID  uuid.UUID
+		fileName string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr error
+	}{
+		{
+			name: "when file does not exist",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeFileNameDoesNotExist,
+			},
+			want:    nil,
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name: "when file exists",
+			args: args{
+				videoID:  seed.FakeVideoIDExists,
+				fileName: seed.FakeVideoFileNameExists,
+			},
+			want:    seed.FakeVideoFileData[fakeOffset:],
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := seed.Repo.OpenFileFromVideo(tt.args.videoID, tt.args.fileName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenFileFromVideo() error: %v, wantErr: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			if _, err := f.Seek(fakeOffset, io.SeekStart); err != nil {
+				t.Fatalf("Seek() error: %v", err)
+			}
+			got, err := ioutil.ReadAll(f)
+			if err != nil {
+				t.Fatalf("test: could not read file: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("OpenFileFromVideo() got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repository_StoreFileToVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := make([]byte, 2*fakePartSize+1)
+	if _, err := rand.Read(fakeData); err != nil {
+		t.Fatalf("test: failed to generate a random Data: %v\n", err)
+	}
+	const fakeDir = "video"
+	wantFileName := fmt.Sprintf("%s/%x", fakeDir, sha256.Sum256(fakeData))
+	gotFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, fakeDir, bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("StoreFileToVideo() error: %v\n", err)
+	}
+	if gotFileName != wantFileName {
+		t.Fatalf("StoreFileToVideo() got: %s, want: %s\n", gotFileName, wantFileName)
+	}
+	got, err := seed.Repo.GetFileFromVideo(seed.FakeVideoIDDoesNotExist, gotFileName)
+	if err != nil {
+		t.Fatalf("GetFileFromVideo() error: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("got %d bytes, want %d bytes\n", len(got), len(fakeData))
+	}
+	out, err := seed.Repo.client.ListObjects(&awss3.ListObjectsInput{
+		Bucket: aws.String(fakeBucket),
+		Prefix: aws.String(fmt.Sprintf("%s/%s/", fakePrefix, seed.FakeVideoIDDoesNotExist)),
+	})
+	if err != nil {
+		t.Fatalf("test: could not list objects: %v\n", err)
+	}
+	if len(out.Contents) != 1 {
+		t.Errorf("StoreFileToVideo() left %d objects, want 1\n", len(out.Contents))
+	}
+}
+
+func Test_repository_DeleteFileFromVideo(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v\n", err)
+	}
+	exists, err := seed.Repo.Exists(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists)
+	if err != nil {
+		t.Fatalf("test: could not verify if file exists: %v\n", err)
+	}
+	if exists {
+		t.Fatalf("DeleteFileFromVideo() left %s behind\n", seed.FakeVideoFileNameExists)
+	}
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, seed.FakeVideoFileNameExists); err != nil {
+		t.Fatalf("DeleteFileFromVideo() error: %v, want: nil when the file does not exist\n", err)
+	}
+	if err := seed.Repo.DeleteFileFromVideo(seed.FakeVideoIDExists, "../escaped"); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("DeleteFileFromVideo() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
+
+func Test_repository_ListFiles(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeFileName, err := seed.Repo.StoreFileToVideo(seed.FakeVideoIDDoesNotExist, "thumbnail", bytes.NewReader(fakeData))
+	if err != nil {
+		t.Fatalf("test: failed to store a file: %v\n", err)
+	}
+	for _, key := range []string{
+		fakePrefix + "/" + seed.FakeVideoIDExists.String() + "/" + tempKeyPrefix + "fake",
+		"other/" + seed.FakeVideoIDExists.String() + "/fake",
+	} {
+		_, err := seed.Repo.client.PutObject(&awss3.PutObjectInput{
+			Bucket: aws.String(fakeBucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(fakeData),
+		})
+		if err != nil {
+			t.Fatalf("test: failed to put %s: %v\n", key, err)
+		}
+	}
+	got, err := seed.Repo.ListFiles()
+	if err != nil {
+		t.Fatalf("ListFiles() error: %v\n", err)
+	}
+	want := map[string]int64{
+		seed.FakeVideoIDExists.String() + "/" + seed.FakeVideoFileNameExists: int64(len(seed.FakeVideoFileData)),
+		seed.FakeVideoIDDoesNotExist.String() + "/" + fakeFileName:           int64(len(fakeData)),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListFiles() got: %v, want: %v\n", got, want)
+	}
+	for _, f := range got {
+		if size, ok := want[f.VideoID.String()+"/"+f.Name]; !ok || size != f.Size || f.ModTime.IsZero() {
+			t.Errorf("ListFiles() got: %v, want one of: %v\n", f, want)
+		}
+	}
+}
+
+func Test_repository_Blobs(t *testing.T) {
+	seed, teardownTestCase, err := setupFileTestCase()
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeData := []byte("fake video data")
+	fakeHash := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	for i, wantCreated := range []bool{true, false} {
+		staged, err := seed.Repo.StageBlob(bytes.NewReader(fakeData))
+		if err != nil {
+			t.Fatalf("StageBlob() error: %v\n", err)
+		}
+		if staged.Hash != fakeHash || staged.Size != int64(len(fakeData)) {
+			t.Fatalf("StageBlob() got: %s of %d bytes, want: %s of %d bytes\n", staged.Hash, staged.Size, fakeHash, len(fakeData))
+		}
+		created, err := seed.Repo.CommitBlob(staged)
+		if err != nil {
+			t.Fatalf("CommitBlob() error: %v\n", err)
+		}
+		if created != wantCreated {
+			t.Fatalf("CommitBlob() #%d created: %v, want: %v\n", i, created, wantCreated)
+		}
+	}
+	blobs, err := seed.Repo.ListBlobs()
+	if err != nil {
+		t.Fatalf("ListBlobs() error: %v\n", err)
+	}
+	if len(blobs) != 1 || blobs[0].Hash != fakeHash || blobs[0].Size != int64(len(fakeData)) {
+		t.Fatalf("ListBlobs() got: %v, want only %s\n", blobs, fakeHash)
+	}
+	f, err := seed.Repo.OpenBlob(fakeHash)
+	if err != nil {
+		t.Fatalf("OpenBlob() error: %v\n", err)
+	}
+	got, err := ioutil.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		t.Fatalf("test: could not read blob: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("OpenBlob() got: %v, want: %v\n", got, fakeData)
+	}
+	staged, err := seed.Repo.StageBlob(bytes.NewReader([]byte("fake discarded data")))
+	if err != nil {
+		t.Fatalf("StageBlob() error: %v\n", err)
+	}
+	if err := seed.Repo.DiscardBlob(staged); err != nil {
+		t.Fatalf("DiscardBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.CommitBlob(staged); err == nil {
+		t.Fatalf("CommitBlob() committed a discarded blob\n")
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob(fakeHash); !errors.Is(err, logger.ErrNotFound) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrNotFound)
+	}
+	if err := seed.Repo.DeleteBlob(fakeHash); err != nil {
+		t.Fatalf("DeleteBlob() error: %v, want: nil when the blob does not exist\n", err)
+	}
+	if _, err := seed.Repo.OpenBlob("../" + fakeHash); !errors.Is(err, logger.ErrIsNotValidated) {
+		t.Fatalf("OpenBlob() error: %v, wantErr: %v\n", err, logger.ErrIsNotValidated)
+	}
+}
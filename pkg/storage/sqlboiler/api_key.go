@@ -0,0 +1,108 @@
+package sqlboiler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func (r Repository) AddAPIKey(key auth.APIKey, hash string) error {
+	scopes := make(types.StringArray, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+	k := models.APIKey{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Hash:      hash,
+		Scopes:    scopes,
+		CreatedBy: key.CreatedBy,
+		CreatedAt: key.CreatedAt,
+		ExpiresAt: null.TimeFromPtr(key.ExpiresAt),
+	}
+	return k.Insert(r.ctx, r.exec(), boil.Infer())
+}
+
+func (r Repository) GetAPIKeys(page crud.Page) ([]auth.APIKey, crud.PageInfo, error) {
+	total, err := models.APIKeys().Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	keySlice, err := models.APIKeys(
+		OrderBy(fmt.Sprintf("%s DESC, %s", models.APIKeyColumns.CreatedAt, models.APIKeyColumns.ID)),
+		Limit(page.PerPage),
+		Offset((page.Number-1)*page.PerPage),
+	).All(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	keys := make([]auth.APIKey, len(keySlice))
+	for i, k := range keySlice {
+		keys[i] = apiKeyFromModel(k)
+	}
+	return keys, crud.PageInfo{Total: total, Number: page.Number, PerPage: page.PerPage}, nil
+}
+
+func (r Repository) FetchAPIKeyByHash(hash string) (auth.APIKey, error) {
+	k, err := models.APIKeys(models.APIKeyWhere.Hash.EQ(hash)).One(r.ctx, r.exec())
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.APIKey{}, fmt.Errorf("api key %w", logger.ErrNotFound)
+	}
+	if err != nil {
+		return auth.APIKey{}, err
+	}
+	return apiKeyFromModel(k), nil
+}
+
+func (r Repository) RevokeAPIKey(id string, at time.Time) error {
+	k, err := models.FindAPIKey(r.ctx, r.exec(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("api key %s: %w", id, logger.ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if k.RevokedAt.Valid {
+		return nil
+	}
+	k.RevokedAt = null.TimeFrom(at)
+	_, err = k.Update(r.ctx, r.exec(), boil.Whitelist(models.APIKeyColumns.RevokedAt))
+	return err
+}
+
+func (r Repository) TouchAPIKey(id string, at time.Time) error {
+	_, err := models.APIKeys(models.APIKeyWhere.ID.EQ(id)).UpdateAll(r.ctx, r.exec(), models.M{
+		models.APIKeyColumns.LastUsedAt: at,
+	})
+	return err
+}
+
+func apiKeyFromModel(k *models.APIKey) auth.APIKey {
+	scopes := make([]auth.Scope, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = auth.Scope(scope)
+	}
+	return auth.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt.Ptr(),
+		LastUsedAt: k.LastUsedAt.Ptr(),
+		RevokedAt:  k.RevokedAt.Ptr(),
+	}
+}
@@ -0,0 +1,82 @@
+// +build integration
+
+package sqlboiler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/auth"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestRepository_APIKeys(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	fakeAPIKey := auth.APIKey{
+		ID:        uuid.New().String(),
+		Name:      "fake",
+		Prefix:    "cmv_fakefake",
+		Scopes:    []auth.Scope{auth.CatalogRead, auth.VideosWrite},
+		CreatedBy: "fake",
+		CreatedAt: now,
+	}
+	const fakeHash = "fake"
+	if err := repository.AddAPIKey(fakeAPIKey, fakeHash); err != nil {
+		t.Fatalf("test: add api key: %v", err)
+	}
+	t.Run("When the key is fetched by its hash", func(t *testing.T) {
+		key, err := repository.FetchAPIKeyByHash(fakeHash)
+		if err != nil {
+			t.Fatalf("FetchAPIKeyByHash() error: %v", err)
+		}
+		if key.ID != fakeAPIKey.ID || len(key.Scopes) != 2 || key.Scopes[1] != auth.VideosWrite {
+			t.Errorf("FetchAPIKeyByHash() got: %v, want: %v", key, fakeAPIKey)
+		}
+		if _, err := repository.FetchAPIKeyByHash("other"); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("FetchAPIKeyByHash() error: %v, want: %v", err, logger.ErrNotFound)
+		}
+	})
+	t.Run("When the key is used and revoked", func(t *testing.T) {
+		if err := repository.TouchAPIKey(fakeAPIKey.ID, now); err != nil {
+			t.Fatalf("TouchAPIKey() error: %v", err)
+		}
+		revokedAt := now.Add(time.Minute)
+		if err := repository.RevokeAPIKey(fakeAPIKey.ID, revokedAt); err != nil {
+			t.Fatalf("RevokeAPIKey() error: %v", err)
+		}
+		if err := repository.RevokeAPIKey(fakeAPIKey.ID, revokedAt.Add(time.Minute)); err != nil {
+			t.Fatalf("RevokeAPIKey() again error: %v", err)
+		}
+		key, err := repository.FetchAPIKeyByHash(fakeHash)
+		if err != nil {
+			t.Fatalf("FetchAPIKeyByHash() error: %v", err)
+		}
+		if key.LastUsedAt == nil || !key.LastUsedAt.Equal(now) {
+			t.Errorf("last used at: %v, want: %v", key.LastUsedAt, now)
+		}
+		if key.RevokedAt == nil || !key.RevokedAt.Equal(revokedAt) {
+			t.Errorf("revoked at: %v, want: %v", key.RevokedAt, revokedAt)
+		}
+		if err := repository.RevokeAPIKey(uuid.New().String(), now); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("RevokeAPIKey() error: %v, want: %v", err, logger.ErrNotFound)
+		}
+	})
+	t.Run("When the keys are listed", func(t *testing.T) {
+		keys, info, err := repository.GetAPIKeys(crud.Page{Number: 1, PerPage: 10})
+		if err != nil {
+			t.Fatalf("GetAPIKeys() error: %v", err)
+		}
+		if len(keys) != 1 || info.Total != 1 || keys[0].ID != fakeAPIKey.ID {
+			t.Errorf("GetAPIKeys() got: %v, %v", keys, info)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package sqlboiler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+func (r Repository) AddAuditEvent(event crud.AuditEvent) error {
+	diff, err := json.Marshal(event.Diff)
+	if err != nil {
+		return fmt.Errorf("could not encode the diff of the %s of %s %s: %v", event.Operation, event.EntityType, event.EntityID, err)
+	}
+	e := models.AuditEvent{
+		Actor:      event.Actor,
+		OccurredAt: event.OccurredAt,
+		EntityType: string(event.EntityType),
+		EntityID:   event.EntityID,
+		Operation:  string(event.Operation),
+		Diff:       types.JSON(diff),
+	}
+	return e.Insert(r.ctx, r.exec(), boil.Infer())
+}
+
+func (r Repository) GetHistory(entity crud.EntityType, id string, page crud.Page) ([]crud.AuditEvent, crud.PageInfo, error) {
+	where := []QueryMod{
+		models.AuditEventWhere.EntityType.EQ(string(entity)),
+		models.AuditEventWhere.EntityID.EQ(id),
+	}
+	total, err := models.AuditEvents(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	eventSlice, err := models.AuditEvents(append(
+		where,
+		OrderBy(fmt.Sprintf("%s DESC, %s DESC", models.AuditEventColumns.OccurredAt, models.AuditEventColumns.ID)),
+		Limit(page.PerPage),
+		Offset((page.Number-1)*page.PerPage),
+	)...).All(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	events := make([]crud.AuditEvent, len(eventSlice))
+	for i, e := range eventSlice {
+		events[i] = crud.AuditEvent{
+			ID:         e.ID,
+			Actor:      e.Actor,
+			OccurredAt: e.OccurredAt,
+			EntityType: crud.EntityType(e.EntityType),
+			EntityID:   e.EntityID,
+			Operation:  crud.AuditOperation(e.Operation),
+		}
+		if err := e.Diff.Unmarshal(&events[i].Diff); err != nil {
+			return nil, crud.PageInfo{}, fmt.Errorf("could not decode the diff of the audit event %d: %v", e.ID, err)
+		}
+	}
+	return events, crud.PageInfo{Total: total, Number: page.Number, PerPage: page.PerPage}, nil
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -16,61 +15,97 @@ import (
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (r Repository) UpdateCastMember(name string, castMemberDTO crud.CastMemberDTO) error {
-	castMember, err := r.FetchCastMember(name)
+func (r Repository) UpdateCastMember(id string, version int64, castMemberDTO crud.CastMemberDTO) error {
+	castMember, err := r.FetchCastMember(id)
 	if err != nil {
 		return err
 	}
-	nameDTO := strings.ToLower(strings.TrimSpace(castMemberDTO.Name))
+	nameDTO := crud.NormalizeName(castMemberDTO.Name)
 	castMember.Name = nameDTO
-	_, err = castMember.UpdateG(r.ctx, boil.Infer())
+	castMember.Type = int16(castMemberDTO.Type)
+	tx, err := r.beginTx()
 	if err != nil {
+		return err
+	}
+	if castMember.Version, err = r.bump(tx, models.TableNames.CastMembers, id, version); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
+	_, err = castMember.Update(r.ctx, tx, boil.Infer())
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
 		return fmt.Errorf("%s %w", nameDTO, logger.ErrAlreadyExists)
 	}
-	return nil
+	return tx.Commit()
 }
 
-func (r Repository) AddCastMember(castMemberDTO crud.CastMemberDTO) error {
+func (r Repository) AddCastMember(castMemberDTO crud.CastMemberDTO) (uuid.UUID, error) {
+	id := uuid.New()
 	castMember := models.CastMember{
-		ID:   uuid.New().String(),
-		Name: strings.ToLower(strings.TrimSpace(castMemberDTO.Name)),
+		ID:   id.String(),
+		Name: crud.NormalizeName(castMemberDTO.Name),
+		Type: int16(castMemberDTO.Type),
 	}
-	err := castMember.InsertG(r.ctx, boil.Infer())
+	err := castMember.Insert(r.ctx, r.exec(), boil.Infer())
 	if err != nil {
 		var e *pq.Error
 		if errors.As(err, &e) {
 			if e.Code.Name() == "unique_violation" {
-				return fmt.Errorf("name '%s' %w", castMemberDTO.Name, logger.ErrAlreadyExists)
+				return uuid.UUID{}, fmt.Errorf("name '%s' %w", castMemberDTO.Name, logger.ErrAlreadyExists)
 			} else {
-				return fmt.Errorf("%s: %w", "method Repository.AddCastMember(castMemberDTO)", err)
+				return uuid.UUID{}, fmt.Errorf("%s: %w", "method Repository.AddCastMember(castMemberDTO)", err)
 			}
 		}
+		return uuid.UUID{}, err
 	}
-	return nil
+	return id, nil
 }
 
-func (r Repository) RemoveCastMember(name string) error {
-	c, err := r.FetchCastMember(name)
+func (r Repository) RemoveCastMember(id string, version int64) error {
+	return r.remove(models.TableNames.CastMembers, id, version)
+}
+
+func (r Repository) GetCastMembersInTrash(page crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
+	var castMembers models.CastMemberSlice
+	info, err := r.trashed(models.TableNames.CastMembers, page, &castMembers)
 	if err != nil {
-		return err
+		return nil, crud.PageInfo{}, err
 	}
-	_, err = c.DeleteG(r.ctx, false)
-	return err
+	return castMembers, info, nil
 }
 
-func (r Repository) GetCastMembers(limit int) (models.CastMemberSlice, error) {
-	if limit <= 0 {
-		return nil, nil
+func (r Repository) RestoreCastMember(id string) error {
+	return r.restore(models.TableNames.CastMembers, id)
+}
+
+func (r Repository) PurgeCastMember(id string, version int64) error {
+	return r.purge(r.exec(), models.TableNames.CastMembers, id, version)
+}
+
+func (r Repository) GetCastMembers(filter crud.Filter, page crud.Page) (models.CastMemberSlice, crud.PageInfo, error) {
+	where := castMemberFilterMods(filter)
+	total, err := models.CastMembers(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	mods, err := pageMods(models.TableNames.CastMembers, filter, page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
 	}
-	castMembers, err := models.CastMembers(Limit(limit)).AllG(r.ctx)
+	castMembers, err := models.CastMembers(append(where, mods...)...).All(r.ctx, r.exec())
 	if err != nil {
-		return nil, err
+		return nil, crud.PageInfo{}, err
 	}
-	return castMembers, nil
+	n, info := pageOf(filter, page, total, castMembers)
+	return castMembers[:n], info, nil
 }
 
-func (r Repository) FetchCastMember(name string) (models.CastMember, error) {
-	castMemberSlice, err := models.CastMembers(models.CastMemberWhere.Name.EQ(name)).AllG(r.ctx)
+func (r Repository) FetchCastMember(id string) (models.CastMember, error) {
+	castMemberSlice, err := models.CastMembers(models.CastMemberWhere.ID.EQ(id)).All(r.ctx, r.exec())
 	if err != nil {
 		return models.CastMember{}, err
 	}
@@ -79,3 +114,42 @@ func (r Repository) FetchCastMember(name string) (models.CastMember, error) {
 	}
 	return *castMemberSlice[0], nil
 }
+
+func (r Repository) GetCastMemberVideos(id string, page crud.Page) (models.CastMemberVideoSlice, crud.PageInfo, error) {
+	if _, err := r.FetchCastMember(id); err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	where := []QueryMod{
+		InnerJoin(`"videos" ON "videos"."id" = "cast_member_video"."video_id" AND "videos"."deleted_at" IS NULL`),
+		models.CastMemberVideoWhere.CastMemberID.EQ(id),
+	}
+	total, err := models.CastMemberVideos(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	credits, err := models.CastMemberVideos(append(
+		where,
+		Select(`"cast_member_video".*`),
+		OrderBy(`"videos"."year_launched" DESC, "videos"."title", "videos"."id", "cast_member_video"."role"`),
+		Limit(page.PerPage),
+		Offset((page.Number-1)*page.PerPage),
+		Load(models.CastMemberVideoRels.Video),
+		Load(Rels(models.CastMemberVideoRels.Video, models.VideoRels.Categories)),
+		Load(Rels(models.CastMemberVideoRels.Video, models.VideoRels.Genres)),
+		Load(Rels(models.CastMemberVideoRels.Video, models.VideoRels.VideoAssets)),
+		Load(
+			Rels(models.CastMemberVideoRels.Video, models.VideoRels.CastMemberVideos),
+			Where(creditedCastMember),
+			OrderBy(castOrder),
+		),
+		Load(Rels(
+			models.CastMemberVideoRels.Video,
+			models.VideoRels.CastMemberVideos,
+			models.CastMemberVideoRels.CastMember,
+		)),
+	)...).All(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	return credits, crud.PageInfo{Total: total, Number: page.Number, PerPage: page.PerPage}, nil
+}
@@ -5,6 +5,7 @@ package sqlboiler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -65,11 +66,13 @@ func TestRepository_AddCastMember(t *testing.T) {
 			args: args{
 				crud.CastMemberDTO{
 					Name: fakeDoesNotExistName,
+					Type: crud.Composer,
 				},
 			},
 			want: returns{
 				models.CastMember{
 					Name: fakeDoesNotExistName,
+					Type: int16(crud.Composer),
 				},
 				nil,
 			},
@@ -94,7 +97,7 @@ func TestRepository_AddCastMember(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.AddCastMember(tt.args.castMemberDTO)
+			id, err := repository.AddCastMember(tt.args.castMemberDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddCastMember() error: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -103,6 +106,16 @@ func TestRepository_AddCastMember(t *testing.T) {
 				t.Errorf("AddCastMember() got: %v, want: %v", err, tt.want.err)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
+			got, err := repository.FetchCastMember(id.String())
+			if err != nil {
+				t.Fatalf("test: fetch cast member: %v", err)
+			}
+			if got.Type != tt.want.castMember.Type {
+				t.Errorf("AddCastMember() stored type: %d, want: %d", got.Type, tt.want.castMember.Type)
+			}
 		})
 	}
 }
@@ -116,12 +129,13 @@ func TestRepository_GetCastMembers(t *testing.T) {
 	defer teardownTestCase(t)
 	maximum := len(testdata.FakeCastMembers)
 	type args struct {
-		limit int
+		page crud.Page
 	}
 	type returns struct {
 		castMembers models.CastMemberSlice
 		e           error
 		amount      int
+		next        bool
 	}
 	tests := []struct {
 		name    string
@@ -130,39 +144,33 @@ func TestRepository_GetCastMembers(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When limit is negative",
-			args:    args{-1},
-			want:    returns{nil, nil, 0},
+			name:    "When per_page is less then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum - 1}},
+			want:    returns{nil, nil, maximum - 1, true},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is zero",
-			args:    args{0},
-			want:    returns{nil, nil, 0},
+			name:    "When per_page is equal the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is less then the maximum",
-			args:    args{maximum - 1},
-			want:    returns{nil, nil, maximum - 1},
+			name:    "When per_page is more then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum + 1}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is equal the maximum",
-			args:    args{maximum},
-			want:    returns{nil, nil, maximum},
-			wantErr: false,
-		},
-		{
-			name:    "When limit is more then the maximum",
-			args:    args{maximum + 1},
-			want:    returns{nil, nil, maximum},
+			name:    "When page is past the end",
+			args:    args{crud.Page{Number: 2, PerPage: maximum}},
+			want:    returns{nil, nil, 0, false},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.GetCastMembers(tt.args.limit)
+			got, info, err := repository.GetCastMembers(crud.Filter{Sort: crud.DefaultSort}, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCastMembers() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -170,8 +178,38 @@ func TestRepository_GetCastMembers(t *testing.T) {
 			if len(got) != tt.want.amount {
 				t.Errorf("GetCastMembers() len(got): %v, want: %d", len(got), tt.want.amount)
 			}
+			if info.Total != int64(maximum) {
+				t.Errorf("GetCastMembers() total: %d, want: %d", info.Total, maximum)
+			}
+			if (info.Next != "") != tt.want.next {
+				t.Errorf("GetCastMembers() next: %q, want one: %v", info.Next, tt.want.next)
+			}
 		})
 	}
+	t.Run("When type is given", func(t *testing.T) {
+		fakeType := crud.Director
+		got, info, err := repository.GetCastMembers(
+			crud.Filter{Type: &fakeType, Sort: crud.DefaultSort},
+			crud.Page{Number: 1, PerPage: maximum},
+		)
+		if err != nil {
+			t.Fatalf("GetCastMembers() error: %v", err)
+		}
+		var want int64
+		for _, castMember := range testdata.FakeCastMembers {
+			if castMember.Type == int16(fakeType) {
+				want++
+			}
+		}
+		if info.Total != want {
+			t.Errorf("GetCastMembers() total: %d, want: %d", info.Total, want)
+		}
+		for _, castMember := range got {
+			if castMember.Type != int16(fakeType) {
+				t.Errorf("GetCastMembers() got the type %d, want: %d", castMember.Type, fakeType)
+			}
+		}
+	})
 }
 
 func TestRepository_FetchCastMember(t *testing.T) {
@@ -181,12 +219,10 @@ func TestRepository_FetchCastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	const (
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		castMember models.CastMember
@@ -199,8 +235,8 @@ func TestRepository_FetchCastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is not found",
-			args: args{fakeDoesNotExistName},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.CastMember{},
 				sql.ErrNoRows,
@@ -208,11 +244,11 @@ func TestRepository_FetchCastMember(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "When name is found",
-			args: args{fakeExistName},
+			name: "When id is found",
+			args: args{fakeExistID},
 			want: returns{
 				models.CastMember{
-					Name: fakeExistName,
+					Name: testdata.FakeCastMembers[0].Name,
 				},
 				nil,
 			},
@@ -222,7 +258,7 @@ func TestRepository_FetchCastMember(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.FetchCastMember(tt.args.name)
+			got, err := repository.FetchCastMember(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchCastMember() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -241,15 +277,13 @@ func TestRepository_RemoveCastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	const (
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -259,21 +293,21 @@ func TestRepository_RemoveCastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is not found",
-			args:    args{fakeDoesNotExistName},
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID},
 			want:    sql.ErrNoRows,
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeExistName},
+			name:    "When id is found",
+			args:    args{fakeExistID},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.RemoveCastMember(tt.args.name)
+			err := repository.RemoveCastMember(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveCastMember() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -292,16 +326,14 @@ func TestRepository_UpdateCastMember(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistName := testdata.FakeCastMembers[0].Name
-	const (
-		fakeDoesNotExistName     = "fakeDoesNotExistName"
-		fakeNewDoestNotExistName = "new_action"
-	)
+	fakeExistID := testdata.FakeCastMembers[0].ID
+	fakeDoesNotExistID := uuid.New().String()
+	const fakeNewDoestNotExistName = "new_action"
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		name          string
+		id            string
 		castMemberDTO crud.CastMemberDTO
 	}
 	tests := []struct {
@@ -312,9 +344,9 @@ func TestRepository_UpdateCastMember(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name to update doesn't exist",
+			name: "When id to update doesn't exist",
 			args: args{
-				fakeDoesNotExistName,
+				fakeDoesNotExistID,
 				crud.CastMemberDTO{
 					Name: fakeNewDoestNotExistName,
 				},
@@ -323,9 +355,9 @@ func TestRepository_UpdateCastMember(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "When name exists and CastMemberDTO is right",
+			name: "When id exists and CastMemberDTO is right",
 			args: args{
-				fakeExistName,
+				fakeExistID,
 				crud.CastMemberDTO{
 					Name: fakeNewDoestNotExistName,
 				},
@@ -336,7 +368,7 @@ func TestRepository_UpdateCastMember(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.UpdateCastMember(tt.args.name, tt.args.castMemberDTO)
+			err := repository.UpdateCastMember(tt.args.id, crud.AnyVersion, tt.args.castMemberDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateCastMember() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -349,6 +381,97 @@ func TestRepository_UpdateCastMember(t *testing.T) {
 	}
 }
 
+func TestRepository_GetCastMemberVideos(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(testdata.FakeCastMembers)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeCastMemberID := testdata.FakeCastMembers[0].ID
+	fakeVideoDTO := func(title string, yearLaunched int16, cast ...crud.VideoCastMemberDTO) crud.VideoDTO {
+		return crud.VideoDTO{
+			Title:        title,
+			YearLaunched: &yearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Genres:       []crud.GenreDTO{fakeGenreDTO},
+			Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+			CastMembers:  cast,
+		}
+	}
+	if _, err := repository.AddVideo(fakeVideoDTO(
+		"fake old video",
+		1990,
+		crud.VideoCastMemberDTO{ID: fakeCastMemberID, Role: crud.Actor, Character: "fake character", BillingOrder: billingOrder(2)},
+	)); err != nil {
+		t.Fatalf("test: add video: %v", err)
+	}
+	fakeNewVideoID, err := repository.AddVideo(fakeVideoDTO(
+		"fake new video",
+		2020,
+		crud.VideoCastMemberDTO{ID: fakeCastMemberID, Role: crud.Director, BillingOrder: billingOrder(1)},
+		crud.VideoCastMemberDTO{ID: testdata.FakeCastMembers[1].ID, Role: crud.Actor, BillingOrder: billingOrder(2)},
+	))
+	if err != nil {
+		t.Fatalf("test: add video: %v", err)
+	}
+	t.Run("When a cast member does not exist", func(t *testing.T) {
+		_, err := repository.AddVideo(fakeVideoDTO(
+			"fake video",
+			2020,
+			crud.VideoCastMemberDTO{ID: uuid.New().String(), Role: crud.Actor, BillingOrder: billingOrder(1)},
+		))
+		if !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("AddVideo() error: %v, want: %v", err, logger.ErrNotFound)
+		}
+	})
+	t.Run("When the video is fetched", func(t *testing.T) {
+		video, err := repository.FetchVideo(fakeNewVideoID.String())
+		if err != nil {
+			t.Fatalf("FetchVideo() error: %v", err)
+		}
+		if len(video.R.CastMemberVideos) != 2 {
+			t.Fatalf("FetchVideo() got %d credits, want: 2", len(video.R.CastMemberVideos))
+		}
+		director := video.R.CastMemberVideos[0]
+		if director.CastMemberID != fakeCastMemberID || director.R.CastMember == nil {
+			t.Errorf("FetchVideo() billed %s first, want: %s", director.CastMemberID, fakeCastMemberID)
+		}
+	})
+	t.Run("When id is not found", func(t *testing.T) {
+		_, _, err := repository.GetCastMemberVideos(uuid.New().String(), crud.Page{Number: 1, PerPage: crud.DefaultPerPage})
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("GetCastMemberVideos() error: %v, want: %v", err, sql.ErrNoRows)
+		}
+	})
+	t.Run("When id is found", func(t *testing.T) {
+		got, info, err := repository.GetCastMemberVideos(fakeCastMemberID, crud.Page{Number: 1, PerPage: crud.DefaultPerPage})
+		if err != nil {
+			t.Fatalf("GetCastMemberVideos() error: %v", err)
+		}
+		if len(got) != 2 || info.Total != 2 {
+			t.Fatalf("GetCastMemberVideos() found: %d of %d, want: 2", len(got), info.Total)
+		}
+		if got[0].R.Video.Title != "fake new video" || got[0].Role != int16(crud.Director) {
+			t.Errorf("GetCastMemberVideos() listed %s first, want the latest video", got[0].R.Video.Title)
+		}
+		if got[1].CharacterName.String != "fake character" {
+			t.Errorf("GetCastMemberVideos() character: %q, want: %q", got[1].CharacterName.String, "fake character")
+		}
+	})
+}
+
 func TestCastMember_isValidUUIDHook(t *testing.T) {
 	_, teardownTestCase, repository, err := setupTestCase(nil)
 	if err != nil {
@@ -16,17 +16,23 @@ import (
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (r Repository) UpdateCategory(name string, categoryDTO crud.CategoryDTO) error {
-	category, err := r.FetchCategory(name)
+func (r Repository) UpdateCategory(id string, version int64, categoryDTO crud.CategoryDTO) error {
+	category, err := r.FetchCategory(id)
 	if err != nil {
 		return err
 	}
 	category.Name = categoryDTO.Name
 	category.Description = null.String{String: categoryDTO.Description, Valid: true}
-	tx, err := boil.BeginTx(r.ctx, nil)
+	tx, err := r.beginTx()
 	if err != nil {
 		return err
 	}
+	if category.Version, err = r.bump(tx, models.TableNames.Categories, id, version); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
 	_, err = category.Update(r.ctx, tx, boil.Infer())
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -46,43 +52,45 @@ func (r Repository) UpdateCategory(name string, categoryDTO crud.CategoryDTO) er
 	return nil
 }
 
-func (r Repository) AddCategory(categoryDTO crud.CategoryDTO) error {
+func (r Repository) AddCategory(categoryDTO crud.CategoryDTO) (uuid.UUID, error) {
+	id := uuid.New()
 	category := models.Category{
-		ID:          uuid.New().String(),
+		ID:          id.String(),
 		Name:        categoryDTO.Name,
 		Description: null.String{String: categoryDTO.Description, Valid: true},
 	}
-	tx, err := boil.BeginTx(r.ctx, nil)
+	tx, err := r.beginTx()
 	if err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
 	err = category.Insert(r.ctx, tx, boil.Infer())
 	if err != nil {
 		var e *pq.Error
 		if err := tx.Rollback(); err != nil {
-			return err
+			return uuid.UUID{}, err
 		}
 		if errors.As(err, &e) {
 			if e.Code.Name() == "unique_violation" {
-				return fmt.Errorf("name '%s' %w", categoryDTO.Name, logger.ErrAlreadyExists)
+				return uuid.UUID{}, fmt.Errorf("name '%s' %w", categoryDTO.Name, logger.ErrAlreadyExists)
 			} else {
-				return fmt.Errorf("%s: %w", "method Repository.AddCategory(categoryDTO)", err)
+				return uuid.UUID{}, fmt.Errorf("%s: %w", "method Repository.AddCategory(categoryDTO)", err)
 			}
 		}
+		return uuid.UUID{}, err
 	}
 	if err := r.setGenresInCategory(categoryDTO.Genres, category, tx); err != nil {
 		if err := tx.Rollback(); err != nil {
-			return err
+			return uuid.UUID{}, err
 		}
-		return err
+		return uuid.UUID{}, err
 	}
 	if err := tx.Commit(); err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
-	return nil
+	return id, nil
 }
 
-func (r Repository) setGenresInCategory(genres []crud.GenreDTO, category models.Category, tx *sql.Tx) error {
+func (r Repository) setGenresInCategory(genres []crud.GenreDTO, category models.Category, tx boil.ContextExecutor) error {
 	if genres == nil || len(genres) == 0 {
 		return nil
 	}
@@ -96,7 +104,7 @@ func (r Repository) setGenresInCategory(genres []crud.GenreDTO, category models.
 	}
 	genreSlice, err := models.Genres(
 		Where(clause, genreNames...),
-	).AllG(r.ctx)
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return err
 	}
@@ -109,29 +117,50 @@ func (r Repository) setGenresInCategory(genres []crud.GenreDTO, category models.
 	return nil
 }
 
-func (r Repository) RemoveCategory(name string) error {
-	c, err := r.FetchCategory(name)
+func (r Repository) RemoveCategory(id string, version int64) error {
+	return r.remove(models.TableNames.Categories, id, version)
+}
+
+func (r Repository) GetCategoriesInTrash(page crud.Page) (models.CategorySlice, crud.PageInfo, error) {
+	var categories models.CategorySlice
+	info, err := r.trashed(models.TableNames.Categories, page, &categories)
 	if err != nil {
-		return err
+		return nil, crud.PageInfo{}, err
 	}
-	c.IsValidated = false
-	_, err = c.UpdateG(r.ctx, boil.Infer())
-	return err
+	return categories, info, nil
+}
+
+func (r Repository) RestoreCategory(id string) error {
+	return r.restore(models.TableNames.Categories, id)
 }
 
-func (r Repository) GetCategories(limit int) (models.CategorySlice, error) {
-	if limit <= 0 {
-		return nil, nil
+func (r Repository) PurgeCategory(id string, version int64) error {
+	return r.purge(r.exec(), models.TableNames.Categories, id, version)
+}
+
+func (r Repository) GetCategories(filter crud.Filter, page crud.Page) (models.CategorySlice, crud.PageInfo, error) {
+	where := categoryFilterMods(filter)
+	total, err := models.Categories(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	mods, err := pageMods(models.TableNames.Categories, filter, page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
 	}
-	categories, err := models.Categories(Where("is_validated=?", true), Limit(limit)).AllG(r.ctx)
+	categories, err := models.Categories(append(where, mods...)...).All(r.ctx, r.exec())
 	if err != nil {
-		return nil, err
+		return nil, crud.PageInfo{}, err
 	}
-	return categories, nil
+	n, info := pageOf(filter, page, total, categories)
+	return categories[:n], info, nil
 }
 
-func (r Repository) FetchCategory(name string) (models.Category, error) {
-	categorySlice, err := models.Categories(Where("is_validated=?", true), models.CategoryWhere.Name.EQ(name)).AllG(r.ctx)
+func (r Repository) FetchCategory(id string) (models.Category, error) {
+	categorySlice, err := models.Categories(
+		models.CategoryWhere.ID.EQ(id),
+		Load(models.CategoryRels.Genres),
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return models.Category{}, err
 	}
@@ -36,11 +36,11 @@ func TestRepository_AddCategory(t *testing.T) {
 	fakeExistCategoryDTO := crud.CategoryDTO{Name: fakeExistCategoryName}
 	fakeDoesNotExistGenreDTO := crud.GenreDTO{Name: fakeDoesNotExistGenreName}
 	fakeExistGenreDTO := crud.GenreDTO{Name: fakeExistGenreName}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
@@ -96,7 +96,7 @@ func TestRepository_AddCategory(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.AddCategory(tt.args.categoryDTO)
+			id, err := repository.AddCategory(tt.args.categoryDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddCategory() error: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -107,6 +107,9 @@ func TestRepository_AddCategory(t *testing.T) {
 				}
 				return
 			}
+			if !tt.wantErr && id == uuid.Nil {
+				t.Errorf("AddCategory() got a nil id")
+			}
 		})
 	}
 }
@@ -120,12 +123,13 @@ func TestRepository_GetCategories(t *testing.T) {
 	defer teardownTestCase(t)
 	maximum := len(testdata.FakeCategories)
 	type args struct {
-		limit int
+		page crud.Page
 	}
 	type returns struct {
 		categories models.CategorySlice
 		e          error
 		amount     int
+		next       bool
 	}
 	tests := []struct {
 		name    string
@@ -134,39 +138,33 @@ func TestRepository_GetCategories(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When limit is negative",
-			args:    args{-1},
-			want:    returns{nil, nil, 0},
-			wantErr: false,
-		},
-		{
-			name:    "When limit is zero",
-			args:    args{0},
-			want:    returns{nil, nil, 0},
+			name:    "When per_page is less then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum - 1}},
+			want:    returns{nil, nil, maximum - 1, true},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is less then the maximum",
-			args:    args{maximum - 1},
-			want:    returns{nil, nil, maximum - 1},
+			name:    "When per_page is equal the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is equal the maximum",
-			args:    args{maximum},
-			want:    returns{nil, nil, maximum},
+			name:    "When per_page is more then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum + 1}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is more then the maximum",
-			args:    args{maximum + 1},
-			want:    returns{nil, nil, maximum},
+			name:    "When page is past the end",
+			args:    args{crud.Page{Number: 2, PerPage: maximum}},
+			want:    returns{nil, nil, 0, false},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.GetCategories(tt.args.limit)
+			got, info, err := repository.GetCategories(crud.Filter{Sort: crud.DefaultSort}, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCategories() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -174,10 +172,96 @@ func TestRepository_GetCategories(t *testing.T) {
 			if len(got) != tt.want.amount {
 				t.Errorf("GetCategories() len(got): %v, want: %d", len(got), tt.want.amount)
 			}
+			if info.Total != int64(maximum) {
+				t.Errorf("GetCategories() total: %d, want: %d", info.Total, maximum)
+			}
+			if (info.Next != "") != tt.want.next {
+				t.Errorf("GetCategories() next: %q, want one: %v", info.Next, tt.want.next)
+			}
 		})
 	}
 }
 
+func TestRepository_GetCategories_Cursor(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(testdata.FakeCategories)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	maximum := len(testdata.FakeCategories)
+	filter := crud.Filter{Sort: []crud.SortKey{{Field: "name", Desc: true}}}
+	all, _, err := repository.GetCategories(filter, crud.Page{Number: 1, PerPage: maximum})
+	if err != nil {
+		t.Fatalf("test: get categories: %v", err)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Name < all[i].Name {
+			t.Fatalf("GetCategories() sorted %s before %s, want the names descending", all[i-1].Name, all[i].Name)
+		}
+	}
+	const perPage = 2
+	var forward, backward []string
+	t.Run("When the cursors are followed forward", func(t *testing.T) {
+		page := crud.Page{Number: 1, PerPage: perPage}
+		for {
+			categories, info, err := repository.GetCategories(filter, page)
+			if err != nil {
+				t.Fatalf("GetCategories() error: %v", err)
+			}
+			for _, c := range categories {
+				forward = append(forward, c.ID)
+			}
+			if info.Next == "" {
+				break
+			}
+			cursor, err := crud.ParseCursor(info.Next)
+			if err != nil {
+				t.Fatalf("GetCategories() next: %v", err)
+			}
+			page = crud.Page{Cursor: cursor, PerPage: perPage}
+		}
+		for i, c := range all {
+			if i >= len(forward) || forward[i] != c.ID {
+				t.Fatalf("GetCategories() walked: %v, want the order of %d categories", forward, len(all))
+			}
+		}
+	})
+	t.Run("When the cursors are followed backward", func(t *testing.T) {
+		last := crud.Cursor{
+			Sort:   filter.SortString(),
+			Keys:   []interface{}{all[len(all)-1].Name},
+			ID:     all[len(all)-1].ID,
+			Before: true,
+		}
+		page := crud.Page{Cursor: &last, PerPage: perPage}
+		for {
+			categories, info, err := repository.GetCategories(filter, page)
+			if err != nil {
+				t.Fatalf("GetCategories() error: %v", err)
+			}
+			for i := len(categories) - 1; i >= 0; i-- {
+				backward = append(backward, categories[i].ID)
+			}
+			if info.Prev == "" {
+				break
+			}
+			cursor, err := crud.ParseCursor(info.Prev)
+			if err != nil {
+				t.Fatalf("GetCategories() prev: %v", err)
+			}
+			page = crud.Page{Cursor: cursor, PerPage: perPage}
+		}
+		if len(backward) != len(all)-1 {
+			t.Fatalf("GetCategories() walked back %d categories, want: %d", len(backward), len(all)-1)
+		}
+		for i, id := range backward {
+			if want := all[len(all)-2-i].ID; id != want {
+				t.Errorf("GetCategories() walked back to %s, want: %s", id, want)
+			}
+		}
+	})
+}
+
 func TestRepository_FetchCategory(t *testing.T) {
 	_, teardownTestCase, repository, err := setupTestCase(testdata.FakeCategories)
 	if err != nil {
@@ -185,12 +269,10 @@ func TestRepository_FetchCategory(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	const (
-		fakeExistName        = "action"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistCategory := testdata.FakeCategories[0]
+	fakeDoesNotExistID := uuid.New().String()
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		category models.Category
@@ -203,8 +285,8 @@ func TestRepository_FetchCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is not found",
-			args: args{fakeDoesNotExistName},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.Category{},
 				sql.ErrNoRows,
@@ -212,11 +294,11 @@ func TestRepository_FetchCategory(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "When name is found",
-			args: args{fakeExistName},
+			name: "When id is found",
+			args: args{fakeExistCategory.ID},
 			want: returns{
 				models.Category{
-					Name: fakeExistName,
+					Name: fakeExistCategory.Name,
 				},
 				nil,
 			},
@@ -225,7 +307,7 @@ func TestRepository_FetchCategory(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.FetchCategory(tt.args.name)
+			got, err := repository.FetchCategory(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchCategory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -244,15 +326,13 @@ func TestRepository_RemoveCategory(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	const (
-		fakeExistName        = "action"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistCategory := testdata.FakeCategories[0]
+	fakeDoesNotExistID := uuid.New().String()
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -262,21 +342,21 @@ func TestRepository_RemoveCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is not found",
-			args:    args{fakeDoesNotExistName},
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID},
 			want:    sql.ErrNoRows,
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeExistName},
+			name:    "When id is found",
+			args:    args{fakeExistCategory.ID},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.RemoveCategory(tt.args.name)
+			err := repository.RemoveCategory(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveCategory() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -304,24 +384,26 @@ func TestRepository_UpdateCategory(t *testing.T) {
 		fakeExistGenreName               = "fakeExistGenreName"
 		fakeDesc                         = "fakeDesc"
 	)
+	fakeDoesNotExistCategoryID := uuid.New().String()
 	fakeDoesNotExistGenreDTO := crud.GenreDTO{Name: fakeDoesNotExistGenreName}
 	fakeExistCategoryDTO := crud.CategoryDTO{Name: fakeExistCategoryName}
 	fakeNewExistCategoryDTO := crud.CategoryDTO{Name: fakeNewExistCategoryName}
 	fakeExistGenreDTO := crud.GenreDTO{Name: fakeExistGenreName}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	fakeExistCategoryID, err := repository.AddCategory(fakeExistCategoryDTO)
+	if err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
-	if err := repository.AddCategory(fakeNewExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeNewExistCategoryDTO); err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
 	type args struct {
-		name        string
+		id          string
 		categoryDTO crud.CategoryDTO
 	}
 	tests := []struct {
@@ -331,9 +413,9 @@ func TestRepository_UpdateCategory(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name to update doesn't exist",
+			name: "When id to update doesn't exist",
 			args: args{
-				fakeDoesNotExistCategoryName,
+				fakeDoesNotExistCategoryID,
 				crud.CategoryDTO{
 					Name:   fakeNewDoestNotExistCategoryName,
 					Genres: []crud.GenreDTO{fakeExistGenreDTO},
@@ -345,7 +427,7 @@ func TestRepository_UpdateCategory(t *testing.T) {
 		{
 			name: "When name in CategoryDTO already exists",
 			args: args{
-				fakeExistCategoryName,
+				fakeExistCategoryID.String(),
 				crud.CategoryDTO{
 					Name:   fakeNewExistCategoryName,
 					Genres: []crud.GenreDTO{fakeExistGenreDTO},
@@ -357,7 +439,7 @@ func TestRepository_UpdateCategory(t *testing.T) {
 		{
 			name: "When CategoryDTO is with wrong genres",
 			args: args{
-				fakeExistCategoryName,
+				fakeExistCategoryID.String(),
 				crud.CategoryDTO{
 					Name:        fakeDoesNotExistCategoryName,
 					Description: fakeDesc,
@@ -370,7 +452,7 @@ func TestRepository_UpdateCategory(t *testing.T) {
 		{
 			name: "When everything is right",
 			args: args{
-				fakeExistCategoryName,
+				fakeExistCategoryID.String(),
 				crud.CategoryDTO{
 					Name:        fakeNewDoestNotExistCategoryName,
 					Description: fakeDesc,
@@ -383,7 +465,7 @@ func TestRepository_UpdateCategory(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.UpdateCategory(tt.args.name, tt.args.categoryDTO)
+			err := repository.UpdateCategory(tt.args.id, crud.AnyVersion, tt.args.categoryDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateCategory() got: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,119 @@
+package sqlboiler
+
+import (
+	"strings"
+
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// exists keeps the rows for which query finds something, or the ones for which it does not when want is false
+func exists(want bool, query string, args ...interface{}) QueryMod {
+	if want {
+		return Where("EXISTS ("+query+")", args...)
+	}
+	return Where("NOT EXISTS ("+query+")", args...)
+}
+
+// nameMods keeps the rows whose column is the name of filter and the ones it starts with the prefix of filter. The
+// names are stored as crud.NormalizeName writes them, so they are compared as they are.
+func nameMods(column string, filter crud.Filter) []QueryMod {
+	var mods []QueryMod
+	if filter.Name != "" {
+		mods = append(mods, Where(column+` = ?`, filter.Name))
+	}
+	if filter.NamePrefix != "" {
+		mods = append(mods, Where(`lower(`+column+`) LIKE ?`, likeEscaper.Replace(filter.NamePrefix)+"%"))
+	}
+	return mods
+}
+
+func castMemberFilterMods(filter crud.Filter) []QueryMod {
+	var mods []QueryMod
+	mods = append(mods, nameMods(`"cast_members"."name"`, filter)...)
+	if filter.Type != nil {
+		mods = append(mods, models.CastMemberWhere.Type.EQ(int16(*filter.Type)))
+	}
+	return mods
+}
+
+func categoryFilterMods(filter crud.Filter) []QueryMod {
+	var mods []QueryMod
+	mods = append(mods, nameMods(`"categories"."name"`, filter)...)
+	if filter.HasVideos != nil {
+		mods = append(mods, exists(
+			*filter.HasVideos,
+			`SELECT 1 FROM "category_video" JOIN "videos" ON "videos"."id" = "category_video"."video_id"
+			WHERE "category_video"."category_id" = "categories"."id" AND "videos"."deleted_at" IS NULL`,
+		))
+	}
+	return mods
+}
+
+func genreFilterMods(filter crud.Filter) []QueryMod {
+	var mods []QueryMod
+	mods = append(mods, nameMods(`"genres"."name"`, filter)...)
+	if filter.HasVideos != nil {
+		mods = append(mods, exists(
+			*filter.HasVideos,
+			`SELECT 1 FROM "genre_video" JOIN "videos" ON "videos"."id" = "genre_video"."video_id"
+			WHERE "genre_video"."genre_id" = "genres"."id" AND "videos"."deleted_at" IS NULL`,
+		))
+	}
+	return mods
+}
+
+func videoFilterMods(filter crud.Filter) []QueryMod {
+	var mods []QueryMod
+	mods = append(mods, nameMods(`"videos"."title"`, filter)...)
+	if filter.Category != "" {
+		mods = append(mods, exists(
+			true,
+			`SELECT 1 FROM "category_video" JOIN "categories" ON "categories"."id" = "category_video"."category_id"
+			WHERE "category_video"."video_id" = "videos"."id" AND "categories"."deleted_at" IS NULL AND "categories"."name" = ?`,
+			filter.Category,
+		))
+	}
+	if filter.Genre != "" {
+		mods = append(mods, exists(
+			true,
+			`SELECT 1 FROM "genre_video" JOIN "genres" ON "genres"."id" = "genre_video"."genre_id"
+			WHERE "genre_video"."video_id" = "videos"."id" AND "genres"."deleted_at" IS NULL AND "genres"."name" = ?`,
+			filter.Genre,
+		))
+	}
+	if filter.Rating != nil {
+		mods = append(mods, Where(`"videos"."rating" = ?`, int16(*filter.Rating)))
+	}
+	if filter.MaxRating != nil {
+		mods = append(mods, Where(`"videos"."rating" <= ?`, int16(*filter.MaxRating)))
+	}
+	mods = append(mods, rangeMods(`"videos"."year_launched"`, filter.YearLaunched)...)
+	mods = append(mods, rangeMods(`"videos"."duration"`, filter.Duration)...)
+	if filter.Opened != nil {
+		mods = append(mods, Where(`COALESCE("videos"."opened", false) = ?`, *filter.Opened))
+	}
+	if filter.HasFile != nil {
+		mods = append(mods, exists(
+			*filter.HasFile,
+			`SELECT 1 FROM "video_assets" WHERE "video_assets"."video_id" = "videos"."id" AND "video_assets"."kind" = ?`,
+			string(crud.VideoAsset),
+		))
+	}
+	return mods
+}
+
+func rangeMods(column string, r crud.Range) []QueryMod {
+	var mods []QueryMod
+	if r.Min != nil {
+		mods = append(mods, Where(column+" >= ?", *r.Min))
+	}
+	if r.Max != nil {
+		mods = append(mods, Where(column+" <= ?", *r.Max))
+	}
+	return mods
+}
@@ -15,16 +15,22 @@ import (
 	"github.com/selmison/code-micro-videos/pkg/logger"
 )
 
-func (r Repository) UpdateGenre(name string, genreDTO crud.GenreDTO) error {
-	genre, err := r.FetchGenre(name)
+func (r Repository) UpdateGenre(id string, version int64, genreDTO crud.GenreDTO) error {
+	genre, err := r.FetchGenre(id)
 	if err != nil {
 		return err
 	}
 	genre.Name = genreDTO.Name
-	tx, err := boil.BeginTx(r.ctx, nil)
+	tx, err := r.beginTx()
 	if err != nil {
 		return err
 	}
+	if genre.Version, err = r.bump(tx, models.TableNames.Genres, id, version); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
 	_, err = genre.Update(r.ctx, tx, boil.Infer())
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -44,41 +50,43 @@ func (r Repository) UpdateGenre(name string, genreDTO crud.GenreDTO) error {
 	return nil
 }
 
-func (r Repository) AddGenre(genreDTO crud.GenreDTO) error {
+func (r Repository) AddGenre(genreDTO crud.GenreDTO) (uuid.UUID, error) {
+	id := uuid.New()
 	genre := models.Genre{
-		ID:   uuid.New().String(),
+		ID:   id.String(),
 		Name: genreDTO.Name,
 	}
-	tx, err := boil.BeginTx(r.ctx, nil)
+	tx, err := r.beginTx()
 	if err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
 	err = genre.Insert(r.ctx, tx, boil.Infer())
 	if err != nil {
 		var e *pq.Error
 		if err := tx.Rollback(); err != nil {
-			return err
+			return uuid.UUID{}, err
 		}
 		if errors.As(err, &e) {
 			if e.Code.Name() == "unique_violation" {
-				return fmt.Errorf("name '%s' %w", genreDTO.Name, logger.ErrAlreadyExists)
+				return uuid.UUID{}, fmt.Errorf("name '%s' %w", genreDTO.Name, logger.ErrAlreadyExists)
 			} else {
-				return fmt.Errorf("%s: %w", "method Repository.AddGenre(genreDTO)", err)
+				return uuid.UUID{}, fmt.Errorf("%s: %w", "method Repository.AddGenre(genreDTO)", err)
 			}
 		}
+		return uuid.UUID{}, err
 	}
 	if err := r.setCategoriesInGenre(genreDTO.Categories, genre, tx); err != nil {
 		if err := tx.Rollback(); err != nil {
-			return err
+			return uuid.UUID{}, err
 		}
-		return err
+		return uuid.UUID{}, err
 	}
 	if err := tx.Commit(); err != nil {
-		return err
+		return uuid.UUID{}, err
 	}
-	return nil
+	return id, nil
 }
-func (r Repository) setCategoriesInGenre(categories []crud.CategoryDTO, genre models.Genre, tx *sql.Tx) error {
+func (r Repository) setCategoriesInGenre(categories []crud.CategoryDTO, genre models.Genre, tx boil.ContextExecutor) error {
 	if categories == nil || len(categories) == 0 {
 		return nil
 	}
@@ -92,7 +100,7 @@ func (r Repository) setCategoriesInGenre(categories []crud.CategoryDTO, genre mo
 	}
 	categorySlice, err := models.Categories(
 		Where(clause, categoryNames...),
-	).AllG(r.ctx)
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return err
 	}
@@ -105,29 +113,50 @@ func (r Repository) setCategoriesInGenre(categories []crud.CategoryDTO, genre mo
 	return nil
 }
 
-func (r Repository) RemoveGenre(name string) error {
-	c, err := r.FetchGenre(name)
+func (r Repository) RemoveGenre(id string, version int64) error {
+	return r.remove(models.TableNames.Genres, id, version)
+}
+
+func (r Repository) GetGenresInTrash(page crud.Page) (models.GenreSlice, crud.PageInfo, error) {
+	var genres models.GenreSlice
+	info, err := r.trashed(models.TableNames.Genres, page, &genres)
 	if err != nil {
-		return err
+		return nil, crud.PageInfo{}, err
 	}
-	c.IsValidated = false
-	_, err = c.UpdateG(r.ctx, boil.Infer())
-	return err
+	return genres, info, nil
+}
+
+func (r Repository) RestoreGenre(id string) error {
+	return r.restore(models.TableNames.Genres, id)
 }
 
-func (r Repository) GetGenres(limit int) (models.GenreSlice, error) {
-	if limit <= 0 {
-		return nil, nil
+func (r Repository) PurgeGenre(id string, version int64) error {
+	return r.purge(r.exec(), models.TableNames.Genres, id, version)
+}
+
+func (r Repository) GetGenres(filter crud.Filter, page crud.Page) (models.GenreSlice, crud.PageInfo, error) {
+	where := genreFilterMods(filter)
+	total, err := models.Genres(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	mods, err := pageMods(models.TableNames.Genres, filter, page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
 	}
-	genres, err := models.Genres(Where("is_validated=?", true), Limit(limit)).AllG(r.ctx)
+	genres, err := models.Genres(append(where, mods...)...).All(r.ctx, r.exec())
 	if err != nil {
-		return nil, err
+		return nil, crud.PageInfo{}, err
 	}
-	return genres, nil
+	n, info := pageOf(filter, page, total, genres)
+	return genres[:n], info, nil
 }
 
-func (r Repository) FetchGenre(name string) (models.Genre, error) {
-	genreSlice, err := models.Genres(Where("is_validated=?", true), models.GenreWhere.Name.EQ(name)).AllG(r.ctx)
+func (r Repository) FetchGenre(id string) (models.Genre, error) {
+	genreSlice, err := models.Genres(
+		models.GenreWhere.ID.EQ(id),
+		Load(models.GenreRels.Categories),
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return models.Genre{}, err
 	}
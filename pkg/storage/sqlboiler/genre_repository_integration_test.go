@@ -35,11 +35,11 @@ func TestRepository_AddGenre(t *testing.T) {
 	fakeExistGenreDTO := crud.GenreDTO{Name: fakeExistGenreName}
 	fakeDoesNotExistCategoryDTO := crud.CategoryDTO{Name: fakeDoesNotExistCategoryName}
 	fakeExistCategoryDTO := crud.CategoryDTO{Name: fakeExistCategoryName}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
@@ -94,7 +94,7 @@ func TestRepository_AddGenre(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.AddGenre(tt.args.genreDTO)
+			id, err := repository.AddGenre(tt.args.genreDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddGenre() error: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -105,6 +105,9 @@ func TestRepository_AddGenre(t *testing.T) {
 				}
 				return
 			}
+			if !tt.wantErr && id == uuid.Nil {
+				t.Errorf("AddGenre() got a nil id")
+			}
 		})
 	}
 }
@@ -118,12 +121,13 @@ func TestRepository_GetGenres(t *testing.T) {
 	defer teardownTestCase(t)
 	maximum := len(testdata.FakeGenres)
 	type args struct {
-		limit int
+		page crud.Page
 	}
 	type returns struct {
 		genres models.GenreSlice
 		e      error
 		amount int
+		next   bool
 	}
 	tests := []struct {
 		name    string
@@ -132,39 +136,33 @@ func TestRepository_GetGenres(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When limit is negative",
-			args:    args{-1},
-			want:    returns{nil, nil, 0},
-			wantErr: false,
-		},
-		{
-			name:    "When limit is zero",
-			args:    args{0},
-			want:    returns{nil, nil, 0},
+			name:    "When per_page is less then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum - 1}},
+			want:    returns{nil, nil, maximum - 1, true},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is less then the maximum",
-			args:    args{maximum - 1},
-			want:    returns{nil, nil, maximum - 1},
+			name:    "When per_page is equal the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is equal the maximum",
-			args:    args{maximum},
-			want:    returns{nil, nil, maximum},
+			name:    "When per_page is more then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum + 1}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is more then the maximum",
-			args:    args{maximum + 1},
-			want:    returns{nil, nil, maximum},
+			name:    "When page is past the end",
+			args:    args{crud.Page{Number: 2, PerPage: maximum}},
+			want:    returns{nil, nil, 0, false},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.GetGenres(tt.args.limit)
+			got, info, err := repository.GetGenres(crud.Filter{Sort: crud.DefaultSort}, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetGenres() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -172,6 +170,12 @@ func TestRepository_GetGenres(t *testing.T) {
 			if len(got) != tt.want.amount {
 				t.Errorf("GetGenres() len(got): %v, want: %d", len(got), tt.want.amount)
 			}
+			if info.Total != int64(maximum) {
+				t.Errorf("GetGenres() total: %d, want: %d", info.Total, maximum)
+			}
+			if (info.Next != "") != tt.want.next {
+				t.Errorf("GetGenres() next: %q, want one: %v", info.Next, tt.want.next)
+			}
 		})
 	}
 }
@@ -183,12 +187,10 @@ func TestRepository_FetchGenre(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	const (
-		fakeExistName        = "action"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistGenre := testdata.FakeGenres[0]
+	fakeDoesNotExistID := uuid.New().String()
 	type args struct {
-		name string
+		id string
 	}
 	type returns struct {
 		genre models.Genre
@@ -201,8 +203,8 @@ func TestRepository_FetchGenre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name is not found",
-			args: args{fakeDoesNotExistName},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.Genre{},
 				sql.ErrNoRows,
@@ -210,11 +212,11 @@ func TestRepository_FetchGenre(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "When name is found",
-			args: args{fakeExistName},
+			name: "When id is found",
+			args: args{fakeExistGenre.ID},
 			want: returns{
 				models.Genre{
-					Name: fakeExistName,
+					Name: fakeExistGenre.Name,
 				},
 				nil,
 			},
@@ -223,7 +225,7 @@ func TestRepository_FetchGenre(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.FetchGenre(tt.args.name)
+			got, err := repository.FetchGenre(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchGenre() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -242,15 +244,13 @@ func TestRepository_RemoveGenre(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	const (
-		fakeExistName        = "action"
-		fakeDoesNotExistName = "fakeDoesNotExistName"
-	)
+	fakeExistGenre := testdata.FakeGenres[0]
+	fakeDoesNotExistID := uuid.New().String()
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		name string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -260,21 +260,21 @@ func TestRepository_RemoveGenre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When name is not found",
-			args:    args{fakeDoesNotExistName},
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID},
 			want:    sql.ErrNoRows,
 			wantErr: true,
 		},
 		{
-			name:    "When name is found",
-			args:    args{fakeExistName},
+			name:    "When id is found",
+			args:    args{fakeExistGenre.ID},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.RemoveGenre(tt.args.name)
+			err := repository.RemoveGenre(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveGenre() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -301,24 +301,26 @@ func TestRepository_UpdateGenre(t *testing.T) {
 		fakeNewDoestNotExistGenreName = "fakeNewDoestNotExistGenreName"
 		fakeExistCategoryName         = "fakeExistCategoryName"
 	)
+	fakeDoesNotExistGenreID := uuid.New().String()
 	fakeDoesNotExistCategoryDTO := crud.CategoryDTO{Name: fakeDoesNotExistCategoryName}
 	fakeExistGenreDTO := crud.GenreDTO{Name: fakeExistGenreName}
 	fakeNewExistGenreDTO := crud.GenreDTO{Name: fakeNewExistGenreName}
 	fakeExistCategoryDTO := crud.CategoryDTO{Name: fakeExistCategoryName}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	fakeExistGenreID, err := repository.AddGenre(fakeExistGenreDTO)
+	if err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
-	if err := repository.AddGenre(fakeNewExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeNewExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
 	type args struct {
-		name     string
+		id       string
 		genreDTO crud.GenreDTO
 	}
 	tests := []struct {
@@ -328,9 +330,9 @@ func TestRepository_UpdateGenre(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When name to update doesn't exist",
+			name: "When id to update doesn't exist",
 			args: args{
-				fakeDoesNotExistGenreName,
+				fakeDoesNotExistGenreID,
 				crud.GenreDTO{
 					Name:       fakeNewDoestNotExistGenreName,
 					Categories: []crud.CategoryDTO{fakeExistCategoryDTO},
@@ -342,7 +344,7 @@ func TestRepository_UpdateGenre(t *testing.T) {
 		{
 			name: "When name in GenreDTO already exists",
 			args: args{
-				fakeExistGenreName,
+				fakeExistGenreID.String(),
 				crud.GenreDTO{
 					Name:       fakeNewExistGenreName,
 					Categories: []crud.CategoryDTO{fakeExistCategoryDTO},
@@ -354,7 +356,7 @@ func TestRepository_UpdateGenre(t *testing.T) {
 		{
 			name: "When GenreDTO is with wrong genres",
 			args: args{
-				fakeExistGenreName,
+				fakeExistGenreID.String(),
 				crud.GenreDTO{
 					Name:       fakeDoesNotExistGenreName,
 					Categories: []crud.CategoryDTO{fakeDoesNotExistCategoryDTO},
@@ -366,7 +368,7 @@ func TestRepository_UpdateGenre(t *testing.T) {
 		{
 			name: "When everything is right",
 			args: args{
-				fakeExistGenreName,
+				fakeExistGenreID.String(),
 				crud.GenreDTO{
 					Name:       fakeNewDoestNotExistGenreName,
 					Categories: []crud.CategoryDTO{fakeExistCategoryDTO},
@@ -378,7 +380,7 @@ func TestRepository_UpdateGenre(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.UpdateGenre(tt.args.name, tt.args.genreDTO)
+			err := repository.UpdateGenre(tt.args.id, crud.AnyVersion, tt.args.genreDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateGenre() got: %v, wantErr %v", err, tt.wantErr)
 				return
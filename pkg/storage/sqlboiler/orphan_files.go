@@ -0,0 +1,103 @@
+package sqlboiler
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+)
+
+// OrphanReport lists the stored files that no video asset references
+type OrphanReport struct {
+	DryRun  bool
+	Scanned int
+	// Orphans were stored under the directory of a video
+	Orphans []files.StoredFile
+	// OrphanBlobs have no references left or were committed by a transaction that failed
+	OrphanBlobs []files.Blob
+	// Freed is the size of the orphans, the space a dry run would have released
+	Freed int64
+}
+
+// CollectOrphanFiles removes the stored files that no video asset references and that are older than minAge.
+// The files are listed before the references are loaded and minAge should outlast a store, so a file stored
+// for a transaction that is not committed yet is never taken for an orphan. A dry run only reports them.
+func (r Repository) CollectOrphanFiles(minAge time.Duration, dryRun bool) (OrphanReport, error) {
+	stored, err := r.repoFiles.ListFiles()
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	blobs, err := r.repoFiles.ListBlobs()
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	assets, err := models.VideoAssets(
+		Select(models.VideoAssetColumns.VideoID, models.VideoAssetColumns.FileName),
+	).AllG(r.ctx)
+	if err != nil {
+		return OrphanReport{}, fmt.Errorf("could not load the referenced files: %v", err)
+	}
+	blobRows, err := models.Blobs(Select(models.BlobColumns.ID, models.BlobColumns.RefCount)).AllG(r.ctx)
+	if err != nil {
+		return OrphanReport{}, fmt.Errorf("could not load the blobs: %v", err)
+	}
+	referenced := make(map[string]bool, len(assets))
+	for _, asset := range assets {
+		referenced[path.Join(asset.VideoID, asset.FileName)] = true
+	}
+	refCounts := make(map[string]int, len(blobRows))
+	for _, blob := range blobRows {
+		refCounts[blob.ID] = blob.RefCount
+	}
+	report := OrphanReport{DryRun: dryRun, Scanned: len(stored) + len(blobs)}
+	before := time.Now().Add(-minAge)
+	for _, f := range stored {
+		if referenced[path.Join(f.VideoID.String(), f.Name)] || f.ModTime.After(before) {
+			continue
+		}
+		if !dryRun {
+			if err := r.repoFiles.DeleteFileFromVideo(f.VideoID, f.Name); err != nil {
+				return report, fmt.Errorf("could not remove orphan file: %v", err)
+			}
+		}
+		report.Orphans = append(report.Orphans, f)
+		report.Freed += f.Size
+	}
+	for _, blob := range blobs {
+		refCount, ok := refCounts[blob.Hash]
+		if refCount > 0 || (!ok && blob.ModTime.After(before)) {
+			continue
+		}
+		if !dryRun {
+			// a blob committed by a failed transaction gets a row without references first, so it is released
+			// under the same lock as the others and an upload that links it meanwhile keeps it
+			if !ok {
+				_, err := boil.GetContextDB().ExecContext(
+					r.ctx,
+					`INSERT INTO blobs (id, size, ref_count, created_at, updated_at) VALUES ($1, $2, 0, now(), now())
+					ON CONFLICT (id) DO NOTHING`,
+					blob.Hash,
+					blob.Size,
+				)
+				if err != nil {
+					return report, fmt.Errorf("could not register orphan blob: %v", err)
+				}
+			}
+			released, err := r.releaseBlob(blob.Hash)
+			if err != nil {
+				return report, fmt.Errorf("could not release orphan blob: %v", err)
+			}
+			if !released {
+				continue
+			}
+		}
+		report.OrphanBlobs = append(report.OrphanBlobs, blob)
+		report.Freed += blob.Size
+	}
+	return report, nil
+}
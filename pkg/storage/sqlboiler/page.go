@@ -0,0 +1,113 @@
+package sqlboiler
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// pageMods orders the rows of table by the sort of filter, then by id, and selects the ones of page plus one
+// more to know whether the list goes on. The sort fields are the columns checked by the service.
+func pageMods(table string, filter crud.Filter, page crud.Page) ([]QueryMod, error) {
+	column := func(name string) string {
+		return fmt.Sprintf(`"%s"."%s"`, table, name)
+	}
+	backward := page.Cursor != nil && page.Cursor.Before
+	order := make([]string, 0, len(filter.Sort)+1)
+	for _, key := range filter.Sort {
+		dir := "ASC"
+		if key.Desc != backward {
+			dir = "DESC"
+		}
+		order = append(order, column(key.Field)+" "+dir)
+	}
+	idDir := "ASC"
+	if backward {
+		idDir = "DESC"
+	}
+	order = append(order, column("id")+" "+idDir)
+	mods := []QueryMod{OrderBy(strings.Join(order, ", ")), Limit(page.PerPage + 1)}
+	if page.Cursor == nil {
+		return append(mods, Offset((page.Number-1)*page.PerPage)), nil
+	}
+	if len(page.Cursor.Keys) != len(filter.Sort) {
+		return nil, fmt.Errorf("cursor %w", logger.ErrIsNotValidated)
+	}
+	// the rows after the cursor differ from it on a key, the previous ones being equal
+	var terms []string
+	var args []interface{}
+	var equal []string
+	var equalArgs []interface{}
+	for i, key := range filter.Sort {
+		op := ">"
+		if key.Desc != backward {
+			op = "<"
+		}
+		terms = append(terms, "("+strings.Join(append(equal, column(key.Field)+" "+op+" ?"), " AND ")+")")
+		args = append(append(args, equalArgs...), page.Cursor.Keys[i])
+		equal = append(equal, column(key.Field)+" = ?")
+		equalArgs = append(equalArgs, page.Cursor.Keys[i])
+	}
+	op := ">"
+	if backward {
+		op = "<"
+	}
+	terms = append(terms, "("+strings.Join(append(equal, column("id")+" "+op+" ?"), " AND ")+")")
+	args = append(append(args, equalArgs...), page.Cursor.ID)
+	return append(mods, Where("("+strings.Join(terms, " OR ")+")", args...)), nil
+}
+
+// pageOf puts the rows fetched with pageMods in the list order and locates their page, rows is the fetched
+// slice of models. Only the first n rows belong to the page.
+func pageOf(filter crud.Filter, page crud.Page, total int64, rows interface{}) (n int, info crud.PageInfo) {
+	slice := reflect.ValueOf(rows)
+	n = slice.Len()
+	more := n > page.PerPage
+	if more {
+		n = page.PerPage
+	}
+	// walking backwards, the page ends where the list was left and the extra row precedes it
+	hasNext, hasPrev := more, page.Cursor != nil || page.Number > 1
+	if page.Cursor != nil && page.Cursor.Before {
+		hasNext, hasPrev = true, more
+		swap := reflect.Swapper(rows)
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+	info = crud.PageInfo{Total: total, Number: page.Number, PerPage: page.PerPage}
+	if n == 0 {
+		return n, info
+	}
+	if hasNext {
+		info.Next = cursorOf(filter, slice.Index(n-1), false).String()
+	}
+	if hasPrev {
+		info.Prev = cursorOf(filter, slice.Index(0), true).String()
+	}
+	return n, info
+}
+
+// cursorOf reads the position of row, a pointer to a model, from its columns named by the sort keys
+func cursorOf(filter crud.Filter, row reflect.Value, before bool) crud.Cursor {
+	row = reflect.Indirect(row)
+	columns := make(map[string]reflect.Value, row.NumField())
+	for i := 0; i < row.NumField(); i++ {
+		columns[row.Type().Field(i).Tag.Get("boil")] = row.Field(i)
+	}
+	c := crud.Cursor{
+		Sort:   filter.SortString(),
+		Keys:   make([]interface{}, len(filter.Sort)),
+		ID:     columns["id"].String(),
+		Before: before,
+	}
+	for i, key := range filter.Sort {
+		c.Keys[i] = columns[key.Field].Interface()
+	}
+	return c
+}
@@ -16,6 +16,8 @@ import (
 type Repository struct {
 	ctx       context.Context
 	repoFiles files.Repository
+	// tx is the transaction InTx bound the repository to, the repository writes in the database itself without it
+	tx *boundTx
 }
 
 func NewRepository(ctx context.Context, db *sql.DB, repoFiles files.Repository) *Repository {
@@ -37,7 +39,7 @@ func NewRepository(ctx context.Context, db *sql.DB, repoFiles files.Repository)
 	models.AddVideoHook(boil.BeforeUpdateHook, isValidUUIDVideoHook)
 	models.AddVideoHook(boil.BeforeUpsertHook, isValidUUIDVideoHook)
 
-	return &Repository{ctx, repoFiles}
+	return &Repository{ctx: ctx, repoFiles: repoFiles}
 }
 
 func isValidUUIDCategoryHook(_ context.Context, _ boil.ContextExecutor, c *models.Category) error {
@@ -0,0 +1,104 @@
+package sqlboiler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// The queries of the models leave the removed rows out, the trash is read with plain queries on the tables.
+
+// trashed binds the page of the removed rows of table to rows, the last removed first, mods load their relations
+func (r Repository) trashed(table string, page crud.Page, rows interface{}, mods ...QueryMod) (crud.PageInfo, error) {
+	from := From(fmt.Sprintf(`"%s"`, table))
+	inTrash := Where(fmt.Sprintf(`"%s"."deleted_at" IS NOT NULL`, table))
+	var total int64
+	err := models.NewQuery(Select("count(*)"), from, inTrash).QueryRowContext(r.ctx, r.exec()).Scan(&total)
+	if err != nil {
+		return crud.PageInfo{}, fmt.Errorf("could not count the trash of %s: %v", table, err)
+	}
+	mods = append([]QueryMod{
+		Select(fmt.Sprintf(`"%s".*`, table)),
+		from,
+		inTrash,
+		OrderBy(fmt.Sprintf(`"%[1]s"."deleted_at" DESC, "%[1]s"."id"`, table)),
+		Limit(page.PerPage),
+		Offset((page.Number - 1) * page.PerPage),
+	}, mods...)
+	if err := models.NewQuery(mods...).Bind(r.ctx, r.exec(), rows); err != nil {
+		return crud.PageInfo{}, fmt.Errorf("could not load the trash of %s: %v", table, err)
+	}
+	return crud.PageInfo{Total: total, Number: page.Number, PerPage: page.PerPage}, nil
+}
+
+// remove moves the row of table with id at version to the trash
+func (r Repository) remove(table, id string, version int64) error {
+	exec := r.exec()
+	clause, args := versionClause(version, []interface{}{id, time.Now().In(boil.GetLocation())})
+	result, err := exec.ExecContext(
+		r.ctx,
+		fmt.Sprintf(
+			`UPDATE "%s" SET "deleted_at" = $2, "version" = "version" + 1 WHERE "id" = $1 AND "deleted_at" IS NULL%s`,
+			table,
+			clause,
+		),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return r.missed(exec, table, id, version, false)
+	}
+	return nil
+}
+
+// restore takes the row of table with id out of the trash, a row of a unique name taken meanwhile stays in it
+func (r Repository) restore(table, id string) error {
+	result, err := r.exec().ExecContext(
+		r.ctx,
+		fmt.Sprintf(`UPDATE "%s" SET "deleted_at" = NULL, "updated_at" = $2, "version" = "version" + 1 WHERE "id" = $1 AND "deleted_at" IS NOT NULL`, table),
+		id,
+		time.Now().In(boil.GetLocation()),
+	)
+	if err != nil {
+		var e *pq.Error
+		if errors.As(err, &e) && e.Code.Name() == "unique_violation" {
+			return fmt.Errorf("name of %s %w", id, logger.ErrAlreadyExists)
+		}
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// purge deletes the row of table with id at version for good, whether it is in the trash or not, with its
+// relations
+func (r Repository) purge(exec boil.ContextExecutor, table, id string, version int64) error {
+	clause, args := versionClause(version, []interface{}{id})
+	result, err := exec.ExecContext(r.ctx, fmt.Sprintf(`DELETE FROM "%s" WHERE "id" = $1%s`, table, clause), args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return r.missed(exec, table, id, version, true)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// +build integration
+
+package sqlboiler
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func TestRepository_Trash(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoriesDTO := testdata.FakeCategoriesDTO[:2]
+	categoryIDs := make([]string, len(fakeCategoriesDTO))
+	for i, categoryDTO := range fakeCategoriesDTO {
+		id, err := repository.AddCategory(categoryDTO)
+		if err != nil {
+			t.Fatalf("test: insert category: %s", err)
+		}
+		categoryIDs[i] = id.String()
+	}
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	videoID, err := repository.AddVideo(crud.VideoDTO{
+		Title:        "fake",
+		YearLaunched: fakeYearLaunched,
+		Rating:       fakeRating,
+		Duration:     fakeDuration,
+		Genres:       []crud.GenreDTO{fakeGenreDTO},
+		Categories:   fakeCategoriesDTO,
+	})
+	if err != nil {
+		t.Fatalf("test: add video: %v", err)
+	}
+	fakePage := crud.Page{Number: 1, PerPage: crud.DefaultPerPage}
+	t.Run("When a category is removed", func(t *testing.T) {
+		if err := repository.RemoveCategory(categoryIDs[0], crud.AnyVersion); err != nil {
+			t.Fatalf("RemoveCategory() error: %v", err)
+		}
+		if _, err := repository.FetchCategory(categoryIDs[0]); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("FetchCategory() error: %v, want: %v", err, sql.ErrNoRows)
+		}
+		trashed, info, err := repository.GetCategoriesInTrash(fakePage)
+		if err != nil {
+			t.Fatalf("GetCategoriesInTrash() error: %v", err)
+		}
+		if len(trashed) != 1 || info.Total != 1 || trashed[0].ID != categoryIDs[0] || !trashed[0].DeletedAt.Valid {
+			t.Errorf("GetCategoriesInTrash() got: %d of %d, want the removed category", len(trashed), info.Total)
+		}
+		video, err := repository.FetchVideo(videoID.String())
+		if err != nil {
+			t.Fatalf("FetchVideo() error: %v", err)
+		}
+		if len(video.R.Categories) != 1 || video.R.Categories[0].ID != categoryIDs[1] {
+			t.Errorf("FetchVideo() kept the categories in the trash: %v", video.R.Categories)
+		}
+	})
+	t.Run("When its name is taken meanwhile", func(t *testing.T) {
+		id, err := repository.AddCategory(fakeCategoriesDTO[0])
+		if err != nil {
+			t.Fatalf("AddCategory() error: %v", err)
+		}
+		if err := repository.RestoreCategory(categoryIDs[0]); !errors.Is(err, logger.ErrAlreadyExists) {
+			t.Errorf("RestoreCategory() error: %v, want: %v", err, logger.ErrAlreadyExists)
+		}
+		if err := repository.PurgeCategory(id.String(), crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeCategory() error: %v", err)
+		}
+	})
+	t.Run("When a category is restored", func(t *testing.T) {
+		if err := repository.RestoreCategory(categoryIDs[0]); err != nil {
+			t.Fatalf("RestoreCategory() error: %v", err)
+		}
+		if _, err := repository.FetchCategory(categoryIDs[0]); err != nil {
+			t.Errorf("FetchCategory() error: %v", err)
+		}
+		video, err := repository.FetchVideo(videoID.String())
+		if err != nil {
+			t.Fatalf("FetchVideo() error: %v", err)
+		}
+		if len(video.R.Categories) != 2 {
+			t.Errorf("FetchVideo() categories: %d, want: 2", len(video.R.Categories))
+		}
+		if err := repository.RestoreCategory(categoryIDs[0]); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("RestoreCategory() out of the trash error: %v, want: %v", err, sql.ErrNoRows)
+		}
+	})
+	t.Run("When a video in the trash is purged", func(t *testing.T) {
+		if err := repository.RemoveVideo(videoID.String(), crud.AnyVersion); err != nil {
+			t.Fatalf("RemoveVideo() error: %v", err)
+		}
+		trashed, _, err := repository.GetVideosInTrash(fakePage)
+		if err != nil {
+			t.Fatalf("GetVideosInTrash() error: %v", err)
+		}
+		if len(trashed) != 1 || trashed[0].ID != videoID.String() || len(trashed[0].R.Categories) != 2 {
+			t.Fatalf("GetVideosInTrash() got: %v, want the removed video with its relations", trashed)
+		}
+		if err := repository.PurgeVideo(videoID.String(), crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeVideo() error: %v", err)
+		}
+		if err := repository.RestoreVideo(videoID.String()); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("RestoreVideo() error: %v, want: %v", err, sql.ErrNoRows)
+		}
+	})
+	t.Run("When id is not found", func(t *testing.T) {
+		if err := repository.PurgeGenre(uuid.New().String(), crud.AnyVersion); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("PurgeGenre() error: %v, want: %v", err, sql.ErrNoRows)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package sqlboiler
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+// entityTables are the tables of the entities of the audit log
+var entityTables = map[crud.EntityType]string{
+	crud.CategoryEntity:   models.TableNames.Categories,
+	crud.GenreEntity:      models.TableNames.Genres,
+	crud.CastMemberEntity: models.TableNames.CastMembers,
+	crud.VideoEntity:      models.TableNames.Videos,
+}
+
+// boundTx is the transaction of a repository bound by InTx
+type boundTx struct {
+	*sql.Tx
+	// committed run once the transaction is committed
+	committed []func()
+}
+
+// transaction is the transaction of a write. The writes of a bound repository are made in its transaction,
+// which their commit and rollback leave to InTx.
+type transaction struct {
+	*sql.Tx
+	bound bool
+}
+
+func (t transaction) Commit() error {
+	if t.bound {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t transaction) Rollback() error {
+	if t.bound {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// exec runs the queries of r in its transaction, in the database when it is not bound to one
+func (r Repository) exec() boil.ContextExecutor {
+	if r.tx != nil {
+		return r.tx
+	}
+	return boil.GetContextDB()
+}
+
+// beginTx begins the transaction of a write, or joins the one r is bound to
+func (r Repository) beginTx() (transaction, error) {
+	if r.tx != nil {
+		return transaction{Tx: r.tx.Tx, bound: true}, nil
+	}
+	tx, err := boil.BeginTx(r.ctx, nil)
+	if err != nil {
+		return transaction{}, err
+	}
+	return transaction{Tx: tx}, nil
+}
+
+// afterCommit runs f once the transaction r is bound to is committed, right away when it is not bound to one
+func (r Repository) afterCommit(f func()) {
+	if r.tx != nil {
+		r.tx.committed = append(r.tx.committed, f)
+		return
+	}
+	f()
+}
+
+// InTx runs fn with the repository, as the catalogue and the audit log, bound to a transaction that is committed
+// when fn succeeds and rolled back otherwise. The row of the entity with id is locked first, so it cannot be
+// changed by another request while fn reads and changes it.
+func (r Repository) InTx(entity crud.EntityType, id string, fn func(r crud.Repository, log crud.AuditLog) error) error {
+	if r.tx != nil {
+		if err := r.lock(entity, id); err != nil {
+			return err
+		}
+		return fn(r, r)
+	}
+	tx, err := boil.BeginTx(r.ctx, nil)
+	if err != nil {
+		return err
+	}
+	bound := r
+	bound.tx = &boundTx{Tx: tx}
+	if err := bound.lock(entity, id); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
+	if err := fn(bound, bound); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, f := range bound.tx.committed {
+		f()
+	}
+	return nil
+}
+
+// lock locks the row of the entity with id, whether it is in the trash or not, until the transaction of r ends.
+// There is nothing to lock for an entity that is being added, it has no id yet.
+func (r Repository) lock(entity crud.EntityType, id string) error {
+	if id == "" || !isValidUUID(id) {
+		return nil
+	}
+	table, ok := entityTables[entity]
+	if !ok {
+		return entity.Validate()
+	}
+	if _, err := r.tx.ExecContext(r.ctx, fmt.Sprintf(`SELECT 1 FROM "%s" WHERE "id" = $1 FOR UPDATE`, table), id); err != nil {
+		return fmt.Errorf("could not lock %s %s: %w", entity, id, err)
+	}
+	return nil
+}
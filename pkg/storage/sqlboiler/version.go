@@ -0,0 +1,58 @@
+package sqlboiler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// versionClause is the condition of a write on the row at $1 made at version, appended as its argument to args
+func versionClause(version int64, args []interface{}) (string, []interface{}) {
+	if version == crud.AnyVersion {
+		return "", args
+	}
+	return fmt.Sprintf(` AND "version" = $%d`, len(args)+1), append(args, version)
+}
+
+// bump moves the version of the row of table with id on within exec, when the row is out of the trash and still
+// at version. The row stays locked by exec until it ends, so the write it goes with cannot be lost.
+func (r Repository) bump(exec boil.ContextExecutor, table, id string, version int64) (int64, error) {
+	clause, args := versionClause(version, []interface{}{id})
+	var bumped int64
+	err := exec.QueryRowContext(
+		r.ctx,
+		fmt.Sprintf(
+			`UPDATE "%s" SET "version" = "version" + 1 WHERE "id" = $1 AND "deleted_at" IS NULL%s RETURNING "version"`,
+			table,
+			clause,
+		),
+		args...,
+	).Scan(&bumped)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, r.missed(exec, table, id, version, false)
+	}
+	return bumped, err
+}
+
+// missed tells why a write made at version found no row of table with id: the row is stale when it is there at
+// another version, it is not found otherwise. inTrash counts the rows of the trash in.
+func (r Repository) missed(exec boil.ContextExecutor, table, id string, version int64, inTrash bool) error {
+	where := `"id" = $1`
+	if !inTrash {
+		where += ` AND "deleted_at" IS NULL`
+	}
+	var found bool
+	err := exec.QueryRowContext(r.ctx, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM "%s" WHERE %s)`, table, where), id).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("%s at version %d %w", id, version, logger.ErrIsStale)
+	}
+	return sql.ErrNoRows
+}
@@ -0,0 +1,93 @@
+// +build integration
+
+package sqlboiler
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+func TestRepository_Version(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeCastMemberDTO := crud.CastMemberDTO{Name: "fake", Type: crud.Actor}
+	id, err := repository.AddCastMember(fakeCastMemberDTO)
+	if err != nil {
+		t.Fatalf("test: add cast member: %v", err)
+	}
+	castMemberID := id.String()
+	version := func(t *testing.T) int64 {
+		castMember, err := repository.FetchCastMember(castMemberID)
+		if err != nil {
+			t.Fatalf("FetchCastMember() error: %v", err)
+		}
+		return castMember.Version
+	}
+	t.Run("When the cast member is added", func(t *testing.T) {
+		if got := version(t); got != 1 {
+			t.Errorf("version: %d, want: 1", got)
+		}
+	})
+	t.Run("When it is updated at its version", func(t *testing.T) {
+		if err := repository.UpdateCastMember(castMemberID, 1, fakeCastMemberDTO); err != nil {
+			t.Fatalf("UpdateCastMember() error: %v", err)
+		}
+		if got := version(t); got != 2 {
+			t.Errorf("version: %d, want: 2", got)
+		}
+	})
+	t.Run("When it is updated at an older version", func(t *testing.T) {
+		fakeCastMemberDTO.Type = crud.Director
+		if err := repository.UpdateCastMember(castMemberID, 1, fakeCastMemberDTO); !errors.Is(err, logger.ErrIsStale) {
+			t.Errorf("UpdateCastMember() error: %v, want: %v", err, logger.ErrIsStale)
+		}
+		castMember, err := repository.FetchCastMember(castMemberID)
+		if err != nil {
+			t.Fatalf("FetchCastMember() error: %v", err)
+		}
+		if castMember.Version != 2 || castMember.Type != int16(crud.Actor) {
+			t.Errorf("the stale update was written: version %d, type %d", castMember.Version, castMember.Type)
+		}
+	})
+	t.Run("When it is removed at an older version", func(t *testing.T) {
+		if err := repository.RemoveCastMember(castMemberID, 1); !errors.Is(err, logger.ErrIsStale) {
+			t.Errorf("RemoveCastMember() error: %v, want: %v", err, logger.ErrIsStale)
+		}
+	})
+	t.Run("When it is removed and restored", func(t *testing.T) {
+		if err := repository.RemoveCastMember(castMemberID, 2); err != nil {
+			t.Fatalf("RemoveCastMember() error: %v", err)
+		}
+		if err := repository.UpdateCastMember(castMemberID, 3, fakeCastMemberDTO); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("UpdateCastMember() in the trash error: %v, want: %v", err, sql.ErrNoRows)
+		}
+		if err := repository.RestoreCastMember(castMemberID); err != nil {
+			t.Fatalf("RestoreCastMember() error: %v", err)
+		}
+		if got := version(t); got != 4 {
+			t.Errorf("version: %d, want: 4", got)
+		}
+	})
+	t.Run("When it is purged at any version", func(t *testing.T) {
+		if err := repository.PurgeCastMember(castMemberID, 3); !errors.Is(err, logger.ErrIsStale) {
+			t.Errorf("PurgeCastMember() error: %v, want: %v", err, logger.ErrIsStale)
+		}
+		if err := repository.PurgeCastMember(castMemberID, crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeCastMember() error: %v", err)
+		}
+	})
+	t.Run("When id is not found", func(t *testing.T) {
+		if err := repository.UpdateCastMember(uuid.New().String(), 1, fakeCastMemberDTO); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("UpdateCastMember() error: %v, want: %v", err, sql.ErrNoRows)
+		}
+	})
+}
@@ -1,12 +1,11 @@
 package sqlboiler
 
 import (
-	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"path"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -17,17 +16,52 @@ import (
 	"github.com/selmison/code-micro-videos/models"
 	"github.com/selmison/code-micro-videos/pkg/crud"
 	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
 )
 
-func (r Repository) UpdateVideo(title string, videoDTO crud.VideoDTO) (uuid.UUID, error) {
-	video, err := r.FetchVideo(title)
+// castOrder lists the credits of a video the way they are billed
+const castOrder = `"cast_member_video"."billing_order", "cast_member_video"."role"`
+
+// creditedCastMember leaves out the credits of the cast members in the trash, the loads of the categories and
+// genres leave out theirs already
+const creditedCastMember = `EXISTS (SELECT 1 FROM "cast_members"
+	WHERE "cast_members"."id" = "cast_member_video"."cast_member_id" AND "cast_members"."deleted_at" IS NULL)`
+
+// videoLoads eager loads the relations of the videos
+func videoLoads() []QueryMod {
+	return []QueryMod{
+		Load(models.VideoRels.Categories),
+		Load(models.VideoRels.Genres),
+		Load(models.VideoRels.VideoAssets),
+		Load(models.VideoRels.CastMemberVideos, Where(creditedCastMember), OrderBy(castOrder)),
+		Load(Rels(models.VideoRels.CastMemberVideos, models.CastMemberVideoRels.CastMember)),
+	}
+}
+
+func (r Repository) UpdateVideo(id string, version int64, videoDTO crud.VideoDTO) (uuid.UUID, error) {
+	video, err := r.FetchVideo(id)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	videoID, err := uuid.Parse(video.ID)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("could not parse video.ID: %v", err)
+	}
+	assets, err := r.storeAssets(videoID, videoDTO.Files)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
-	tx, err := boil.BeginTx(r.ctx, nil)
+	defer r.discardAssets(assets)
+	tx, err := r.beginTx()
 	if err != nil {
 		return uuid.UUID{}, err
 	}
+	if video.Version, err = r.bump(tx, models.TableNames.Videos, id, version); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return uuid.UUID{}, err
+		}
+		return uuid.UUID{}, err
+	}
 	if err := r.setCategoriesInVideo(videoDTO.Categories, video, tx); err != nil {
 		if err := tx.Rollback(); err != nil {
 			return uuid.UUID{}, err
@@ -40,41 +74,35 @@ func (r Repository) UpdateVideo(title string, videoDTO crud.VideoDTO) (uuid.UUID
 		}
 		return uuid.UUID{}, err
 	}
+	if err := r.setCastInVideo(videoDTO.CastMembers, video, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return uuid.UUID{}, err
+		}
+		return uuid.UUID{}, err
+	}
 	video.Title = videoDTO.Title
 	video.Description = videoDTO.Description
 	video.YearLaunched = *videoDTO.YearLaunched
 	video.Opened = null.Bool{Bool: videoDTO.Opened, Valid: true}
 	video.Rating = int16(*videoDTO.Rating)
 	video.Duration = *videoDTO.Duration
-	var videoFile multipart.File
-	fileName := null.String{}
-	if videoDTO.VideoFileHandler == nil {
-		//TODO remove current video
-	} else if videoDTO.VideoFileHandler.Size > 0 {
-		hash := sha256.New()
-		var err error
-		if videoFile, err = videoDTO.VideoFileHandler.Open(); err != nil {
-			return uuid.UUID{}, fmt.Errorf("could not genarete hash videoFile: %w", err)
-		}
-		if _, err := io.Copy(hash, videoFile); err != nil {
-			return uuid.UUID{}, fmt.Errorf("could not genarete hash videoFile: %w", err)
-		}
-		hashName := fmt.Sprintf("%x", hash.Sum(nil))
-		fileName = null.String{String: hashName, Valid: true}
+	if videoDTO.Language != "" {
+		video.Language = videoDTO.Language
 	}
-	video.VideoFile = fileName
-	_, err = video.Update(r.ctx, tx, boil.Infer())
+	// search is generated by the database from the title and the description
+	_, err = video.Update(r.ctx, tx, boil.Blacklist(models.VideoColumns.Search))
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			return uuid.UUID{}, err
 		}
+		var e *pq.Error
+		if errors.As(err, &e) && e.Code.Name() == "undefined_object" {
+			return uuid.UUID{}, fmt.Errorf("language '%s' %w", videoDTO.Language, logger.ErrIsNotValidated)
+		}
 		return uuid.UUID{}, fmt.Errorf("%s %w", videoDTO.Title, logger.ErrAlreadyExists)
 	}
-	videoID, err := uuid.Parse(video.ID)
+	released, err := r.setAssetsInVideo(assets, tx)
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("could not parse video.ID: %v", err)
-	}
-	if _, err := r.repoFiles.UpdateFileToVideo(videoID, fileName.String, videoFile); err != nil {
 		if err := tx.Rollback(); err != nil {
 			return uuid.UUID{}, err
 		}
@@ -83,25 +111,17 @@ func (r Repository) UpdateVideo(title string, videoDTO crud.VideoDTO) (uuid.UUID
 	if err := tx.Commit(); err != nil {
 		return uuid.UUID{}, err
 	}
+	r.release(videoID, released)
 	return videoID, nil
 }
 
 func (r Repository) AddVideo(videoDTO crud.VideoDTO) (uuid.UUID, error) {
 	id := uuid.New()
-	var videoFile multipart.File
-	fileName := null.String{}
-	if videoDTO.VideoFileHandler != nil && videoDTO.VideoFileHandler.Size > 0 {
-		hash := sha256.New()
-		var err error
-		if videoFile, err = videoDTO.VideoFileHandler.Open(); err != nil {
-			return uuid.UUID{}, fmt.Errorf("could not genarete hash videoFile: %w", err)
-		}
-		if _, err := io.Copy(hash, videoFile); err != nil {
-			return uuid.UUID{}, fmt.Errorf("could not genarete hash videoFile: %w", err)
-		}
-		hashName := fmt.Sprintf("%x", hash.Sum(nil))
-		fileName = null.String{String: hashName, Valid: true}
+	assets, err := r.storeAssets(id, videoDTO.Files)
+	if err != nil {
+		return uuid.UUID{}, err
 	}
+	defer r.discardAssets(assets)
 	video := models.Video{
 		ID:           id.String(),
 		Title:        videoDTO.Title,
@@ -110,9 +130,9 @@ func (r Repository) AddVideo(videoDTO crud.VideoDTO) (uuid.UUID, error) {
 		Opened:       null.Bool{Bool: videoDTO.Opened, Valid: true},
 		Rating:       int16(*videoDTO.Rating),
 		Duration:     *videoDTO.Duration,
-		VideoFile:    fileName,
+		Language:     videoDTO.Language,
 	}
-	tx, err := boil.BeginTx(r.ctx, nil)
+	tx, err := r.beginTx()
 	if err != nil {
 		return uuid.UUID{}, err
 	}
@@ -123,12 +143,14 @@ func (r Repository) AddVideo(videoDTO crud.VideoDTO) (uuid.UUID, error) {
 			return uuid.UUID{}, err
 		}
 		if errors.As(err, &e) {
-			if e.Code.Name() == "unique_violation" {
+			switch e.Code.Name() {
+			case "unique_violation":
 				return uuid.UUID{}, fmt.Errorf("title '%s' %w", videoDTO.Title, logger.ErrAlreadyExists)
-			} else {
-				return uuid.UUID{}, fmt.Errorf("%s: %w", "method Repository.AddVideo(videoDTO)", err)
+			case "undefined_object":
+				return uuid.UUID{}, fmt.Errorf("language '%s' %w", videoDTO.Language, logger.ErrIsNotValidated)
 			}
 		}
+		return uuid.UUID{}, fmt.Errorf("%s: %w", "method Repository.AddVideo(videoDTO)", err)
 	}
 	if err := r.setCategoriesInVideo(videoDTO.Categories, video, tx); err != nil {
 		if err := tx.Rollback(); err != nil {
@@ -142,13 +164,17 @@ func (r Repository) AddVideo(videoDTO crud.VideoDTO) (uuid.UUID, error) {
 		}
 		return uuid.UUID{}, err
 	}
-	if videoDTO.VideoFileHandler != nil && videoDTO.VideoFileHandler.Size > 0 {
-		if err := r.repoFiles.SaveFileToVideo(id, fileName.String, videoFile); err != nil {
-			if err := tx.Rollback(); err != nil {
-				return uuid.UUID{}, err
-			}
-			return uuid.UUID{}, fmt.Errorf("could not save file to video: %v", err)
+	if err := r.setCastInVideo(videoDTO.CastMembers, video, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return uuid.UUID{}, err
 		}
+		return uuid.UUID{}, err
+	}
+	if _, err := r.setAssetsInVideo(assets, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return uuid.UUID{}, err
+		}
+		return uuid.UUID{}, err
 	}
 	if err := tx.Commit(); err != nil {
 		return uuid.UUID{}, err
@@ -156,7 +182,266 @@ func (r Repository) AddVideo(videoDTO crud.VideoDTO) (uuid.UUID, error) {
 	return id, nil
 }
 
-func (r Repository) setCategoriesInVideo(categories []crud.CategoryDTO, video models.Video, tx *sql.Tx) error {
+// stagedAsset is an asset whose file is staged in the files repository until the transaction that
+// references it commits the file to its content address
+type stagedAsset struct {
+	*models.VideoAsset
+	blob files.StagedBlob
+}
+
+// releasedFiles are the files a committed transaction stopped referencing
+type releasedFiles struct {
+	// videoFiles were stored under the video directory before the blobs were shared
+	videoFiles []string
+	// blobs lost their last reference
+	blobs []string
+}
+
+// storeAssets streams each file of source to the files repository, checking it against the spec of its kind.
+// The files are staged before the transaction of the repository begins, a failed request discards them. The
+// caller of a repository bound to its transaction stages them with StageAsset before that transaction begins.
+func (r Repository) storeAssets(videoID uuid.UUID, source crud.AssetSource) ([]stagedAsset, error) {
+	if source == nil {
+		return nil, nil
+	}
+	stored := make(map[crud.AssetKind]stagedAsset)
+	for {
+		kind, file, err := source.NextAsset()
+		if err == io.EOF {
+			break
+		}
+		var asset stagedAsset
+		if err == nil {
+			asset, err = r.storeAsset(videoID, kind, file)
+		}
+		if err != nil {
+			for _, asset := range stored {
+				r.discardAssets([]stagedAsset{asset})
+			}
+			return nil, err
+		}
+		if previous, ok := stored[kind]; ok {
+			r.discardAssets([]stagedAsset{previous})
+		}
+		stored[kind] = asset
+	}
+	assets := make([]stagedAsset, 0, len(stored))
+	for _, kind := range crud.AssetKinds() {
+		if asset, ok := stored[kind]; ok {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+// stagedFile is a file StageAsset staged ahead of the change that stores it, it reads as empty since its content
+// is in the files repository already
+type stagedFile struct {
+	blob        files.StagedBlob
+	contentType string
+}
+
+func (stagedFile) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+// StageAsset stages a file of kind, so that the transaction of the change that stores it does not stream it
+func (r Repository) StageAsset(kind crud.AssetKind, file io.Reader) (io.Reader, func(), error) {
+	staged, err := r.stageFile(kind, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return staged, func() { _ = r.repoFiles.DiscardBlob(staged.blob) }, nil
+}
+
+// stageFile streams a file of kind to the files repository, unless StageAsset did already. The service hands
+// over readers it already validated with its own specs and any other reader is checked against the default ones.
+func (r Repository) stageFile(kind crud.AssetKind, file io.Reader) (stagedFile, error) {
+	if staged, ok := file.(stagedFile); ok {
+		return staged, nil
+	}
+	assetReader, ok := file.(*crud.AssetReader)
+	if !ok {
+		var err error
+		if assetReader, err = crud.NewAssetReader(kind, file); err != nil {
+			return stagedFile{}, err
+		}
+	}
+	staged, err := r.repoFiles.StageBlob(assetReader)
+	if err != nil {
+		if err := assetReader.Err(); err != nil {
+			return stagedFile{}, err
+		}
+		return stagedFile{}, fmt.Errorf("could not save file to video: %w", err)
+	}
+	return stagedFile{blob: staged, contentType: assetReader.ContentType}, nil
+}
+
+// storeAsset stages a file of the video as its asset of kind
+func (r Repository) storeAsset(videoID uuid.UUID, kind crud.AssetKind, file io.Reader) (stagedAsset, error) {
+	staged, err := r.stageFile(kind, file)
+	if err != nil {
+		return stagedAsset{}, err
+	}
+	return stagedAsset{
+		VideoAsset: &models.VideoAsset{
+			ID:          uuid.New().String(),
+			VideoID:     videoID.String(),
+			Kind:        string(kind),
+			FileName:    path.Join(string(kind), staged.blob.Hash),
+			ContentType: staged.contentType,
+			Size:        staged.blob.Size,
+			BlobID:      null.StringFrom(staged.blob.Hash),
+		},
+		blob: staged.blob,
+	}, nil
+}
+
+// discardAssets removes the staged files, the files already committed to their blobs are left untouched
+func (r Repository) discardAssets(assets []stagedAsset) {
+	for _, asset := range assets {
+		_ = r.repoFiles.DiscardBlob(asset.blob)
+	}
+}
+
+// setAssetsInVideo replaces the assets of the video that have the same kind, linking each one to the blob of
+// its content and returning the files that are no longer referenced once the transaction is committed
+func (r Repository) setAssetsInVideo(assets []stagedAsset, tx boil.ContextExecutor) (releasedFiles, error) {
+	var released releasedFiles
+	for _, asset := range assets {
+		if err := r.linkBlob(asset.blob, tx); err != nil {
+			return releasedFiles{}, err
+		}
+		current, err := models.VideoAssets(
+			models.VideoAssetWhere.VideoID.EQ(asset.VideoID),
+			models.VideoAssetWhere.Kind.EQ(asset.Kind),
+		).One(r.ctx, tx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return releasedFiles{}, fmt.Errorf("could not get the %s of the video: %v", asset.Kind, err)
+		}
+		if current != nil {
+			if err := r.unlinkAsset(current, tx, &released); err != nil {
+				return releasedFiles{}, err
+			}
+		}
+		err = asset.Upsert(
+			r.ctx,
+			tx,
+			true,
+			[]string{models.VideoAssetColumns.VideoID, models.VideoAssetColumns.Kind},
+			boil.Whitelist(
+				models.VideoAssetColumns.FileName,
+				models.VideoAssetColumns.ContentType,
+				models.VideoAssetColumns.Size,
+				models.VideoAssetColumns.BlobID,
+				models.VideoAssetColumns.UpdatedAt,
+			),
+			boil.Infer(),
+		)
+		if err != nil {
+			return releasedFiles{}, fmt.Errorf("could not set the %s of the video: %v", asset.Kind, err)
+		}
+	}
+	return released, nil
+}
+
+// linkBlob adds a reference to the blob of a staged file and commits the file, which is not written again
+// when the blob is already stored. The upsert locks the row of the blob until the transaction ends, so the
+// blob cannot be released while its file is committed.
+func (r Repository) linkBlob(staged files.StagedBlob, tx boil.ContextExecutor) error {
+	_, err := tx.ExecContext(
+		r.ctx,
+		`INSERT INTO blobs (id, size, ref_count, created_at, updated_at) VALUES ($1, $2, 1, now(), now())
+		ON CONFLICT (id) DO UPDATE SET ref_count = blobs.ref_count + 1, updated_at = now()`,
+		staged.Hash,
+		staged.Size,
+	)
+	if err != nil {
+		return fmt.Errorf("could not link blob %s: %v", staged.Hash, err)
+	}
+	if _, err := r.repoFiles.CommitBlob(staged); err != nil {
+		return fmt.Errorf("could not commit blob %s: %v", staged.Hash, err)
+	}
+	return nil
+}
+
+// unlinkAsset drops the reference of an asset to its file, the files that lose their last reference are
+// added to released
+func (r Repository) unlinkAsset(asset *models.VideoAsset, tx boil.ContextExecutor, released *releasedFiles) error {
+	if !asset.BlobID.Valid {
+		released.videoFiles = append(released.videoFiles, asset.FileName)
+		return nil
+	}
+	var refCount int
+	err := tx.QueryRowContext(
+		r.ctx,
+		"UPDATE blobs SET ref_count = ref_count - 1, updated_at = now() WHERE id = $1 RETURNING ref_count",
+		asset.BlobID.String,
+	).Scan(&refCount)
+	if err != nil {
+		return fmt.Errorf("could not unlink blob %s: %w", asset.BlobID.String, err)
+	}
+	if refCount == 0 {
+		released.blobs = append(released.blobs, asset.BlobID.String)
+	}
+	return nil
+}
+
+// release deletes the files a committed transaction stopped referencing,
+// a file that could not be deleted is left for the garbage collector
+func (r Repository) release(videoID uuid.UUID, released releasedFiles) {
+	unbound := r
+	unbound.tx = nil
+	r.afterCommit(func() {
+		for _, fileName := range released.videoFiles {
+			_ = unbound.repoFiles.DeleteFileFromVideo(videoID, fileName)
+		}
+		for _, id := range released.blobs {
+			_, _ = unbound.releaseBlob(id)
+		}
+	})
+}
+
+// releaseBlob deletes a blob that has no references left and reports whether it was deleted. The row of the
+// blob stays locked while its file is deleted, so an upload of the same content waits to store it again.
+func (r Repository) releaseBlob(id string) (bool, error) {
+	tx, err := r.beginTx()
+	if err != nil {
+		return false, err
+	}
+	blob, err := models.Blobs(
+		models.BlobWhere.ID.EQ(id),
+		models.BlobWhere.RefCount.EQ(0),
+		For("UPDATE"),
+	).One(r.ctx, tx)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not lock blob %s: %w", id, err)
+	}
+	if err := r.repoFiles.DeleteBlob(id); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+		return false, err
+	}
+	if _, err := blob.Delete(r.ctx, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("could not delete blob %s: %w", id, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r Repository) setCategoriesInVideo(categories []crud.CategoryDTO, video models.Video, tx boil.ContextExecutor) error {
 	if categories == nil || len(categories) == 0 {
 		return fmt.Errorf("none category is %w", logger.ErrNotFound)
 	}
@@ -170,7 +455,7 @@ func (r Repository) setCategoriesInVideo(categories []crud.CategoryDTO, video mo
 	}
 	categorySlice, err := models.Categories(
 		Where(clause, categoryNames...),
-	).AllG(r.ctx)
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return err
 	}
@@ -183,7 +468,7 @@ func (r Repository) setCategoriesInVideo(categories []crud.CategoryDTO, video mo
 	return nil
 }
 
-func (r Repository) setGenresInVideo(genres []crud.GenreDTO, video models.Video, tx *sql.Tx) error {
+func (r Repository) setGenresInVideo(genres []crud.GenreDTO, video models.Video, tx boil.ContextExecutor) error {
 	if genres == nil || len(genres) == 0 {
 		return fmt.Errorf("none genre is %w", logger.ErrNotFound)
 	}
@@ -197,7 +482,7 @@ func (r Repository) setGenresInVideo(genres []crud.GenreDTO, video models.Video,
 	}
 	genreSlice, err := models.Genres(
 		Where(clause, genreNames...),
-	).AllG(r.ctx)
+	).All(r.ctx, r.exec())
 	if err != nil {
 		return err
 	}
@@ -210,36 +495,125 @@ func (r Repository) setGenresInVideo(genres []crud.GenreDTO, video models.Video,
 	return nil
 }
 
-func (r Repository) RemoveVideo(title string) error {
-	c, err := r.FetchVideo(title)
+// setCastInVideo replaces the credits of the video, each one naming a cast member that exists and numbered by the
+// service
+func (r Repository) setCastInVideo(cast []crud.VideoCastMemberDTO, video models.Video, tx boil.ContextExecutor) error {
+	_, err := models.CastMemberVideos(models.CastMemberVideoWhere.VideoID.EQ(video.ID)).DeleteAll(r.ctx, tx)
+	if err != nil {
+		return fmt.Errorf("could not remove the cast of the video: %v", err)
+	}
+	if len(cast) == 0 {
+		return nil
+	}
+	ids := make([]string, len(cast))
+	for i, credit := range cast {
+		ids[i] = credit.ID
+	}
+	castMembers, err := models.CastMembers(models.CastMemberWhere.ID.IN(ids)).All(r.ctx, tx)
 	if err != nil {
 		return err
 	}
-	_, err = c.DeleteG(r.ctx, false)
-	return err
+	found := make(map[string]bool, len(castMembers))
+	for _, castMember := range castMembers {
+		found[castMember.ID] = true
+	}
+	for _, credit := range cast {
+		if !found[credit.ID] {
+			return fmt.Errorf("cast member '%s' %w", credit.ID, logger.ErrNotFound)
+		}
+		castMemberVideo := models.CastMemberVideo{
+			CastMemberID:  credit.ID,
+			VideoID:       video.ID,
+			Role:          int16(credit.Role),
+			CharacterName: null.NewString(credit.Character, credit.Character != ""),
+			BillingOrder:  *credit.BillingOrder,
+		}
+		if err := castMemberVideo.Insert(r.ctx, tx, boil.Infer()); err != nil {
+			return fmt.Errorf("could not credit cast member '%s' in the video: %v", credit.ID, err)
+		}
+	}
+	return nil
 }
 
-func (r Repository) GetVideos(limit int) (models.VideoSlice, error) {
-	if limit <= 0 {
-		return nil, nil
+// RemoveVideo moves the video to the trash, its assets are kept until it is purged
+func (r Repository) RemoveVideo(id string, version int64) error {
+	return r.remove(models.TableNames.Videos, id, version)
+}
+
+func (r Repository) GetVideosInTrash(page crud.Page) (models.VideoSlice, crud.PageInfo, error) {
+	var videos models.VideoSlice
+	info, err := r.trashed(models.TableNames.Videos, page, &videos, videoLoads()...)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
 	}
-	videos, err := models.Videos(
-		Load(models.VideoRels.Categories),
-		Load(models.VideoRels.Genres),
-		Limit(limit),
-	).AllG(r.ctx)
+	return videos, info, nil
+}
+
+func (r Repository) RestoreVideo(id string) error {
+	return r.restore(models.TableNames.Videos, id)
+}
+
+// PurgeVideo deletes the video with its assets and releases the files they were the last to reference
+func (r Repository) PurgeVideo(id string, version int64) error {
+	videoID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("id '%s' %w: %v", id, logger.ErrIsNotValidated, err)
+	}
+	tx, err := r.beginTx()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	assets, err := models.VideoAssets(models.VideoAssetWhere.VideoID.EQ(id)).All(r.ctx, tx)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return fmt.Errorf("could not load the assets of the video: %w", err)
+	}
+	var released releasedFiles
+	for _, asset := range assets {
+		if err := r.unlinkAsset(asset, tx, &released); err != nil {
+			if err := tx.Rollback(); err != nil {
+				return err
+			}
+			return err
+		}
+	}
+	// the assets and the relations of the video are deleted with it
+	if err := r.purge(tx, models.TableNames.Videos, id, version); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
 	}
-	return videos, nil
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.release(videoID, released)
+	return nil
 }
 
-func (r Repository) FetchVideo(title string) (models.Video, error) {
-	videoSlice, err := models.Videos(
-		Load(models.VideoRels.Categories),
-		Load(models.VideoRels.Genres),
-		models.VideoWhere.Title.EQ(title),
-	).AllG(r.ctx)
+func (r Repository) GetVideos(filter crud.Filter, page crud.Page) (models.VideoSlice, crud.PageInfo, error) {
+	where := videoFilterMods(filter)
+	total, err := models.Videos(where...).Count(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	mods, err := pageMods(models.TableNames.Videos, filter, page)
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	mods = append(append(where, mods...), videoLoads()...)
+	videos, err := models.Videos(mods...).All(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	n, info := pageOf(filter, page, total, videos)
+	return videos[:n], info, nil
+}
+
+func (r Repository) FetchVideo(id string) (models.Video, error) {
+	videoSlice, err := models.Videos(append(videoLoads(), models.VideoWhere.ID.EQ(id))...).All(r.ctx, r.exec())
 	if err != nil {
 		return models.Video{}, err
 	}
@@ -248,3 +622,77 @@ func (r Repository) FetchVideo(title string) (models.Video, error) {
 	}
 	return *videoSlice[0], nil
 }
+
+func (r Repository) OpenVideoAsset(id string, kind crud.AssetKind) (crud.VideoFile, error) {
+	video, err := r.FetchVideo(id)
+	if err != nil {
+		return crud.VideoFile{}, err
+	}
+	var asset *models.VideoAsset
+	for _, a := range video.R.VideoAssets {
+		if a.Kind == string(kind) {
+			asset = a
+		}
+	}
+	if asset == nil {
+		return crud.VideoFile{}, fmt.Errorf("%s of video '%s' %w", kind, id, logger.ErrNotFound)
+	}
+	videoID, err := uuid.Parse(video.ID)
+	if err != nil {
+		return crud.VideoFile{}, fmt.Errorf("could not parse video.ID: %v", err)
+	}
+	var f files.File
+	if asset.BlobID.Valid {
+		f, err = r.repoFiles.OpenBlob(asset.BlobID.String)
+	} else {
+		f, err = r.repoFiles.OpenFileFromVideo(videoID, asset.FileName)
+	}
+	if err != nil {
+		return crud.VideoFile{}, err
+	}
+	return crud.VideoFile{
+		Name:        path.Base(asset.FileName),
+		ContentType: asset.ContentType,
+		ModTime:     asset.UpdatedAt.Time,
+		Content:     f,
+	}, nil
+}
+
+func (r Repository) AttachVideoAsset(id string, kind crud.AssetKind, file io.Reader) error {
+	video, err := r.FetchVideo(id)
+	if err != nil {
+		return err
+	}
+	videoID, err := uuid.Parse(video.ID)
+	if err != nil {
+		return fmt.Errorf("could not parse video.ID: %v", err)
+	}
+	asset, err := r.storeAsset(videoID, kind, file)
+	if err != nil {
+		return err
+	}
+	defer r.discardAssets([]stagedAsset{asset})
+	tx, err := r.beginTx()
+	if err != nil {
+		return err
+	}
+	// an asset changes the video as much as an update does
+	if _, err := r.bump(tx, models.TableNames.Videos, id, crud.AnyVersion); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
+	released, err := r.setAssetsInVideo([]stagedAsset{asset}, tx)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.release(videoID, released)
+	return nil
+}
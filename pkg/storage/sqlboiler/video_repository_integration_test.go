@@ -3,10 +3,13 @@
 package sqlboiler
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"reflect"
 	"strings"
 	"testing"
@@ -51,11 +54,11 @@ func TestRepository_AddVideo(t *testing.T) {
 	fakeDoesNotExistCategoryDTO := crud.CategoryDTO{Name: faker.FirstName(), Description: faker.Sentence()}
 	fakeExistGenreDTO := testdata.FakeGenresDTO[fakeGenreIndex]
 	fakeDoesNotExistGenreDTO := crud.GenreDTO{Name: faker.FirstName()}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
@@ -72,7 +75,7 @@ func TestRepository_AddVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When VideoFileHandler in VideoDTO is omitted",
+			name: "When VideoFile in VideoDTO is omitted",
 			args: args{
 				crud.VideoDTO{
 					Title:        fakeDoesNotExistTitle,
@@ -138,6 +141,364 @@ func TestRepository_AddVideo(t *testing.T) {
 	}
 }
 
+func TestRepository_AddVideo_Assets(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeVideoData := testdata.FakeMP4(64)
+	fakeThumbnailData := testdata.FakePNG(64)
+	newVideoDTO := func(files testdata.FakeAssets) crud.VideoDTO {
+		return crud.VideoDTO{
+			Title:        strings.ToLower(faker.Name()),
+			YearLaunched: fakeYearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Genres:       []crud.GenreDTO{fakeGenreDTO},
+			Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+			Files:        &files,
+		}
+	}
+	t.Run("When the assets match their kinds", func(t *testing.T) {
+		videoDTO := newVideoDTO(testdata.FakeAssets{
+			{Kind: crud.VideoAsset, Data: fakeVideoData},
+			{Kind: crud.ThumbnailAsset, Data: fakeThumbnailData},
+		})
+		id, err := repository.AddVideo(videoDTO)
+		if err != nil {
+			t.Fatalf("AddVideo() error: %v", err)
+		}
+		wants := map[crud.AssetKind][]byte{crud.VideoAsset: fakeVideoData, crud.ThumbnailAsset: fakeThumbnailData}
+		for kind, want := range wants {
+			got, err := readBlob(fmt.Sprintf("%x", sha256.Sum256(want)))
+			if err != nil {
+				t.Fatalf("test: could not get %s file: %v", kind, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("AddVideo() stored %s: %v, want: %v", kind, got, want)
+			}
+		}
+		video, err := repository.FetchVideo(id.String())
+		if err != nil {
+			t.Fatalf("test: fetch video: %v", err)
+		}
+		if len(video.R.VideoAssets) != len(wants) {
+			t.Errorf("AddVideo() got %d assets, want %d", len(video.R.VideoAssets), len(wants))
+		}
+	})
+	t.Run("When an asset does not match its kind", func(t *testing.T) {
+		videoDTO := newVideoDTO(testdata.FakeAssets{
+			{Kind: crud.ThumbnailAsset, Data: fakeVideoData},
+		})
+		if _, err := repository.AddVideo(videoDTO); !errors.Is(err, logger.ErrIsNotValidated) {
+			t.Errorf("AddVideo() error: %v, want: %v", err, logger.ErrIsNotValidated)
+		}
+	})
+}
+
+func TestRepository_VideoAssets_RemoveFiles(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeOldData := testdata.FakePNG(64)
+	fakeNewData := testdata.FakePNG(64)
+	fakeTitle := strings.ToLower(faker.Name())
+	id, err := repository.AddVideo(crud.VideoDTO{
+		Title:        fakeTitle,
+		YearLaunched: fakeYearLaunched,
+		Rating:       fakeRating,
+		Duration:     fakeDuration,
+		Genres:       []crud.GenreDTO{fakeGenreDTO},
+		Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+		Files:        &testdata.FakeAssets{{Kind: crud.ThumbnailAsset, Data: fakeOldData}},
+	})
+	if err != nil {
+		t.Fatalf("test: add video: %v", err)
+	}
+	fakeOldHash := fmt.Sprintf("%x", sha256.Sum256(fakeOldData))
+	fakeNewHash := fmt.Sprintf("%x", sha256.Sum256(fakeNewData))
+	t.Run("When the asset is replaced", func(t *testing.T) {
+		if err := repository.AttachVideoAsset(id.String(), crud.ThumbnailAsset, bytes.NewReader(fakeNewData)); err != nil {
+			t.Fatalf("AttachVideoAsset() error: %v", err)
+		}
+		if _, err := readBlob(fakeOldHash); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("AttachVideoAsset() left the replaced blob behind, error: %v", err)
+		}
+		if _, err := readBlob(fakeNewHash); err != nil {
+			t.Errorf("AttachVideoAsset() did not keep the new blob, error: %v", err)
+		}
+	})
+	countAssets := func() int64 {
+		count, err := models.VideoAssets(models.VideoAssetWhere.VideoID.EQ(id.String())).CountG(context.Background())
+		if err != nil {
+			t.Fatalf("test: count assets: %v", err)
+		}
+		return count
+	}
+	t.Run("When the video is removed", func(t *testing.T) {
+		if err := repository.RemoveVideo(id.String(), crud.AnyVersion); err != nil {
+			t.Fatalf("RemoveVideo() error: %v", err)
+		}
+		if _, err := readBlob(fakeNewHash); err != nil {
+			t.Errorf("RemoveVideo() did not keep the blob in the trash, error: %v", err)
+		}
+		if count := countAssets(); count != 1 {
+			t.Errorf("RemoveVideo() kept %d assets, want: 1", count)
+		}
+	})
+	t.Run("When the video is purged", func(t *testing.T) {
+		if err := repository.PurgeVideo(id.String(), crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeVideo() error: %v", err)
+		}
+		if _, err := readBlob(fakeNewHash); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("PurgeVideo() left the blob behind, error: %v", err)
+		}
+		if count := countAssets(); count != 0 {
+			t.Errorf("PurgeVideo() left %d assets behind", count)
+		}
+	})
+}
+
+func TestRepository_VideoAssets_SharedBlobs(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeData := testdata.FakeMP4(64)
+	fakeHash := fmt.Sprintf("%x", sha256.Sum256(fakeData))
+	fakeTitles := []string{strings.ToLower(faker.Name()), strings.ToLower(faker.Name())}
+	ids := make([]string, len(fakeTitles))
+	for i, title := range fakeTitles {
+		id, err := repository.AddVideo(crud.VideoDTO{
+			Title:        title,
+			YearLaunched: fakeYearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Genres:       []crud.GenreDTO{fakeGenreDTO},
+			Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+			Files:        &testdata.FakeAssets{{Kind: crud.VideoAsset, Data: fakeData}},
+		})
+		if err != nil {
+			t.Fatalf("test: add video: %v", err)
+		}
+		ids[i] = id.String()
+	}
+	refCount := func() int {
+		blob, err := models.FindBlobG(context.Background(), fakeHash)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0
+		}
+		if err != nil {
+			t.Fatalf("test: find blob: %v", err)
+		}
+		return blob.RefCount
+	}
+	t.Run("When the same content is stored for two videos", func(t *testing.T) {
+		if got := refCount(); got != len(fakeTitles) {
+			t.Errorf("AddVideo() ref count: %d, want: %d", got, len(fakeTitles))
+		}
+		blobs, err := cfg.RepoFiles.ListBlobs()
+		if err != nil {
+			t.Fatalf("test: list blobs: %v", err)
+		}
+		stored := 0
+		for _, blob := range blobs {
+			if blob.Hash == fakeHash {
+				stored++
+			}
+		}
+		if stored != 1 {
+			t.Errorf("AddVideo() stored the content %d times, want once", stored)
+		}
+	})
+	t.Run("When one of the videos is purged", func(t *testing.T) {
+		if err := repository.PurgeVideo(ids[0], crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeVideo() error: %v", err)
+		}
+		if got := refCount(); got != 1 {
+			t.Errorf("PurgeVideo() ref count: %d, want: 1", got)
+		}
+		got, err := readBlob(fakeHash)
+		if err != nil {
+			t.Fatalf("PurgeVideo() freed a blob still referenced, error: %v", err)
+		}
+		if !bytes.Equal(got, fakeData) {
+			t.Errorf("test: blob got: %v, want: %v", got, fakeData)
+		}
+	})
+	t.Run("When the last video is purged", func(t *testing.T) {
+		if err := repository.PurgeVideo(ids[1], crud.AnyVersion); err != nil {
+			t.Fatalf("PurgeVideo() error: %v", err)
+		}
+		if got := refCount(); got != 0 {
+			t.Errorf("PurgeVideo() ref count: %d, want: 0", got)
+		}
+		if _, err := readBlob(fakeHash); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("PurgeVideo() left the blob behind, error: %v", err)
+		}
+	})
+}
+
+func billingOrder(order int16) *int16 {
+	return &order
+}
+
+func readBlob(hash string) ([]byte, error) {
+	f, err := cfg.RepoFiles.OpenBlob(hash)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+func TestRepository_CollectOrphanFiles(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeReferencedData := testdata.FakeMP4(64)
+	id, err := repository.AddVideo(crud.VideoDTO{
+		Title:        strings.ToLower(faker.Name()),
+		YearLaunched: fakeYearLaunched,
+		Rating:       fakeRating,
+		Duration:     fakeDuration,
+		Genres:       []crud.GenreDTO{fakeGenreDTO},
+		Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+		Files:        &testdata.FakeAssets{{Kind: crud.VideoAsset, Data: fakeReferencedData}},
+	})
+	if err != nil {
+		t.Fatalf("test: add video: %v", err)
+	}
+	fakeReferencedHash := fmt.Sprintf("%x", sha256.Sum256(fakeReferencedData))
+	fakeOrphanVideoID := uuid.New()
+	fakeOrphanFileName, err := cfg.RepoFiles.StoreFileToVideo(fakeOrphanVideoID, string(crud.VideoAsset), bytes.NewReader(testdata.FakeMP4(64)))
+	if err != nil {
+		t.Fatalf("test: store orphan file: %v", err)
+	}
+	fakeOrphanBlob, err := cfg.RepoFiles.StageBlob(bytes.NewReader(testdata.FakeMP4(96)))
+	if err != nil {
+		t.Fatalf("test: stage orphan blob: %v", err)
+	}
+	if _, err := cfg.RepoFiles.CommitBlob(fakeOrphanBlob); err != nil {
+		t.Fatalf("test: commit orphan blob: %v", err)
+	}
+	isOrphan := func(report OrphanReport) bool {
+		blobFound := false
+		for _, blob := range report.OrphanBlobs {
+			if blob.Hash == fakeReferencedHash {
+				t.Errorf("CollectOrphanFiles() took the referenced blob for an orphan")
+			}
+			if blob.Hash == fakeOrphanBlob.Hash {
+				blobFound = true
+			}
+		}
+		if !blobFound {
+			return false
+		}
+		for _, f := range report.Orphans {
+			if f.VideoID == id {
+				t.Errorf("CollectOrphanFiles() took the referenced %s for an orphan", f.Name)
+			}
+			if f.VideoID == fakeOrphanVideoID && f.Name == fakeOrphanFileName {
+				return true
+			}
+		}
+		return false
+	}
+	t.Run("When the orphans are younger than the minimum age", func(t *testing.T) {
+		report, err := repository.CollectOrphanFiles(time.Hour, false)
+		if err != nil {
+			t.Fatalf("CollectOrphanFiles() error: %v", err)
+		}
+		if isOrphan(report) {
+			t.Errorf("CollectOrphanFiles() took a recent file for an orphan")
+		}
+	})
+	t.Run("When it is a dry run", func(t *testing.T) {
+		report, err := repository.CollectOrphanFiles(0, true)
+		if err != nil {
+			t.Fatalf("CollectOrphanFiles() error: %v", err)
+		}
+		if !isOrphan(report) || !report.DryRun || report.Freed == 0 {
+			t.Errorf("CollectOrphanFiles() report: %+v, want %s among the orphans", report, fakeOrphanFileName)
+		}
+		if exists, err := cfg.RepoFiles.Exists(fakeOrphanVideoID, fakeOrphanFileName); err != nil || !exists {
+			t.Errorf("CollectOrphanFiles() removed a file on a dry run, exists: %v, error: %v", exists, err)
+		}
+		if _, err := readBlob(fakeOrphanBlob.Hash); err != nil {
+			t.Errorf("CollectOrphanFiles() removed a blob on a dry run, error: %v", err)
+		}
+	})
+	t.Run("When the orphans are removed", func(t *testing.T) {
+		report, err := repository.CollectOrphanFiles(0, false)
+		if err != nil {
+			t.Fatalf("CollectOrphanFiles() error: %v", err)
+		}
+		if !isOrphan(report) {
+			t.Errorf("CollectOrphanFiles() report: %+v, want %s among the orphans", report, fakeOrphanFileName)
+		}
+		if exists, err := cfg.RepoFiles.Exists(fakeOrphanVideoID, fakeOrphanFileName); err != nil || exists {
+			t.Errorf("CollectOrphanFiles() left the orphan behind, exists: %v, error: %v", exists, err)
+		}
+		if _, err := readBlob(fakeOrphanBlob.Hash); !errors.Is(err, logger.ErrNotFound) {
+			t.Errorf("CollectOrphanFiles() left the orphan blob behind, error: %v", err)
+		}
+		if _, err := readBlob(fakeReferencedHash); err != nil {
+			t.Errorf("CollectOrphanFiles() removed a referenced blob, error: %v", err)
+		}
+	})
+}
+
 func TestRepository_GetVideos(t *testing.T) {
 	_, teardownTestCase, repository, err := setupTestCase(testdata.FakeVideos)
 	if err != nil {
@@ -148,12 +509,13 @@ func TestRepository_GetVideos(t *testing.T) {
 	maximum := testdata.FakeVideosLength
 	fakeVideosSlice := testdata.FakeVideoSlice
 	type args struct {
-		limit int
+		page crud.Page
 	}
 	type returns struct {
 		videos models.VideoSlice
 		e      error
 		amount int
+		next   bool
 	}
 	tests := []struct {
 		name    string
@@ -162,48 +524,180 @@ func TestRepository_GetVideos(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When limit is negative",
-			args:    args{-1},
-			want:    returns{nil, nil, 0},
-			wantErr: false,
-		},
-		{
-			name:    "When limit is zero",
-			args:    args{0},
-			want:    returns{nil, nil, 0},
+			name:    "When per_page is less then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum - 1}},
+			want:    returns{nil, nil, maximum - 1, true},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is less then the maximum",
-			args:    args{maximum - 1},
-			want:    returns{fakeVideosSlice[:maximum-1], nil, maximum - 1},
+			name:    "When per_page is equal the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum}},
+			want:    returns{fakeVideosSlice, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is equal the maximum",
-			args:    args{maximum},
-			want:    returns{fakeVideosSlice, nil, maximum},
+			name:    "When per_page is more then the maximum",
+			args:    args{crud.Page{Number: 1, PerPage: maximum + 1}},
+			want:    returns{nil, nil, maximum, false},
 			wantErr: false,
 		},
 		{
-			name:    "When limit is more then the maximum",
-			args:    args{maximum + 1},
-			want:    returns{fakeVideosSlice, nil, maximum},
+			name:    "When page is past the end",
+			args:    args{crud.Page{Number: 2, PerPage: maximum}},
+			want:    returns{nil, nil, 0, false},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.GetVideos(tt.args.limit)
+			got, info, err := repository.GetVideos(crud.Filter{Sort: crud.DefaultSort}, tt.args.page)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetVideos() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			gotRemovedTimes := removeUnwantedStuff(got)
-			videosRemovedTimes := removeUnwantedStuff(tt.want.videos)
-			assert.Equal(t, gotRemovedTimes, videosRemovedTimes, "they should be equal")
+			if len(got) != tt.want.amount {
+				t.Errorf("GetVideos() len(got): %v, want: %d", len(got), tt.want.amount)
+			}
+			if info.Total != int64(maximum) {
+				t.Errorf("GetVideos() total: %d, want: %d", info.Total, maximum)
+			}
+			if (info.Next != "") != tt.want.next {
+				t.Errorf("GetVideos() next: %q, want one: %v", info.Next, tt.want.next)
+			}
+			if tt.want.videos != nil {
+				gotRemovedTimes := removeUnwantedStuff(got)
+				videosRemovedTimes := removeUnwantedStuff(tt.want.videos)
+				assert.ElementsMatch(t, gotRemovedTimes, videosRemovedTimes, "they should have the same videos")
+			}
+		})
+	}
+}
+
+func TestRepository_GetVideos_Filter(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(testdata.FakeVideos)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	fakeVideo := testdata.FakeVideos[0]
+	fakeRating := crud.VideoRating(fakeVideo.Rating)
+	fakeYear := int(fakeVideo.YearLaunched)
+	fakeOpened := true
+	fakeHasFile := true
+	fakeCategory := fakeVideo.R.Categories[0]
+	fakeTitle := crud.NormalizeName(fakeVideo.Title)
+	fakePrefix := fakeTitle[:len(fakeTitle)-1]
+	tests := []struct {
+		name   string
+		filter crud.Filter
+		want   func(video models.Video) bool
+	}{
+		{
+			name:   "When name is given",
+			filter: crud.Filter{Name: fakeTitle},
+			want: func(video models.Video) bool {
+				return crud.NormalizeName(video.Title) == fakeTitle
+			},
+		},
+		{
+			name:   "When name is a prefix of the title",
+			filter: crud.Filter{Name: fakePrefix},
+			want: func(video models.Video) bool {
+				return crud.NormalizeName(video.Title) == fakePrefix
+			},
+		},
+		{
+			name:   "When name_prefix is given",
+			filter: crud.Filter{NamePrefix: fakePrefix},
+			want: func(video models.Video) bool {
+				return strings.HasPrefix(strings.ToLower(video.Title), fakePrefix)
+			},
+		},
+		{
+			name:   "When rating is given",
+			filter: crud.Filter{Rating: &fakeRating},
+			want: func(video models.Video) bool {
+				return video.Rating == int16(fakeRating)
+			},
+		},
+		{
+			name:   "When max_rating is given",
+			filter: crud.Filter{MaxRating: &fakeRating},
+			want: func(video models.Video) bool {
+				return video.Rating <= int16(fakeRating)
+			},
+		},
+		{
+			name:   "When year_launched is bounded",
+			filter: crud.Filter{YearLaunched: crud.Range{Min: &fakeYear}},
+			want: func(video models.Video) bool {
+				return int(video.YearLaunched) >= fakeYear
+			},
+		},
+		{
+			name:   "When opened is given",
+			filter: crud.Filter{Opened: &fakeOpened},
+			want: func(video models.Video) bool {
+				return video.Opened.Bool
+			},
+		},
+		{
+			name:   "When has_file is given",
+			filter: crud.Filter{HasFile: &fakeHasFile},
+			want: func(video models.Video) bool {
+				return false
+			},
+		},
+		{
+			name:   "When category is given",
+			filter: crud.Filter{Category: fakeCategory.Name},
+			want: func(video models.Video) bool {
+				for _, c := range video.R.Categories {
+					if c.Name == fakeCategory.Name && !c.DeletedAt.Valid {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Sort = crud.DefaultSort
+			got, info, err := repository.GetVideos(tt.filter, crud.Page{Number: 1, PerPage: crud.MaxPerPage})
+			if err != nil {
+				t.Fatalf("GetVideos() error: %v", err)
+			}
+			var want []string
+			for _, video := range testdata.FakeVideos {
+				if tt.want(video) {
+					want = append(want, video.ID)
+				}
+			}
+			gotIDs := make([]string, len(got))
+			for i, video := range got {
+				gotIDs[i] = video.ID
+			}
+			assert.ElementsMatch(t, want, gotIDs, "they should have the same videos")
+			if info.Total != int64(len(want)) {
+				t.Errorf("GetVideos() total: %d, want: %d", info.Total, len(want))
+			}
 		})
 	}
+	t.Run("When the list is sorted by several keys", func(t *testing.T) {
+		filter := crud.Filter{Sort: []crud.SortKey{{Field: "year_launched", Desc: true}, {Field: "title"}}}
+		got, _, err := repository.GetVideos(filter, crud.Page{Number: 1, PerPage: crud.MaxPerPage})
+		if err != nil {
+			t.Fatalf("GetVideos() error: %v", err)
+		}
+		for i := 1; i < len(got); i++ {
+			prev, video := got[i-1], got[i]
+			if prev.YearLaunched < video.YearLaunched ||
+				(prev.YearLaunched == video.YearLaunched && prev.Title > video.Title) {
+				t.Errorf("GetVideos() sorted %s before %s", prev.Title, video.Title)
+			}
+		}
+	})
 }
 
 func TestRepository_FetchVideo(t *testing.T) {
@@ -214,11 +708,9 @@ func TestRepository_FetchVideo(t *testing.T) {
 	}
 	defer teardownTestCase(t)
 	fakeExistVideo := testdata.FakeVideos[0]
-	const (
-		fakeDoesNotExistTitle = "fakeDoesNotExistTitle"
-	)
+	fakeDoesNotExistID := uuid.New().String()
 	type args struct {
-		title string
+		id string
 	}
 	type returns struct {
 		video models.Video
@@ -231,8 +723,8 @@ func TestRepository_FetchVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title is not found",
-			args: args{fakeDoesNotExistTitle},
+			name: "When id is not found",
+			args: args{fakeDoesNotExistID},
 			want: returns{
 				models.Video{},
 				sql.ErrNoRows,
@@ -240,8 +732,8 @@ func TestRepository_FetchVideo(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "When title is found",
-			args: args{fakeExistVideo.Title},
+			name: "When id is found",
+			args: args{fakeExistVideo.ID},
 			want: returns{
 				fakeExistVideo,
 				nil,
@@ -252,7 +744,7 @@ func TestRepository_FetchVideo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repository.FetchVideo(tt.args.title)
+			got, err := repository.FetchVideo(tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchVideo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -271,15 +763,13 @@ func TestRepository_RemoveVideo(t *testing.T) {
 		return
 	}
 	defer teardownTestCase(t)
-	fakeExistTitle := testdata.FakeVideos[0].Title
-	const (
-		fakeDoesNotExistTitle = "fakeDoesNotExistTitle"
-	)
+	fakeExistID := testdata.FakeVideos[0].ID
+	fakeDoesNotExistID := uuid.New().String()
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		title string
+		id string
 	}
 	tests := []struct {
 		name    string
@@ -289,21 +779,21 @@ func TestRepository_RemoveVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name:    "When title is not found",
-			args:    args{fakeDoesNotExistTitle},
+			name:    "When id is not found",
+			args:    args{fakeDoesNotExistID},
 			want:    sql.ErrNoRows,
 			wantErr: true,
 		},
 		{
-			name:    "When title is found",
-			args:    args{fakeExistTitle},
+			name:    "When id is found",
+			args:    args{fakeExistID},
 			want:    nil,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repository.RemoveVideo(tt.args.title)
+			err := repository.RemoveVideo(tt.args.id, crud.AnyVersion)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RemoveVideo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -348,23 +838,24 @@ func TestRepository_UpdateVideo(t *testing.T) {
 		Genres:       []crud.GenreDTO{fakeExistGenreDTO},
 		Categories:   []crud.CategoryDTO{fakeExistCategoryDTO},
 	}
-	if err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
+	if _, err := repository.AddCategory(fakeExistCategoryDTO); err != nil {
 		t.Errorf("test: insert category: %s", err)
 		return
 	}
-	if err := repository.AddGenre(fakeExistGenreDTO); err != nil {
+	if _, err := repository.AddGenre(fakeExistGenreDTO); err != nil {
 		t.Errorf("test: insert genre: %s", err)
 		return
 	}
-	if _, err := repository.AddVideo(fakeExistVideoDTO); err != nil {
-		t.Errorf("test: insert category: %s", err)
+	fakeExistID, err := repository.AddVideo(fakeExistVideoDTO)
+	if err != nil {
+		t.Errorf("test: insert video: %s", err)
 		return
 	}
 	type fields struct {
 		ctx context.Context
 	}
 	type args struct {
-		title    string
+		id       string
 		videoDTO crud.VideoDTO
 	}
 	tests := []struct {
@@ -375,9 +866,9 @@ func TestRepository_UpdateVideo(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "When title to update doesn't exist",
+			name: "When id to update doesn't exist",
 			args: args{
-				fakeDoesNotExistTitle,
+				uuid.New().String(),
 				crud.VideoDTO{
 					Title: fakeNewDoestNotExistTitle,
 				},
@@ -388,7 +879,7 @@ func TestRepository_UpdateVideo(t *testing.T) {
 		{
 			name: "When VideoDTO is with wrong genres",
 			args: args{
-				fakeExistTitle,
+				fakeExistID.String(),
 				crud.VideoDTO{
 					Title:        fakeDoesNotExistTitle,
 					Description:  fakeDesc,
@@ -406,7 +897,7 @@ func TestRepository_UpdateVideo(t *testing.T) {
 		{
 			name: "When everything is right",
 			args: args{
-				fakeExistTitle,
+				fakeExistID.String(),
 				crud.VideoDTO{
 					Title:        fakeDoesNotExistTitle,
 					Description:  fakeDesc,
@@ -424,7 +915,7 @@ func TestRepository_UpdateVideo(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := repository.UpdateVideo(tt.args.title, tt.args.videoDTO)
+			_, err := repository.UpdateVideo(tt.args.id, crud.AnyVersion, tt.args.videoDTO)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateVideo() got: %v, wantErr %v", err, tt.wantErr)
 				return
@@ -500,15 +991,10 @@ func removeUnwantedStuff(i interface{}) interface{} {
 	}
 	funcRemoveTimes := func(i interface{}) {
 		value := reflect.ValueOf(i)
-		for _, fieldName := range [3]string{"CreatedAt", "DeletedAt", "UpdatedAt"} {
+		for _, fieldName := range [4]string{"CreatedAt", "DeletedAt", "UpdatedAt", "Search"} {
 			field := reflect.Indirect(value).FieldByName(fieldName)
 			if field.IsValid() && !field.IsZero() {
-				field.Set(
-					reflect.ValueOf(null.Time{
-						Time:  time.Time{},
-						Valid: false,
-					}),
-				)
+				field.Set(reflect.Zero(field.Type()))
 			}
 		}
 	}
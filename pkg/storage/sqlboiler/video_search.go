@@ -0,0 +1,114 @@
+package sqlboiler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/selmison/code-micro-videos/models"
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+// the words of the title and the description that match a search are marked, the description is cut to the
+// fragments around them. The text is HTML escaped before it is marked, the marks are the only markup of a headline.
+const (
+	titleHeadlineOptions       = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+)
+
+// htmlEscaped is the SQL expression of column with its HTML special characters escaped as html.EscapeString does
+func htmlEscaped(column string) string {
+	return fmt.Sprintf(
+		`replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`,
+		column,
+	)
+}
+
+type videoMatch struct {
+	ID          string  `boil:"id"`
+	Rank        float32 `boil:"rank"`
+	Title       string  `boil:"title"`
+	Description string  `boil:"description"`
+}
+
+func (r Repository) SearchVideos(search crud.VideoSearch, page crud.Page) ([]crud.VideoMatch, crud.PageInfo, error) {
+	var total struct {
+		Count int64 `boil:"count"`
+	}
+	err := queries.Raw(
+		`SELECT count(*) AS count FROM "videos", websearch_to_tsquery($1::regconfig, $2) query
+		WHERE "videos"."search" @@ query AND "videos"."deleted_at" IS NULL`,
+		search.Language,
+		search.Query,
+	).Bind(r.ctx, r.exec(), &total)
+	if err != nil {
+		return nil, crud.PageInfo{}, searchError(search, err)
+	}
+	var matches []videoMatch
+	err = queries.Raw(
+		fmt.Sprintf(
+			`SELECT "videos"."id",
+				ts_rank("videos"."search", query) AS rank,
+				ts_headline($1::regconfig, %s, query, $3) AS title,
+				ts_headline($1::regconfig, %s, query, $4) AS description
+			FROM "videos", websearch_to_tsquery($1::regconfig, $2) query
+			WHERE "videos"."search" @@ query AND "videos"."deleted_at" IS NULL
+			ORDER BY rank DESC, "videos"."id"
+			LIMIT $5 OFFSET $6`,
+			htmlEscaped(`"videos"."title"`),
+			htmlEscaped(`"videos"."description"`),
+		),
+		search.Language,
+		search.Query,
+		titleHeadlineOptions,
+		descriptionHeadlineOptions,
+		page.PerPage,
+		(page.Number-1)*page.PerPage,
+	).Bind(r.ctx, r.exec(), &matches)
+	if err != nil {
+		return nil, crud.PageInfo{}, searchError(search, err)
+	}
+	info := crud.PageInfo{Total: total.Count, Number: page.Number, PerPage: page.PerPage}
+	if len(matches) == 0 {
+		return nil, info, nil
+	}
+	ids := make([]interface{}, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+	videos, err := models.Videos(append(videoLoads(), WhereIn(`"videos"."id" IN ?`, ids...))...).All(r.ctx, r.exec())
+	if err != nil {
+		return nil, crud.PageInfo{}, err
+	}
+	byID := make(map[string]*models.Video, len(videos))
+	for _, video := range videos {
+		byID[video.ID] = video
+	}
+	found := make([]crud.VideoMatch, 0, len(matches))
+	for _, match := range matches {
+		// a video removed since it matched is left out
+		video, ok := byID[match.ID]
+		if !ok {
+			continue
+		}
+		found = append(found, crud.VideoMatch{
+			Video:       video,
+			Rank:        match.Rank,
+			Title:       match.Title,
+			Description: match.Description,
+		})
+	}
+	return found, info, nil
+}
+
+func searchError(search crud.VideoSearch, err error) error {
+	var e *pq.Error
+	if errors.As(err, &e) && e.Code.Name() == "undefined_object" {
+		return fmt.Errorf("language '%s' %w", search.Language, logger.ErrIsNotValidated)
+	}
+	return fmt.Errorf("could not search the videos: %w", err)
+}
@@ -0,0 +1,114 @@
+// +build integration
+
+package sqlboiler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/testdata"
+)
+
+func TestRepository_SearchVideos(t *testing.T) {
+	_, teardownTestCase, repository, err := setupTestCase(nil)
+	if err != nil {
+		t.Fatalf("test: failed to setup test case: %v\n", err)
+	}
+	defer teardownTestCase(t)
+	*fakeYearLaunched = 2020
+	*fakeDuration = 90
+	*fakeRating = crud.TwelveRating
+	fakeCategoryDTO := testdata.FakeCategoriesDTO[0]
+	fakeGenreDTO := testdata.FakeGenresDTO[0]
+	if _, err := repository.AddCategory(fakeCategoryDTO); err != nil {
+		t.Fatalf("test: insert category: %s", err)
+	}
+	if _, err := repository.AddGenre(fakeGenreDTO); err != nil {
+		t.Fatalf("test: insert genre: %s", err)
+	}
+	fakeVideos := []struct{ title, description string }{
+		{"walking with volcanoes", "a journey to the craters of the pacific"},
+		{"the deep ocean", "the creatures living under the pacific, far from any volcano"},
+		{"city lights", "a night in the streets of tokyo"},
+		{"<script>alert('forest')</script>", "a walk in the forest & its <b>trees</b>"},
+	}
+	for _, video := range fakeVideos {
+		_, err := repository.AddVideo(crud.VideoDTO{
+			Title:        video.title,
+			Description:  video.description,
+			YearLaunched: fakeYearLaunched,
+			Rating:       fakeRating,
+			Duration:     fakeDuration,
+			Language:     crud.DefaultSearchLanguage,
+			Genres:       []crud.GenreDTO{fakeGenreDTO},
+			Categories:   []crud.CategoryDTO{fakeCategoryDTO},
+		})
+		if err != nil {
+			t.Fatalf("test: add video: %v", err)
+		}
+	}
+	fakePage := crud.Page{Number: 1, PerPage: crud.DefaultPerPage}
+	t.Run("When a title matches above a description", func(t *testing.T) {
+		got, info, err := repository.SearchVideos(crud.VideoSearch{Query: "volcano", Language: "english"}, fakePage)
+		if err != nil {
+			t.Fatalf("SearchVideos() error: %v", err)
+		}
+		if len(got) != 2 || info.Total != 2 {
+			t.Fatalf("SearchVideos() found: %d of %d, want: 2", len(got), info.Total)
+		}
+		if got[0].Video.Title != fakeVideos[0].title || got[1].Video.Title != fakeVideos[1].title {
+			t.Errorf("SearchVideos() ranked %s above %s", got[0].Video.Title, got[1].Video.Title)
+		}
+		if got[0].Rank <= got[1].Rank {
+			t.Errorf("SearchVideos() ranks: %v, %v, want decreasing", got[0].Rank, got[1].Rank)
+		}
+		if !strings.Contains(got[0].Title, "<mark>volcanoes</mark>") {
+			t.Errorf("SearchVideos() title headline: %s", got[0].Title)
+		}
+		if !strings.Contains(got[1].Description, "<mark>volcano</mark>") {
+			t.Errorf("SearchVideos() description headline: %s", got[1].Description)
+		}
+	})
+	t.Run("When a word is excluded", func(t *testing.T) {
+		got, _, err := repository.SearchVideos(crud.VideoSearch{Query: "pacific -volcano", Language: "english"}, fakePage)
+		if err != nil {
+			t.Fatalf("SearchVideos() error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("SearchVideos() found: %d, want: 0", len(got))
+		}
+	})
+	t.Run("When nothing matches", func(t *testing.T) {
+		got, info, err := repository.SearchVideos(crud.VideoSearch{Query: "desert", Language: "english"}, fakePage)
+		if err != nil {
+			t.Fatalf("SearchVideos() error: %v", err)
+		}
+		if len(got) != 0 || info.Total != 0 {
+			t.Errorf("SearchVideos() found: %d of %d, want: 0", len(got), info.Total)
+		}
+	})
+	t.Run("When the text is markup", func(t *testing.T) {
+		got, _, err := repository.SearchVideos(crud.VideoSearch{Query: "forest", Language: "english"}, fakePage)
+		if err != nil {
+			t.Fatalf("SearchVideos() error: %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("SearchVideos() found: %d, want: 1", len(got))
+		}
+		if want := "&lt;script&gt;alert(&#39;<mark>forest</mark>&#39;)&lt;/script&gt;"; got[0].Title != want {
+			t.Errorf("SearchVideos() title headline: %s, want: %s", got[0].Title, want)
+		}
+		if strings.Contains(got[0].Description, "<b>") || !strings.Contains(got[0].Description, "<mark>forest</mark>") {
+			t.Errorf("SearchVideos() description headline: %s", got[0].Description)
+		}
+	})
+	t.Run("When the language is unknown", func(t *testing.T) {
+		_, _, err := repository.SearchVideos(crud.VideoSearch{Query: "volcano", Language: "klingon"}, fakePage)
+		if !errors.Is(err, logger.ErrIsNotValidated) {
+			t.Errorf("SearchVideos() error: %v, want: %v", err, logger.ErrIsNotValidated)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package uploads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+	"github.com/selmison/code-micro-videos/pkg/storage/files"
+)
+
+const (
+	infoExt = ".info"
+	dataExt = ".bin"
+)
+
+var (
+	ErrOffsetMismatch = errors.New("offset does not match the upload offset")
+	ErrExpired        = errors.New("upload has expired")
+	ErrExceedsLength  = errors.New("chunk exceeds the upload length")
+)
+
+// Info describes an upload in progress
+type Info struct {
+	ID        string            `json:"id"`
+	Length    int64             `json:"length"`
+	Offset    int64             `json:"offset"`
+	Metadata  map[string]string `json:"metadata"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// IsComplete reports whether every byte of the upload has been received
+func (i Info) IsComplete() bool {
+	return i.Offset == i.Length
+}
+
+type Store interface {
+	Create(length int64, metadata map[string]string) (Info, error)
+	GetInfo(id string) (Info, error)
+	WriteChunk(id string, offset int64, src io.Reader) (Info, error)
+	Open(id string) (files.File, error)
+	Remove(id string) error
+	RemoveExpired() ([]string, error)
+}
+
+type store struct {
+	Afs   *afero.Afero
+	ttl   time.Duration
+	now   func() time.Time
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewStore creates an upload store on fs, uploads not touched for ttl are considered expired
+func NewStore(fs afero.Fs, ttl time.Duration) *store {
+	return &store{
+		Afs:   &afero.Afero{Fs: fs},
+		ttl:   ttl,
+		now:   time.Now,
+		locks: make(map[string]*sync.Mutex),
+	}
+}
+
+func (s *store) Create(length int64, metadata map[string]string) (Info, error) {
+	if length < 0 {
+		return Info{}, fmt.Errorf("upload length %w", logger.ErrIsNotValidated)
+	}
+	info := Info{
+		ID:        uuid.New().String(),
+		Length:    length,
+		Metadata:  metadata,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+	if err := s.Afs.WriteFile(info.ID+dataExt, nil, 0644); err != nil {
+		return Info{}, fmt.Errorf("could not create upload: %v", err)
+	}
+	if err := s.writeInfo(info); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+func (s *store) GetInfo(id string) (Info, error) {
+	info, err := s.readInfo(id)
+	if err != nil {
+		return Info{}, err
+	}
+	if s.isExpired(info) {
+		return info, fmt.Errorf("%s: %w", id, ErrExpired)
+	}
+	return info, nil
+}
+
+func (s *store) WriteChunk(id string, offset int64, src io.Reader) (Info, error) {
+	lock := s.lock(id)
+	lock.Lock()
+	defer lock.Unlock()
+	info, err := s.GetInfo(id)
+	if err != nil {
+		return Info{}, err
+	}
+	if offset != info.Offset {
+		return info, fmt.Errorf("%d: %w", offset, ErrOffsetMismatch)
+	}
+	f, err := s.Afs.OpenFile(id+dataExt, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return info, fmt.Errorf("could not open upload: %v", err)
+	}
+	remaining := info.Length - info.Offset
+	n, copyErr := io.Copy(f, io.LimitReader(src, remaining+1))
+	if n > remaining {
+		n = remaining
+		if err := f.Truncate(info.Length); err != nil {
+			_ = f.Close()
+			return info, fmt.Errorf("could not truncate upload: %v", err)
+		}
+		copyErr = fmt.Errorf("%d: %w", info.Length, ErrExceedsLength)
+	}
+	if err := f.Close(); err != nil {
+		return info, fmt.Errorf("could not close upload: %v", err)
+	}
+	// The bytes received before an interrupted request are kept, so the client can resume from them.
+	info.Offset += n
+	info.ExpiresAt = s.now().Add(s.ttl)
+	if err := s.writeInfo(info); err != nil {
+		return info, err
+	}
+	return info, copyErr
+}
+
+func (s *store) Open(id string) (files.File, error) {
+	if _, err := s.GetInfo(id); err != nil {
+		return nil, err
+	}
+	return s.Afs.Open(id + dataExt)
+}
+
+func (s *store) Remove(id string) error {
+	if _, err := s.readInfo(id); err != nil {
+		return err
+	}
+	if err := s.Afs.Remove(id + dataExt); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove upload: %v", err)
+	}
+	if err := s.Afs.Remove(id + infoExt); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove upload: %v", err)
+	}
+	s.mu.Lock()
+	delete(s.locks, id)
+	s.mu.Unlock()
+	return nil
+}
+
+// RemoveExpired removes every upload whose expiration has passed and returns their ids
+func (s *store) RemoveExpired() ([]string, error) {
+	infos, err := s.Afs.ReadDir("")
+	if err != nil {
+		return nil, fmt.Errorf("could not list uploads: %v", err)
+	}
+	var removed []string
+	for _, fi := range infos {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), infoExt) {
+			continue
+		}
+		id := strings.TrimSuffix(fi.Name(), infoExt)
+		info, err := s.readInfo(id)
+		if err != nil {
+			return removed, err
+		}
+		if !s.isExpired(info) {
+			continue
+		}
+		if err := s.Remove(id); err != nil {
+			return removed, err
+		}
+		removed = append(removed, id)
+	}
+	return removed, nil
+}
+
+func (s *store) isExpired(info Info) bool {
+	return !info.ExpiresAt.IsZero() && s.now().After(info.ExpiresAt)
+}
+
+func (s *store) lock(id string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[id] = l
+	}
+	return l
+}
+
+func (s *store) readInfo(id string) (Info, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return Info{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+	}
+	bs, err := s.Afs.ReadFile(id + infoExt)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Info{}, fmt.Errorf("%s: %w", id, logger.ErrNotFound)
+		}
+		return Info{}, fmt.Errorf("could not read upload info: %v", err)
+	}
+	var info Info
+	if err := json.Unmarshal(bs, &info); err != nil {
+		return Info{}, fmt.Errorf("could not decode upload info: %v", err)
+	}
+	return info, nil
+}
+
+func (s *store) writeInfo(info Info) error {
+	bs, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("could not encode upload info: %v", err)
+	}
+	if err := s.Afs.WriteFile(info.ID+infoExt, bs, 0644); err != nil {
+		return fmt.Errorf("could not write upload info: %v", err)
+	}
+	return nil
+}
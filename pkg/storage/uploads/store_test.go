@@ -0,0 +1,152 @@
+package uploads
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/selmison/code-micro-videos/pkg/logger"
+)
+
+const fakeTTL = time.Hour
+
+func setupStoreTestCase(t *testing.T) (*store, func(t *testing.T)) {
+	rootDir, err := ioutil.TempDir("", "uploads-store")
+	if err != nil {
+		t.Fatalf("test: failed to make root directory: %v\n", err)
+	}
+	s := NewStore(afero.NewBasePathFs(afero.NewOsFs(), rootDir), fakeTTL)
+	return s, func(t *testing.T) {
+		if err := os.RemoveAll(rootDir); err != nil {
+			t.Errorf("test: failed to remove root directory: %v", err)
+		}
+	}
+}
+
+func Test_store_WriteChunk(t *testing.T) {
+	s, teardownTestCase := setupStoreTestCase(t)
+	defer teardownTestCase(t)
+	fakeData := []byte("0123456789")
+	info, err := s.Create(int64(len(fakeData)), map[string]string{"title": "fake"})
+	if err != nil {
+		t.Fatalf("Create() error: %v\n", err)
+	}
+	type args struct {
+		offset int64
+		chunk  []byte
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantOffset int64
+		wantErr    error
+	}{
+		{
+			name:       "when the first chunk is sent",
+			args:       args{0, fakeData[:4]},
+			wantOffset: 4,
+			wantErr:    nil,
+		},
+		{
+			name:       "when the offset does not match",
+			args:       args{2, fakeData[2:6]},
+			wantOffset: 4,
+			wantErr:    ErrOffsetMismatch,
+		},
+		{
+			name:       "when the chunk exceeds the length",
+			args:       args{4, append(fakeData[4:], 'x')},
+			wantOffset: 10,
+			wantErr:    ErrExceedsLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.WriteChunk(info.ID, tt.args.offset, bytes.NewReader(tt.args.chunk))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WriteChunk() error: %v, wantErr: %v\n", err, tt.wantErr)
+			}
+			if got.Offset != tt.wantOffset {
+				t.Fatalf("WriteChunk() offset: %d, want: %d\n", got.Offset, tt.wantOffset)
+			}
+		})
+	}
+	f, err := s.Open(info.ID)
+	if err != nil {
+		t.Fatalf("Open() error: %v\n", err)
+	}
+	defer f.Close()
+	got, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatalf("test: could not read upload: %v\n", err)
+	}
+	if !bytes.Equal(got, fakeData) {
+		t.Fatalf("got: %s, want: %s\n", got, fakeData)
+	}
+}
+
+func Test_store_RemoveExpired(t *testing.T) {
+	s, teardownTestCase := setupStoreTestCase(t)
+	defer teardownTestCase(t)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	expired, err := s.Create(10, nil)
+	if err != nil {
+		t.Fatalf("Create() error: %v\n", err)
+	}
+	now = now.Add(fakeTTL / 2)
+	alive, err := s.Create(10, nil)
+	if err != nil {
+		t.Fatalf("Create() error: %v\n", err)
+	}
+	now = now.Add(fakeTTL/2 + time.Second)
+	if _, err := s.GetInfo(expired.ID); !errors.Is(err, ErrExpired) {
+		t.Fatalf("GetInfo() error: %v, wantErr: %v\n", err, ErrExpired)
+	}
+	removed, err := s.RemoveExpired()
+	if err != nil {
+		t.Fatalf("RemoveExpired() error: %v\n", err)
+	}
+	if len(removed) != 1 || removed[0] != expired.ID {
+		t.Fatalf("RemoveExpired() got: %v, want: [%s]\n", removed, expired.ID)
+	}
+	if _, err := s.GetInfo(expired.ID); !errors.Is(err, logger.ErrNotFound) {
+		t.Fatalf("GetInfo() error: %v, wantErr: %v\n", err, logger.ErrNotFound)
+	}
+	if _, err := s.GetInfo(alive.ID); err != nil {
+		t.Fatalf("GetInfo() error: %v\n", err)
+	}
+}
+
+func Test_store_GetInfo(t *testing.T) {
+	s, teardownTestCase := setupStoreTestCase(t)
+	defer teardownTestCase(t)
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{
+			name:    "when id is not an upload id",
+			id:      "../../etc/passwd",
+			wantErr: logger.ErrNotFound,
+		},
+		{
+			name:    "when upload does not exist",
+			id:      "0b4f8b44-5b8d-4c1c-9d54-0d3a4b6d1f00",
+			wantErr: logger.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.GetInfo(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetInfo() error: %v, wantErr: %v\n", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package testdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math/rand"
+
+	"github.com/selmison/code-micro-videos/pkg/crud"
+)
+
+var (
+	fakeMP4Header = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	fakePNGHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A")
+	// fakePNGIHDR describes a 1x1 RGB image
+	fakePNGIHDR = []byte("IHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+)
+
+// FakeMP4 builds a file sniffed as video/mp4, its ftyp box followed by an mdat box of size random bytes
+func FakeMP4(size int) []byte {
+	header := make([]byte, len(fakeMP4Header)+8)
+	copy(header, fakeMP4Header)
+	binary.BigEndian.PutUint32(header[len(fakeMP4Header):], uint32(8+size))
+	copy(header[len(fakeMP4Header)+4:], "mdat")
+	return fakeFile(header, size)
+}
+
+// FakePNG builds a file sniffed as image/png, its signature and IHDR chunk followed by size random bytes
+func FakePNG(size int) []byte {
+	header := make([]byte, len(fakePNGHeader), len(fakePNGHeader)+4+len(fakePNGIHDR)+4)
+	copy(header, fakePNGHeader)
+	header = append(header, 0, 0, 0, byte(len(fakePNGIHDR)-4))
+	header = append(header, fakePNGIHDR...)
+	var crc [4]byte
+	binary.BigEndian.PutUint32(crc[:], crc32.ChecksumIEEE(fakePNGIHDR))
+	header = append(header, crc[:]...)
+	return fakeFile(header, size)
+}
+
+func fakeFile(header []byte, size int) []byte {
+	data := make([]byte, len(header)+size)
+	copy(data, header)
+	rand.Read(data[len(header):])
+	return data
+}
+
+// FakeAssets is an asset source that yields its files in order
+type FakeAssets []FakeAsset
+
+type FakeAsset struct {
+	Kind crud.AssetKind
+	Data []byte
+}
+
+func (a *FakeAssets) NextAsset() (crud.AssetKind, io.Reader, error) {
+	if len(*a) == 0 {
+		return "", nil, io.EOF
+	}
+	asset := (*a)[0]
+	*a = (*a)[1:]
+	return asset.Kind, bytes.NewReader(asset.Data), nil
+}
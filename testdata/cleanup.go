@@ -18,6 +18,9 @@ func ClearTables(dbDriver, dbConnStr string) error {
 	if _, err = db.Exec("DELETE FROM videos"); err != nil {
 		return err
 	}
+	if _, err = db.Exec("DELETE FROM blobs"); err != nil {
+		return err
+	}
 	if _, err = db.Exec("DELETE FROM categories"); err != nil {
 		return err
 	}
@@ -27,5 +30,11 @@ func ClearTables(dbDriver, dbConnStr string) error {
 	if _, err = db.Exec("DELETE FROM cast_members"); err != nil {
 		return err
 	}
+	if _, err = db.Exec("DELETE FROM audit_events"); err != nil {
+		return err
+	}
+	if _, err = db.Exec("DELETE FROM api_keys"); err != nil {
+		return err
+	}
 	return nil
 }
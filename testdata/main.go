@@ -3,6 +3,7 @@ package testdata
 import (
 	"math/rand"
 	"strings"
+	"time"
 
 	"github.com/Pallinder/go-randomdata"
 	"github.com/bluele/factory-go/factory"
@@ -33,12 +34,12 @@ var (
 			desc = null.String{String: randomdata.Paragraph(), Valid: true}
 		}
 		return desc, nil
-	}).Attr("IsValidated", func(args factory.Args) (interface{}, error) {
-		isValidated := true
+	}).Attr("DeletedAt", func(args factory.Args) (interface{}, error) {
+		var deletedAt null.Time
 		if rand.Intn(2) == 0 {
-			isValidated = false
+			deletedAt = null.TimeFrom(time.Now())
 		}
-		return isValidated, nil
+		return deletedAt, nil
 	})
 
 	genreFactory = factory.NewFactory(
@@ -47,12 +48,12 @@ var (
 		return uuid.New().String(), nil
 	}).Attr("Name", func(args factory.Args) (interface{}, error) {
 		return strings.ToLower(randomdata.SillyName()), nil
-	}).Attr("IsValidated", func(args factory.Args) (interface{}, error) {
-		isValidated := true
+	}).Attr("DeletedAt", func(args factory.Args) (interface{}, error) {
+		var deletedAt null.Time
 		if rand.Intn(2) == 0 {
-			isValidated = false
+			deletedAt = null.TimeFrom(time.Now())
 		}
-		return isValidated, nil
+		return deletedAt, nil
 	})
 
 	videoFactory = factory.NewFactory(
@@ -87,6 +88,8 @@ var (
 		}(), nil
 	}).Attr("Duration", func(args factory.Args) (interface{}, error) {
 		return int16(randomdata.Number(1, 300)), nil
+	}).Attr("Language", func(args factory.Args) (interface{}, error) {
+		return "english", nil
 	}).SubFactory("R", videoRFactory)
 
 	videoRFactory = factory.NewFactory(
@@ -198,6 +201,7 @@ func init() {
 	FakeCategoriesDTO = make([]crud.CategoryDTO, len(FakeCategories))
 	for i, category := range FakeCategories {
 		FakeCategoriesDTO[i] = crud.CategoryDTO{
+			ID:          category.ID,
 			Name:        category.Name,
 			Description: category.Description.String,
 		}
@@ -205,12 +209,14 @@ func init() {
 	FakeGenresDTO = make([]crud.GenreDTO, len(FakeGenres))
 	for i, user := range FakeGenres {
 		FakeGenresDTO[i] = crud.GenreDTO{
+			ID:   user.ID,
 			Name: user.Name,
 		}
 	}
 	FakeCastMembersDTO = make([]crud.CastMemberDTO, len(FakeCastMembers))
 	for i, castMember := range FakeCastMembers {
 		FakeCastMembersDTO[i] = crud.CastMemberDTO{
+			ID:   castMember.ID,
 			Name: castMember.Name,
 			Type: crud.CastMemberType(castMember.Type),
 		}
@@ -227,6 +233,7 @@ func generateFakeVideos(length int) ([]models.Video, []crud.VideoDTO, models.Vid
 		categoriesDTO := make([]crud.CategoryDTO, len(video.R.Categories))
 		for i, category := range video.R.Categories {
 			categoriesDTO[i] = crud.CategoryDTO{
+				ID:          category.ID,
 				Name:        category.Name,
 				Description: category.Description.String,
 			}
@@ -234,6 +241,7 @@ func generateFakeVideos(length int) ([]models.Video, []crud.VideoDTO, models.Vid
 		genresDTO := make([]crud.GenreDTO, len(video.R.Genres))
 		for i, genre := range video.R.Genres {
 			genresDTO[i] = crud.GenreDTO{
+				ID:   genre.ID,
 				Name: genre.Name,
 			}
 		}
@@ -242,6 +250,7 @@ func generateFakeVideos(length int) ([]models.Video, []crud.VideoDTO, models.Vid
 		rating := crud.VideoRating(video.Rating)
 		duration := video.Duration
 		fakeVideosDTO[i] = crud.VideoDTO{
+			ID:           video.ID,
 			Title:        video.Title,
 			Description:  video.Description,
 			YearLaunched: &yearLaunched,
@@ -250,6 +259,8 @@ func generateFakeVideos(length int) ([]models.Video, []crud.VideoDTO, models.Vid
 			Duration:     &duration,
 			Categories:   categoriesDTO,
 			Genres:       genresDTO,
+			CastMembers:  []crud.VideoCastMemberDTO{},
+			Language:     video.Language,
 		}
 	}
 	fakeVideoSlice := make([]*models.Video, length)
@@ -262,6 +273,7 @@ func generateFakeVideos(length int) ([]models.Video, []crud.VideoDTO, models.Vid
 			Opened:       video.Opened,
 			Rating:       video.Rating,
 			Duration:     video.Duration,
+			Language:     video.Language,
 			R:            video.R,
 		}
 	}